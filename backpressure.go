@@ -0,0 +1,209 @@
+package rxgo
+
+import (
+	"context"
+	"sync"
+)
+
+// observerBuffer holds the items sent to an observer channel according to a backpressure strategy. With Drop,
+// Latest and Fail, the pending items are held by a ring buffer, from which a goroutine forwards them to the
+// unbuffered observer channel: an item is only removed from the buffer once received by the observer.
+//
+// The ring buffer is used without lock with Drop and Fail, the producer only pushing items. With Latest, the
+// producer drops the oldest items: the ring buffer is then locked.
+type observerBuffer struct {
+	ch       chan Item
+	ctx      context.Context
+	strategy BackpressureStrategy
+	metrics  Metrics
+	ring     *ringBuffer
+	// mutex synchronizes the producer dropping the oldest items with the forwarding goroutine, with Latest
+	mutex *sync.Mutex
+	// failure holds the BackpressureError of Fail, forwarded once the pending items are
+	failure   chan Item
+	signal    chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// newObserverBuffer creates the buffer of an observer holding capacity items along with the capacity set by
+// WithBufferedChannel. With Latest and Fail, it holds at least one item. metrics may be nil.
+func newObserverBuffer(ctx context.Context, option Option, capacity int, metrics Metrics) *observerBuffer {
+	capacity += option.getBufferedChannelCapacity()
+	b := &observerBuffer{
+		ctx:      ctx,
+		strategy: option.getBackPressureStrategy(),
+		metrics:  metrics,
+	}
+	switch b.strategy {
+	case Latest:
+		if capacity == 0 {
+			capacity = 1
+		}
+		b.mutex = &sync.Mutex{}
+	case Fail:
+		if capacity == 0 {
+			capacity = 1
+		}
+		b.failure = make(chan Item, 1)
+	case Drop:
+		if capacity == 0 {
+			// The items are dropped unless the observer is ready
+			b.ch = make(chan Item)
+			return b
+		}
+	default:
+		b.ch = make(chan Item, capacity)
+		return b
+	}
+	b.ch = make(chan Item)
+	b.ring = newRingBuffer(capacity)
+	b.signal = make(chan struct{}, 1)
+	b.closed = make(chan struct{})
+	go b.forward()
+	return b
+}
+
+// send sends an item according to the backpressure strategy. It returns false if the observer has to be terminated.
+func (b *observerBuffer) send(item Item) bool {
+	if b.ring == nil {
+		sent := false
+		if b.strategy == Drop {
+			sent = item.SendNonBlocking(b.ch)
+		} else {
+			sent = item.SendContext(b.ctx, b.ch)
+		}
+		b.record(sent, !sent)
+		return true
+	}
+
+	terminated := false
+	b.lock()
+	switch b.strategy {
+	case Drop:
+		b.record(b.ring.push(item), true)
+	case Latest:
+		for !b.ring.push(item) {
+			b.ring.pop()
+			b.record(false, true)
+		}
+		b.record(true, false)
+	case Fail:
+		if b.ring.push(item) {
+			b.record(true, false)
+			break
+		}
+		// The items already accepted are delivered before the error
+		b.failure <- Error(BackpressureError{error: "observer not ready"})
+		if b.metrics != nil {
+			b.metrics.ItemDropped()
+			b.metrics.ErrorEmitted()
+		}
+		terminated = true
+	}
+	b.unlock()
+	b.notify()
+	return !terminated
+}
+
+// notify wakes up the forwarding goroutine once the buffer has changed.
+func (b *observerBuffer) notify() {
+	select {
+	case b.signal <- struct{}{}:
+	default:
+	}
+}
+
+// fill sends items to the observer, without recording them, the buffer having room for them.
+func (b *observerBuffer) fill(items ...Item) {
+	for _, item := range items {
+		if b.ring == nil {
+			b.ch <- item
+		} else {
+			b.ring.push(item)
+		}
+	}
+	if b.ring != nil {
+		b.notify()
+	}
+}
+
+// record records an item sent, or dropped if not sent.
+func (b *observerBuffer) record(sent, droppable bool) {
+	if b.metrics == nil {
+		return
+	}
+	switch {
+	case sent:
+		b.metrics.ItemEmitted()
+	case droppable:
+		b.metrics.ItemDropped()
+	}
+}
+
+// close closes the observer channel once the pending items are forwarded.
+func (b *observerBuffer) close() {
+	if b.ring == nil {
+		close(b.ch)
+		return
+	}
+	b.closeOnce.Do(func() {
+		close(b.closed)
+	})
+}
+
+func (b *observerBuffer) forward() {
+	defer close(b.ch)
+	for {
+		b.lock()
+		item, index, ok := b.ring.peek()
+		b.unlock()
+		if !ok {
+			select {
+			case failure := <-b.failure:
+				failure.SendContext(b.ctx, b.ch)
+				return
+			default:
+			}
+			select {
+			case <-b.ctx.Done():
+				return
+			case <-b.signal:
+			case <-b.closed:
+				b.lock()
+				_, _, ok = b.ring.peek()
+				b.unlock()
+				if !ok && len(b.failure) == 0 {
+					return
+				}
+			}
+			continue
+		}
+
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-b.signal:
+			// The buffer has changed, the item may have been dropped
+		case b.ch <- item:
+			b.lock()
+			// Unless dropped in the meantime
+			if _, head, ok := b.ring.peek(); ok && head == index {
+				b.ring.pop()
+			}
+			b.unlock()
+		}
+	}
+}
+
+func (b *observerBuffer) lock() {
+	if b.mutex != nil {
+		b.mutex.Lock()
+	}
+}
+
+func (b *observerBuffer) unlock() {
+	if b.mutex != nil {
+		b.mutex.Unlock()
+	}
+}
@@ -0,0 +1,70 @@
+package rxgo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ObserverBuffer_Latest(t *testing.T) {
+	b := newObserverBuffer(context.Background(), parseOptions(WithBackPressureStrategy(Latest)), 2, nil)
+	for i := 0; i < 5; i++ {
+		assert.True(t, b.send(Of(i)))
+	}
+	b.close()
+	// The pending items are forwarded once closed
+	items, err := collect(context.Background(), b.ch)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{3, 4}, items)
+}
+
+func Test_ObserverBuffer_Drop(t *testing.T) {
+	metrics := &recordingMetrics{}
+	b := newObserverBuffer(context.Background(), parseOptions(WithBackPressureStrategy(Drop)), 2, metrics)
+	for i := 0; i < 5; i++ {
+		assert.True(t, b.send(Of(i)))
+	}
+	b.close()
+	items, err := collect(context.Background(), b.ch)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{0, 1}, items)
+	emitted, _, _, dropped := metrics.counts()
+	assert.Equal(t, 2, emitted)
+	assert.Equal(t, 3, dropped)
+}
+
+func Test_ObserverBuffer_Fail(t *testing.T) {
+	b := newObserverBuffer(context.Background(), parseOptions(WithBackPressureStrategy(Fail)), 2, nil)
+	assert.True(t, b.send(Of(1)))
+	assert.True(t, b.send(Of(2)))
+	assert.False(t, b.send(Of(3)))
+	b.close()
+	items, err := collect(context.Background(), b.ch)
+	assert.NoError(t, err)
+	// The items accepted are not dropped for the error
+	assert.Len(t, items, 3)
+	assert.Equal(t, []interface{}{1, 2}, items[:2])
+	assert.IsType(t, BackpressureError{}, items[2])
+}
+
+func Test_ObserverBuffer_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	b := newObserverBuffer(ctx, parseOptions(WithBackPressureStrategy(Latest)), 1, nil)
+	b.send(Of(1))
+	cancel()
+	// The channel is closed without waiting for the observer
+	for range b.ch {
+	}
+}
+
+func Test_ObserverBuffer_NoAllocation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b := newObserverBuffer(ctx, parseOptions(WithBackPressureStrategy(Latest)), 16, nil)
+	item := Of(1)
+	allocs := testing.AllocsPerRun(1000, func() {
+		b.send(item)
+	})
+	assert.Zero(t, allocs)
+}
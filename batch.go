@@ -0,0 +1,85 @@
+package rxgo
+
+import (
+	"context"
+	"sync"
+)
+
+// itemBatch is the envelope of a batch of items sent by a source to an operator created with WithBatchSize. The
+// envelopes are pooled: the operator releases one once its items are processed, so that a batched pipeline
+// allocates no envelope in the steady state.
+type itemBatch struct {
+	items []Item
+}
+
+var batchPool = sync.Pool{
+	New: func() interface{} {
+		return &itemBatch{}
+	},
+}
+
+// newItemBatch returns an empty envelope from the pool, holding at least size items.
+func newItemBatch(size int) *itemBatch {
+	batch := batchPool.Get().(*itemBatch)
+	if cap(batch.items) < size {
+		batch.items = make([]Item, 0, size)
+	}
+	return batch
+}
+
+// release puts the envelope back in the pool. It must not be used afterwards.
+func (b *itemBatch) release() {
+	for i := range b.items {
+		// The envelope no longer references the values
+		b.items[i] = Item{}
+	}
+	b.items = b.items[:0]
+	batchPool.Put(b)
+}
+
+// batchSender sends items to a channel in batches, if a batch size greater than one is requested.
+type batchSender struct {
+	ctx   context.Context
+	ch    chan<- Item
+	size  int
+	batch *itemBatch
+}
+
+// newBatchSender creates a batchSender with the batch size requested by the operator observing the source.
+// opts are the options passed to Observe, as the request is not propagated further upstream.
+func newBatchSender(ctx context.Context, ch chan<- Item, opts ...Option) *batchSender {
+	return &batchSender{
+		ctx:  ctx,
+		ch:   ch,
+		size: parseOptions(opts...).getBatchRequest(),
+	}
+}
+
+// send sends an item, or adds it to the current batch. It returns false once the context is cancelled.
+func (s *batchSender) send(item Item) bool {
+	if s.size <= 1 {
+		return item.SendContext(s.ctx, s.ch)
+	}
+	if s.batch == nil {
+		s.batch = newItemBatch(s.size)
+	}
+	s.batch.items = append(s.batch.items, item)
+	if len(s.batch.items) < s.size {
+		return true
+	}
+	return s.flush()
+}
+
+// flush sends the current batch, if any.
+func (s *batchSender) flush() bool {
+	if s.batch == nil {
+		return true
+	}
+	batch := s.batch
+	s.batch = nil
+	if !Of(batch).SendContext(s.ctx, s.ch) {
+		batch.release()
+		return false
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package rxgo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithBatchSize(t *testing.T) {
+	obs := Range(0, 9).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i.(int) * 10, nil
+	}, WithBatchSize(4))
+	Assert(context.Background(), t, obs, HasItems(0, 10, 20, 30, 40, 50, 60, 70, 80, 90), HasNoError())
+}
+
+func Test_WithBatchSize_Request(t *testing.T) {
+	observe := Range(0, 4).Observe(requestBatches(2))
+	assert.Equal(t, []Item{Of(0), Of(1)}, (<-observe).V.(*itemBatch).items)
+	assert.Equal(t, []Item{Of(2), Of(3)}, (<-observe).V.(*itemBatch).items)
+	assert.Equal(t, []Item{Of(4)}, (<-observe).V.(*itemBatch).items)
+
+	// The batches are only sent to the operators requesting them
+	Assert(context.Background(), t, Range(0, 4), HasItems(0, 1, 2, 3, 4))
+	observe = Just(1, 2)().Observe(WithBatchSize(2))
+	assert.Equal(t, 1, (<-observe).V)
+}
+
+func Test_WithBatchSize_Error(t *testing.T) {
+	obs := Just(1, errFoo, 3)().Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	}, WithBatchSize(10))
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errFoo))
+}
+
+func Test_WithBatchSize_StopMidBatch(t *testing.T) {
+	obs := Range(1, 9).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		if i == 3 {
+			return nil, errFoo
+		}
+		return i, nil
+	}, WithBatchSize(10))
+	Assert(context.Background(), t, obs, HasItems(1, 2), HasError(errFoo))
+}
+
+func Test_WithBatchSize_Fusion(t *testing.T) {
+	obs := Range(0, 9).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		}, WithBatchSize(4)).
+		Filter(func(i interface{}) bool {
+			return i.(int)%2 == 0
+		})
+	Assert(context.Background(), t, obs, HasItems(0, 2, 4, 6, 8), HasNoError())
+	assert.Equal(t, 4, obs.(*ObservableImpl).fusion.batchSize)
+}
+
+func Test_WithBatchSize_NotPropagated(t *testing.T) {
+	// The parallel operator observes its source item by item
+	obs := Range(0, 9).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		}, WithPool(2)).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		}, WithBatchSize(4))
+	Assert(context.Background(), t, obs, HasItemsNoOrder(0, 1, 2, 3, 4, 5, 6, 7, 8, 9), HasNoError())
+}
+
+func Test_ItemBatch_Release(t *testing.T) {
+	batch := newItemBatch(2)
+	batch.items = append(batch.items, Of(1), Of(2))
+	items := batch.items[:2]
+	batch.release()
+	// The values are not retained by the pooled envelope
+	assert.Equal(t, []Item{{}, {}}, items)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		batch := newItemBatch(2)
+		batch.items = append(batch.items, Of(1), Of(2))
+		batch.release()
+	})
+	assert.Zero(t, allocs)
+}
@@ -0,0 +1,306 @@
+package rxgo
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time consulted by the time-based operators.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+	// NewTimer creates a ClockTimer sending the current time on its channel after at least duration d.
+	NewTimer(d time.Duration) ClockTimer
+	// NewTicker creates a ClockTicker sending the current time on its channel every period d, which must be
+	// positive.
+	NewTicker(d time.Duration) ClockTicker
+}
+
+// ClockTimer is a single event timer created by a Clock.
+type ClockTimer interface {
+	// C returns the channel on which the time is delivered.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing. It returns false if the timer has already fired or been stopped.
+	Stop() bool
+}
+
+// ClockTicker is a periodic timer created by a Clock.
+type ClockTicker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+	// Stop turns off the ticker. No more ticks are sent once it returns.
+	Stop()
+}
+
+// ClockOf returns the Clock set with WithClock among the options, the wall clock otherwise, so that a source built
+// outside of this package follows the same time as the operators.
+func ClockOf(opts ...Option) Clock {
+	return parseOptions(opts...).getClock()
+}
+
+type realClock struct{}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+type realTicker struct {
+	ticker *time.Ticker
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) NewTimer(d time.Duration) ClockTimer {
+	return &realTimer{timer: time.NewTimer(d)}
+}
+
+func (realClock) NewTicker(d time.Duration) ClockTicker {
+	return &realTicker{ticker: time.NewTicker(d)}
+}
+
+func (t *realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t *realTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+func (t *realTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+func (t *realTicker) Stop() {
+	t.ticker.Stop()
+}
+
+// TestScheduler is a virtual Clock. Time only moves forward when AdvanceTimeBy
+// or AdvanceTimeTo is called, so timed pipelines can be tested instantly.
+//
+// A fired timer hands its value over to its receiver: AdvanceTimeTo waits until the value is received, or the timer
+// stopped, before firing the next timer and before returning. The channel of a fired timer must therefore be read
+// by another goroutine than the one advancing the time, or the timer stopped. The goroutines registering timers
+// asynchronously, e.g. once they received an item, are awaited with BlockUntil.
+type TestScheduler struct {
+	mutex   sync.Mutex
+	changed *sync.Cond
+	now     time.Time
+	seq     int
+	timers  []*virtualTimer
+	// created counts the timers and tickers created
+	created int
+}
+
+type timerState int
+
+const (
+	timerPending timerState = iota
+	timerFiring
+	timerDone
+)
+
+// virtualTimer is a timer of a TestScheduler, or the timer of a virtualTicker if its period is positive.
+type virtualTimer struct {
+	scheduler *TestScheduler
+	deadline  time.Time
+	period    time.Duration
+	seq       int
+	state     timerState
+	ch        chan time.Time
+	stopped   chan struct{}
+}
+
+type virtualTicker struct {
+	timer *virtualTimer
+}
+
+// NewTestScheduler creates a TestScheduler whose virtual time starts at the given time.
+func NewTestScheduler(start time.Time) *TestScheduler {
+	s := &TestScheduler{now: start}
+	s.changed = sync.NewCond(&s.mutex)
+	return s
+}
+
+// Now returns the current virtual time.
+func (s *TestScheduler) Now() time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.now
+}
+
+// After registers a virtual timer firing once the virtual time has advanced by d. The timer cannot be stopped: its
+// channel has to be read once it fired.
+func (s *TestScheduler) After(d time.Duration) <-chan time.Time {
+	return s.NewTimer(d).C()
+}
+
+// NewTimer registers a virtual timer firing once the virtual time has advanced by d. A timer with a non-positive
+// duration fires immediately, without being handed over.
+func (s *TestScheduler) NewTimer(d time.Duration) ClockTimer {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if d <= 0 {
+		timer := &virtualTimer{
+			scheduler: s,
+			state:     timerDone,
+			ch:        make(chan time.Time, 1),
+		}
+		timer.ch <- s.now
+		return timer
+	}
+	return s.register(s.now.Add(d), 0)
+}
+
+// NewTicker registers a virtual ticker firing each time the virtual time has advanced by d. The next tick is
+// registered once the previous one is received.
+func (s *TestScheduler) NewTicker(d time.Duration) ClockTicker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return virtualTicker{timer: s.register(s.now.Add(d), d)}
+}
+
+// register registers a timer, the mutex being locked.
+func (s *TestScheduler) register(deadline time.Time, period time.Duration) *virtualTimer {
+	s.created++
+	s.seq++
+	timer := &virtualTimer{
+		scheduler: s,
+		deadline:  deadline,
+		period:    period,
+		seq:       s.seq,
+		ch:        make(chan time.Time),
+		stopped:   make(chan struct{}),
+	}
+	s.timers = append(s.timers, timer)
+	s.changed.Broadcast()
+	return timer
+}
+
+// BlockUntil waits until at least n timers and tickers are registered, i.e. until the goroutines consuming the
+// virtual time are waiting for it to advance.
+func (s *TestScheduler) BlockUntil(n int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for len(s.timers) < n {
+		s.changed.Wait()
+	}
+}
+
+// AdvanceTimeBy moves the virtual time forward by d, firing the due timers.
+func (s *TestScheduler) AdvanceTimeBy(d time.Duration) {
+	s.AdvanceTimeTo(s.Now().Add(d))
+}
+
+// AdvanceTimeTo moves the virtual time forward to t, firing the due timers in chronological order. Each timer is
+// handed over to its receiver before the next one fires.
+func (s *TestScheduler) AdvanceTimeTo(t time.Time) {
+	for {
+		s.mutex.Lock()
+		timer := s.popDueTimer(t)
+		if timer == nil {
+			if t.After(s.now) {
+				s.now = t
+			}
+			s.mutex.Unlock()
+			return
+		}
+		if timer.deadline.After(s.now) {
+			s.now = timer.deadline
+		}
+		now := s.now
+		timer.state = timerFiring
+		s.mutex.Unlock()
+
+		select {
+		case timer.ch <- now:
+			s.fired(timer)
+		case <-timer.stopped:
+		}
+	}
+}
+
+// fired registers the next tick of a ticker once its receiver took the value of a tick, and completes a timer.
+func (s *TestScheduler) fired(timer *virtualTimer) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if timer.state != timerFiring {
+		return
+	}
+	if timer.period <= 0 {
+		timer.state = timerDone
+		return
+	}
+	s.seq++
+	timer.deadline = timer.deadline.Add(timer.period)
+	timer.seq = s.seq
+	timer.state = timerPending
+	s.timers = append(s.timers, timer)
+	s.changed.Broadcast()
+}
+
+func (s *TestScheduler) popDueTimer(t time.Time) *virtualTimer {
+	idx := -1
+	for i, timer := range s.timers {
+		if timer.deadline.After(t) {
+			continue
+		}
+		if idx == -1 || timer.deadline.Before(s.timers[idx].deadline) ||
+			(timer.deadline.Equal(s.timers[idx].deadline) && timer.seq < s.timers[idx].seq) {
+			idx = i
+		}
+	}
+	if idx == -1 {
+		return nil
+	}
+	timer := s.timers[idx]
+	s.timers = append(s.timers[:idx], s.timers[idx+1:]...)
+	s.changed.Broadcast()
+	return timer
+}
+
+func (t *virtualTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+// Stop removes the timer, or releases AdvanceTimeTo if the timer is being handed over. It returns false once the
+// value is received.
+func (t *virtualTimer) Stop() bool {
+	s := t.scheduler
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	switch t.state {
+	case timerPending:
+		for i, timer := range s.timers {
+			if timer == t {
+				s.timers = append(s.timers[:i], s.timers[i+1:]...)
+				break
+			}
+		}
+		s.changed.Broadcast()
+	case timerFiring:
+		close(t.stopped)
+	default:
+		return false
+	}
+	t.state = timerDone
+	return true
+}
+
+func (t virtualTicker) C() <-chan time.Time {
+	return t.timer.ch
+}
+
+func (t virtualTicker) Stop() {
+	t.timer.Stop()
+}
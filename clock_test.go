@@ -0,0 +1,147 @@
+package rxgo
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// receive reads the value of a timer on another goroutine, as AdvanceTimeTo waits for it to be received.
+func receive(c <-chan time.Time) <-chan time.Time {
+	received := make(chan time.Time, 1)
+	go func() {
+		received <- <-c
+	}()
+	return received
+}
+
+// awaitCreated waits until n timers and tickers have been created by the TestScheduler, i.e. until the operator
+// under test has processed the items registering them.
+func awaitCreated(s *TestScheduler, n int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for s.created < n {
+		s.changed.Wait()
+	}
+}
+
+func Test_TestScheduler_AdvanceTimeBy(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewTestScheduler(start)
+	second := receive(s.After(2 * time.Second))
+	first := receive(s.After(time.Second))
+
+	s.AdvanceTimeBy(500 * time.Millisecond)
+	assert.Equal(t, start.Add(500*time.Millisecond), s.Now())
+	assert.Len(t, first, 0)
+	assert.Len(t, second, 0)
+
+	s.AdvanceTimeBy(time.Second)
+	assert.Equal(t, start.Add(time.Second), <-first)
+	assert.Len(t, second, 0)
+	assert.Equal(t, start.Add(1500*time.Millisecond), s.Now())
+
+	s.AdvanceTimeTo(start.Add(time.Hour))
+	assert.Equal(t, start.Add(2*time.Second), <-second)
+	assert.Equal(t, start.Add(time.Hour), s.Now())
+}
+
+func Test_TestScheduler_ChainedTimers(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewTestScheduler(start)
+	ticks := make(chan time.Time, 3)
+	go func() {
+		// Each timer is registered once the previous one fired
+		for i := 0; i < 3; i++ {
+			ticks <- <-s.After(time.Second)
+		}
+	}()
+
+	for i := 1; i <= 3; i++ {
+		s.BlockUntil(1)
+		s.AdvanceTimeBy(time.Second)
+		assert.Equal(t, start.Add(time.Duration(i)*time.Second), <-ticks)
+	}
+}
+
+func Test_TestScheduler_Ticker(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewTestScheduler(start)
+	ticker := s.NewTicker(time.Second)
+	ticks := make(chan time.Time, 3)
+	go func() {
+		for i := 0; i < 3; i++ {
+			ticks <- <-ticker.C()
+		}
+		ticker.Stop()
+	}()
+
+	// The next tick is registered as soon as the previous one is received
+	s.AdvanceTimeBy(3 * time.Second)
+	assert.Len(t, ticks, 3)
+	for i := 1; i <= 3; i++ {
+		assert.Equal(t, start.Add(time.Duration(i)*time.Second), <-ticks)
+	}
+	s.AdvanceTimeBy(time.Hour)
+}
+
+func Test_TestScheduler_ImmediateTimer(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	assert.Equal(t, time.Time{}, <-s.After(0))
+}
+
+func Test_TestScheduler_Stop(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	timer := s.NewTimer(time.Second)
+	assert.True(t, timer.Stop())
+	assert.False(t, timer.Stop())
+	s.AdvanceTimeBy(time.Minute)
+	assert.Len(t, timer.C(), 0)
+}
+
+func Test_TestScheduler_StopFiring(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	timer := s.NewTimer(time.Second)
+	go func() {
+		// The value is never received: stopping the timer releases AdvanceTimeBy
+		time.Sleep(10 * time.Millisecond)
+		timer.Stop()
+	}()
+	s.AdvanceTimeBy(time.Minute)
+	assert.False(t, timer.Stop())
+}
+
+func Test_TestScheduler_Concurrent(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		// A busy goroutine does not prevent the time from advancing
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+		}
+	}()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s := NewTestScheduler(time.Time{})
+			ticker := s.NewTicker(time.Second)
+			defer ticker.Stop()
+			go func() {
+				for range ticker.C() {
+				}
+			}()
+			s.AdvanceTimeBy(time.Minute)
+			assert.Equal(t, time.Time{}.Add(time.Minute), s.Now())
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,100 @@
+package rxgo
+
+import "context"
+
+// Completable is an observable emitting no item: it only completes or terminates with an error.
+type Completable interface {
+	Iterable
+	AndThen(next Observable, opts ...Option) Observable
+	Await(opts ...Option) error
+	Run(opts ...Option) Disposed
+	ToObservable() Observable
+}
+
+// CompletableImpl implements Completable.
+type CompletableImpl struct {
+	iterable Iterable
+}
+
+// FromAction creates a Completable running the action each time it is observed.
+// The Completable terminates with the error returned by the action, if any.
+func FromAction(action func(ctx context.Context) error, opts ...Option) Completable {
+	return &CompletableImpl{
+		iterable: newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
+			option := parseOptions(mergeOptions(opts, propagatedOptions)...)
+			next := observerChannel(option, 1)
+			ctx := option.buildContext()
+
+			go func() {
+				defer close(next)
+				if err := action(ctx); err != nil {
+					Error(err).SendContext(ctx, next)
+				}
+			}()
+			return next
+		}),
+	}
+}
+
+// AndThen returns an Observable emitting the items of next once the Completable completes.
+// If the Completable terminates with an error, next is not observed.
+func (c *CompletableImpl) AndThen(next Observable, opts ...Option) Observable {
+	return Concat([]Observable{c.ToObservable(), next}, opts...)
+}
+
+// Await waits for the Completable to terminate and returns its error, if any.
+// The error returned is the context one if the context has been cancelled.
+// This method is blocking.
+func (c *CompletableImpl) Await(opts ...Option) error {
+	option := parseOptions(opts...)
+	ctx := option.buildContext()
+
+	observe := c.Observe(opts...)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case item, ok := <-observe:
+			if !ok {
+				return nil
+			}
+			if item.Error() {
+				return item.E
+			}
+		}
+	}
+}
+
+// Observe observes a Completable by returning its channel.
+func (c *CompletableImpl) Observe(opts ...Option) <-chan Item {
+	return c.iterable.Observe(opts...)
+}
+
+// Run creates an observer without consuming the emitted items.
+func (c *CompletableImpl) Run(opts ...Option) Disposed {
+	dispose := make(chan struct{})
+	option := parseOptions(opts...)
+	ctx := option.buildContext()
+
+	go func() {
+		defer close(dispose)
+		observe := c.Observe(opts...)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-observe:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	return dispose
+}
+
+// ToObservable converts a Completable into an Observable emitting no item.
+func (c *CompletableImpl) ToObservable() Observable {
+	return &ObservableImpl{iterable: c.iterable}
+}
@@ -0,0 +1,63 @@
+package rxgo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Completable_FromAction(t *testing.T) {
+	calls := 0
+	c := FromAction(func(_ context.Context) error {
+		calls++
+		return nil
+	})
+	assert.NoError(t, c.Await())
+	assert.NoError(t, c.Await())
+	assert.Equal(t, 2, calls)
+}
+
+func Test_Completable_FromAction_Error(t *testing.T) {
+	c := FromAction(func(_ context.Context) error {
+		return errFoo
+	})
+	assert.Equal(t, errFoo, c.Await())
+}
+
+func Test_Completable_Await_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c := &CompletableImpl{iterable: Never()}
+	assert.Equal(t, context.Canceled, c.Await(WithContext(ctx)))
+}
+
+func Test_Completable_AndThen(t *testing.T) {
+	c := FromAction(func(_ context.Context) error {
+		return nil
+	})
+	Assert(context.Background(), t, c.AndThen(Just(1, 2)()), HasItems(1, 2), HasNoError())
+}
+
+func Test_Completable_AndThen_Error(t *testing.T) {
+	c := FromAction(func(_ context.Context) error {
+		return errFoo
+	})
+	Assert(context.Background(), t, c.AndThen(Just(1, 2)()), IsEmpty(), HasError(errFoo))
+}
+
+func Test_Completable_Run(t *testing.T) {
+	called := false
+	<-FromAction(func(_ context.Context) error {
+		called = true
+		return nil
+	}).Run()
+	assert.True(t, called)
+}
+
+func Test_Completable_ToObservable(t *testing.T) {
+	c := FromAction(func(_ context.Context) error {
+		return nil
+	})
+	Assert(context.Background(), t, c.ToObservable(), IsEmpty(), HasNoError())
+}
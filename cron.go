@@ -0,0 +1,208 @@
+package rxgo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a parsed cron expression, see FromCron.
+type cronSchedule struct {
+	minute, hour, dom, month, dow cronField
+	// domStar and dowStar record whether the day of month or the day of week is unrestricted.
+	domStar, dowStar bool
+	location         *time.Location
+}
+
+// cronField is the set of the values matched by a field, as a bit set.
+type cronField uint64
+
+func (f cronField) has(v int) bool {
+	return f&(1<<uint(v)) != 0
+}
+
+type cronBounds struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinutes = cronBounds{min: 0, max: 59}
+	cronHours   = cronBounds{min: 0, max: 23}
+	cronDoms    = cronBounds{min: 1, max: 31}
+	cronMonths  = cronBounds{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is also Sunday
+	cronDows = cronBounds{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+
+	cronDescriptors = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// parseCron parses a cron expression: either five fields (minute, hour, day of month, month and day of week) or
+// a descriptor such as @daily, optionally prefixed by CRON_TZ=<location> or TZ=<location>.
+func parseCron(spec string) (*cronSchedule, error) {
+	spec = strings.TrimSpace(spec)
+	location := time.Local
+	if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		idx := strings.IndexByte(spec, ' ')
+		if idx == -1 {
+			return nil, fmt.Errorf("missing fields in %q", spec)
+		}
+		name := spec[strings.IndexByte(spec, '=')+1 : idx]
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, err
+		}
+		location = loc
+		spec = strings.TrimSpace(spec[idx:])
+	}
+	if descriptor, ok := cronDescriptors[spec]; ok {
+		spec = descriptor
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, found %d in %q", len(fields), spec)
+	}
+	schedule := &cronSchedule{location: location}
+	var err error
+	if schedule.minute, _, err = parseCronField(fields[0], cronMinutes); err != nil {
+		return nil, err
+	}
+	if schedule.hour, _, err = parseCronField(fields[1], cronHours); err != nil {
+		return nil, err
+	}
+	if schedule.dom, schedule.domStar, err = parseCronField(fields[2], cronDoms); err != nil {
+		return nil, err
+	}
+	if schedule.month, _, err = parseCronField(fields[3], cronMonths); err != nil {
+		return nil, err
+	}
+	if schedule.dow, schedule.dowStar, err = parseCronField(fields[4], cronDows); err != nil {
+		return nil, err
+	}
+	if schedule.dow.has(7) {
+		schedule.dow |= 1
+	}
+	return schedule, nil
+}
+
+// parseCronField parses a comma-separated list of *, values or ranges, each with an optional step.
+// It also returns whether the field is unrestricted.
+func parseCronField(field string, bounds cronBounds) (cronField, bool, error) {
+	var f cronField
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if idx := strings.IndexByte(part, '/'); idx != -1 {
+			s, err := strconv.Atoi(part[idx+1:])
+			if err != nil || s <= 0 {
+				return 0, false, fmt.Errorf("invalid step in %q", part)
+			}
+			rangePart, step = part[:idx], s
+		}
+
+		var low, high int
+		switch {
+		case rangePart == "*" || rangePart == "?":
+			if step == 1 && field == rangePart {
+				for v := bounds.min; v <= bounds.max; v++ {
+					f |= 1 << uint(v)
+				}
+				return f, true, nil
+			}
+			low, high = bounds.min, bounds.max
+		case strings.Contains(rangePart, "-"):
+			idx := strings.IndexByte(rangePart, '-')
+			var err error
+			if low, err = parseCronValue(rangePart[:idx], bounds); err != nil {
+				return 0, false, err
+			}
+			if high, err = parseCronValue(rangePart[idx+1:], bounds); err != nil {
+				return 0, false, err
+			}
+			if low > high {
+				return 0, false, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			v, err := parseCronValue(rangePart, bounds)
+			if err != nil {
+				return 0, false, err
+			}
+			low, high = v, v
+			if step > 1 {
+				high = bounds.max
+			}
+		}
+		for v := low; v <= high; v += step {
+			f |= 1 << uint(v)
+		}
+	}
+	return f, false, nil
+}
+
+func parseCronValue(s string, bounds cronBounds) (int, error) {
+	if v, ok := bounds.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < bounds.min || v > bounds.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, bounds.min, bounds.max)
+	}
+	return v, nil
+}
+
+// dayMatches applies the cron rule: if both the day of month and the day of week are restricted, a day matching
+// either of them matches.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom.has(t.Day())
+	dow := s.dow.has(int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// next returns the first scheduled time strictly after t, or the zero time if there is none within five years.
+func (s *cronSchedule) next(t time.Time) time.Time {
+	// Truncating the absolute time keeps the offset of an ambiguous wall clock time
+	t = t.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		var candidate time.Time
+		switch {
+		case !s.month.has(int(t.Month())):
+			candidate = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+		case !s.dayMatches(t):
+			candidate = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+		case !s.hour.has(t.Hour()):
+			candidate = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+		case !s.minute.has(t.Minute()):
+			candidate = t.Add(time.Minute)
+		default:
+			return t
+		}
+		// With a daylight saving time transition, the wall clock may not move forward
+		if !candidate.After(t) {
+			candidate = t.Add(time.Hour).Truncate(time.Hour)
+		}
+		t = candidate
+	}
+	return time.Time{}
+}
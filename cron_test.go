@@ -0,0 +1,92 @@
+package rxgo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Cron_Next(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	assert.NoError(t, err)
+	from := time.Date(2020, time.January, 31, 10, 17, 30, 0, time.UTC)
+
+	for spec, expected := range map[string]time.Time{
+		"CRON_TZ=UTC * * * * *":                 time.Date(2020, time.January, 31, 10, 18, 0, 0, time.UTC),
+		"CRON_TZ=UTC */15 * * * *":              time.Date(2020, time.January, 31, 10, 30, 0, 0, time.UTC),
+		"CRON_TZ=UTC 5 9-17 * * MON-FRI":        time.Date(2020, time.January, 31, 11, 5, 0, 0, time.UTC),
+		"CRON_TZ=UTC 0 0 * * sat,sun":           time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC),
+		"CRON_TZ=UTC 0 12 31 * *":               time.Date(2020, time.January, 31, 12, 0, 0, 0, time.UTC),
+		"CRON_TZ=UTC 0 0 30 * *":                time.Date(2020, time.March, 30, 0, 0, 0, 0, time.UTC),
+		"CRON_TZ=UTC 0 0 29 feb *":              time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC),
+		"CRON_TZ=UTC 0 0 1 * 7":                 time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC),
+		"CRON_TZ=UTC 30 10/4 * * *":             time.Date(2020, time.January, 31, 10, 30, 0, 0, time.UTC),
+		"TZ=UTC @monthly":                       time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC),
+		"CRON_TZ=Europe/Paris 0 8 * * *":        time.Date(2020, time.February, 1, 8, 0, 0, 0, paris),
+		"CRON_TZ=Europe/Paris @hourly":          time.Date(2020, time.January, 31, 12, 0, 0, 0, paris),
+		"CRON_TZ=Europe/Paris 30 2 * * *":       time.Date(2020, time.February, 1, 2, 30, 0, 0, paris),
+		"CRON_TZ=America/New_York 0 0 1 1 *":    time.Date(2021, time.January, 1, 0, 0, 0, 0, mustLoadLocation(t, "America/New_York")),
+		"CRON_TZ=Europe/Paris 0 0 31 1 *":       time.Date(2021, time.January, 31, 0, 0, 0, 0, paris),
+		"CRON_TZ=UTC 0,30 * * * *":              time.Date(2020, time.January, 31, 10, 30, 0, 0, time.UTC),
+		"CRON_TZ=UTC 0 0 1-7 * MON":             time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC),
+		"  CRON_TZ=UTC   0   11   *   *   *   ": time.Date(2020, time.January, 31, 11, 0, 0, 0, time.UTC),
+	} {
+		schedule, err := parseCron(spec)
+		assert.NoError(t, err, spec)
+		assert.True(t, expected.Equal(schedule.next(from)), "%s: expected %v, got %v", spec, expected, schedule.next(from))
+	}
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	assert.NoError(t, err)
+	return location
+}
+
+func Test_Cron_Next_DaylightSavingTime(t *testing.T) {
+	paris := mustLoadLocation(t, "Europe/Paris")
+	schedule, err := parseCron("CRON_TZ=Europe/Paris 30 2 * * *")
+	assert.NoError(t, err)
+	// 2:30 does not exist on the 29th of March 2020
+	next := schedule.next(time.Date(2020, time.March, 28, 12, 0, 0, 0, paris))
+	assert.Equal(t, time.Date(2020, time.March, 30, 2, 30, 0, 0, paris), next)
+
+	schedule, err = parseCron("CRON_TZ=Europe/Paris 0 * * * *")
+	assert.NoError(t, err)
+	// 2:00 occurs twice on the 25th of October 2020
+	from := time.Date(2020, time.October, 25, 1, 30, 0, 0, paris)
+	var times []time.Time
+	for i := 0; i < 3; i++ {
+		from = schedule.next(from)
+		times = append(times, from)
+	}
+	assert.Equal(t, 2, times[1].Hour())
+	assert.Equal(t, time.Hour, times[2].Sub(times[1]))
+}
+
+func Test_Cron_Next_Never(t *testing.T) {
+	schedule, err := parseCron("0 0 31 feb *")
+	assert.NoError(t, err)
+	assert.True(t, schedule.next(time.Now()).IsZero())
+}
+
+func Test_Cron_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"foo * * * *",
+		"CRON_TZ=Unknown/Location * * * * *",
+		"CRON_TZ=UTC",
+		"@never",
+	} {
+		_, err := parseCron(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,135 @@
+package rxgo
+
+import "sync"
+
+// CompositeDisposable groups Disposables so that they are disposed all at once.
+type CompositeDisposable struct {
+	mutex       sync.Mutex
+	disposables []Disposable
+	disposed    bool
+}
+
+// NewCompositeDisposable creates a CompositeDisposable holding the given Disposables.
+func NewCompositeDisposable(disposables ...Disposable) *CompositeDisposable {
+	c := &CompositeDisposable{}
+	for _, d := range disposables {
+		c.Add(d)
+	}
+	return c
+}
+
+// Add adds a Disposable to the group. If the CompositeDisposable is already disposed, the Disposable is disposed
+// immediately and false is returned.
+func (c *CompositeDisposable) Add(d Disposable) bool {
+	if d == nil {
+		return false
+	}
+	c.mutex.Lock()
+	if c.disposed {
+		c.mutex.Unlock()
+		d()
+		return false
+	}
+	c.disposables = append(c.disposables, d)
+	c.mutex.Unlock()
+	return true
+}
+
+// Dispose disposes all the Disposables of the group, and the ones added afterwards.
+func (c *CompositeDisposable) Dispose() {
+	c.mutex.Lock()
+	if c.disposed {
+		c.mutex.Unlock()
+		return
+	}
+	c.disposed = true
+	disposables := c.disposables
+	c.disposables = nil
+	c.mutex.Unlock()
+
+	for _, d := range disposables {
+		d()
+	}
+}
+
+// Clear disposes the Disposables of the group, and keeps the CompositeDisposable usable.
+func (c *CompositeDisposable) Clear() {
+	c.mutex.Lock()
+	disposables := c.disposables
+	c.disposables = nil
+	c.mutex.Unlock()
+
+	for _, d := range disposables {
+		d()
+	}
+}
+
+// IsDisposed returns whether the CompositeDisposable is disposed.
+func (c *CompositeDisposable) IsDisposed() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.disposed
+}
+
+// Len returns the number of Disposables held.
+func (c *CompositeDisposable) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.disposables)
+}
+
+// SerialDisposable holds a single Disposable, disposing the previous one whenever a new one is set.
+type SerialDisposable struct {
+	mutex      sync.Mutex
+	disposable Disposable
+	disposed   bool
+}
+
+// NewSerialDisposable creates an empty SerialDisposable.
+func NewSerialDisposable() *SerialDisposable {
+	return &SerialDisposable{}
+}
+
+// Set replaces the Disposable held and disposes the previous one, if any.
+// If the SerialDisposable is already disposed, the new Disposable is disposed immediately.
+func (s *SerialDisposable) Set(d Disposable) {
+	s.mutex.Lock()
+	if s.disposed {
+		s.mutex.Unlock()
+		if d != nil {
+			d()
+		}
+		return
+	}
+	previous := s.disposable
+	s.disposable = d
+	s.mutex.Unlock()
+
+	if previous != nil {
+		previous()
+	}
+}
+
+// Dispose disposes the Disposable held, and the ones set afterwards.
+func (s *SerialDisposable) Dispose() {
+	s.mutex.Lock()
+	if s.disposed {
+		s.mutex.Unlock()
+		return
+	}
+	s.disposed = true
+	d := s.disposable
+	s.disposable = nil
+	s.mutex.Unlock()
+
+	if d != nil {
+		d()
+	}
+}
+
+// IsDisposed returns whether the SerialDisposable is disposed.
+func (s *SerialDisposable) IsDisposed() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.disposed
+}
@@ -0,0 +1,72 @@
+package rxgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func counter() (Disposable, *int) {
+	count := 0
+	return func() {
+		count++
+	}, &count
+}
+
+func Test_CompositeDisposable_Dispose(t *testing.T) {
+	d1, count1 := counter()
+	d2, count2 := counter()
+	c := NewCompositeDisposable(d1)
+	assert.True(t, c.Add(d2))
+	assert.Equal(t, 2, c.Len())
+
+	c.Dispose()
+	c.Dispose()
+	assert.True(t, c.IsDisposed())
+	assert.Equal(t, 1, *count1)
+	assert.Equal(t, 1, *count2)
+	assert.Equal(t, 0, c.Len())
+}
+
+func Test_CompositeDisposable_AddAfterDispose(t *testing.T) {
+	c := NewCompositeDisposable()
+	c.Dispose()
+	d, count := counter()
+	assert.False(t, c.Add(d))
+	assert.Equal(t, 1, *count)
+}
+
+func Test_CompositeDisposable_Clear(t *testing.T) {
+	d1, count1 := counter()
+	c := NewCompositeDisposable(d1)
+	c.Clear()
+	assert.Equal(t, 1, *count1)
+	assert.False(t, c.IsDisposed())
+
+	d2, count2 := counter()
+	assert.True(t, c.Add(d2))
+	assert.Equal(t, 0, *count2)
+}
+
+func Test_SerialDisposable_Set(t *testing.T) {
+	d1, count1 := counter()
+	d2, count2 := counter()
+	s := NewSerialDisposable()
+	s.Set(d1)
+	assert.Equal(t, 0, *count1)
+	s.Set(d2)
+	assert.Equal(t, 1, *count1)
+	assert.Equal(t, 0, *count2)
+
+	s.Dispose()
+	assert.True(t, s.IsDisposed())
+	assert.Equal(t, 1, *count2)
+}
+
+func Test_SerialDisposable_SetAfterDispose(t *testing.T) {
+	s := NewSerialDisposable()
+	s.Dispose()
+	d, count := counter()
+	s.Set(d)
+	assert.Equal(t, 1, *count)
+}
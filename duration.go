@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -30,6 +31,20 @@ func WithDuration(d time.Duration) Duration {
 	}
 }
 
+// ExponentialBackOff creates a backoff policy to be used with BackOffRetry. The first interval is initial, then
+// each interval is multiplied by multiplier, up to max. Each interval is randomized by +/- jitter
+// (e.g. 0.5 means between 50% and 150% of the interval). The policy stops after maxRetries retries.
+func ExponentialBackOff(initial, max time.Duration, multiplier, jitter float64, maxRetries uint64) backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = initial
+	b.MaxInterval = max
+	b.Multiplier = multiplier
+	b.RandomizationFactor = jitter
+	b.MaxElapsedTime = 0
+	b.Reset()
+	return backoff.WithMaxRetries(b, maxRetries)
+}
+
 var tick = struct{}{}
 
 type causalityDuration struct {
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -11,3 +12,11 @@ func TestWithFrequency(t *testing.T) {
 	frequency := WithDuration(100 * time.Millisecond)
 	assert.Equal(t, 100*time.Millisecond, frequency.duration())
 }
+
+func TestExponentialBackOff(t *testing.T) {
+	b := ExponentialBackOff(10*time.Millisecond, 40*time.Millisecond, 2, 0, 3)
+	assert.Equal(t, 10*time.Millisecond, b.NextBackOff())
+	assert.Equal(t, 20*time.Millisecond, b.NextBackOff())
+	assert.Equal(t, 40*time.Millisecond, b.NextBackOff())
+	assert.Equal(t, backoff.Stop, b.NextBackOff())
+}
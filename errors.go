@@ -1,5 +1,30 @@
 package rxgo
 
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// BackpressureError is triggered when an observer is not ready to receive an item with the Fail backpressure strategy.
+type BackpressureError struct {
+	error string
+}
+
+func (e BackpressureError) Error() string {
+	return "backpressure: " + e.error
+}
+
+// HTTPStatusError is triggered when an HTTP server replies with an unexpected status code.
+type HTTPStatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e HTTPStatusError) Error() string {
+	return "unexpected HTTP status: " + e.Status
+}
+
 // IllegalInputError is triggered when the observable receives an illegal input.
 type IllegalInputError struct {
 	error string
@@ -17,3 +42,86 @@ type IndexOutOfBoundError struct {
 func (e IndexOutOfBoundError) Error() string {
 	return "index out of bound: " + e.error
 }
+
+// TimeoutError is triggered when an Observable does not emit any item within the expected duration.
+type TimeoutError struct {
+	error string
+}
+
+func (e TimeoutError) Error() string {
+	return "timeout: " + e.error
+}
+
+// RejectedTaskError is triggered when a Scheduler rejects a task, e.g. once it is stopped.
+type RejectedTaskError struct {
+	error string
+}
+
+func (e RejectedTaskError) Error() string {
+	return "rejected task: " + e.error
+}
+
+// errRejectedTask is emitted by the operators whose task is rejected by a Scheduler.
+var errRejectedTask = RejectedTaskError{error: "scheduler stopped"}
+
+// RetryAfterError wraps an error returned by the fetch function of FromPaginatedFetch to delay the next attempt,
+// e.g. once a server replied with a 429 Too Many Requests status and a Retry-After header, see ParseRetryAfter.
+type RetryAfterError struct {
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e RetryAfterError) Error() string {
+	return fmt.Sprintf("retry after %v: %v", e.RetryAfter, e.Err)
+}
+
+// Unwrap returns the error wrapped.
+func (e RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+// StreamError wraps an error emitted by an operator created with WithStreamErrors.
+type StreamError struct {
+	// Operator is the name of the operator which emitted the error, empty if the error comes from its source.
+	Operator string
+	// Index is the index of the item being processed by the operator, -1 if its source had completed.
+	Index int
+	// Stack is the stack trace of the operator creation.
+	Stack []byte
+	Err   error
+}
+
+func (e StreamError) Error() string {
+	if e.Operator == "" {
+		return fmt.Sprintf("source: item %d: %v", e.Index, e.Err)
+	}
+	return fmt.Sprintf("%s: item %d: %v", e.Operator, e.Index, e.Err)
+}
+
+// Unwrap returns the error wrapped.
+func (e StreamError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is triggered when a function passed to an operator, or an Observer, panics.
+// It holds the panic value and the stack trace of the goroutine which panicked.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func newPanicError(value interface{}) PanicError {
+	return PanicError{Value: value, Stack: debug.Stack()}
+}
+
+func (e PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
@@ -1,172 +1,202 @@
 package rxgo
 
 import (
+	"bufio"
 	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"io"
 	"math"
+	"net/http"
+	"os"
+	"os/signal"
 	"sync"
 	"sync/atomic"
-	"time"
 )
 
 // Amb takes several Observables, emit all of the items from only the first of these Observables
-// to emit an item or notification.
+// to emit an item or an error.
+// Once an Observable wins, the other ones are unsubscribed. An Observable completing without emitting
+// anything does not win.
 func Amb(observables []Observable, opts ...Option) Observable {
-	option := parseOptions(opts...)
-	ctx := option.buildContext()
-	next := option.buildChannel()
-	once := sync.Once{}
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		winner := int32(-1)
+		wg := sync.WaitGroup{}
+		wg.Add(len(observables))
 
-	f := func(o Observable) {
-		it := o.Observe(opts...)
+		contexts := make([]context.Context, len(observables))
+		cancels := make([]context.CancelFunc, len(observables))
+		for i := range observables {
+			contexts[i], cancels[i] = context.WithCancel(ctx)
+		}
 
-		select {
-		case <-ctx.Done():
-			return
-		case item, ok := <-it:
-			if !ok {
+		handler := func(i int) {
+			defer wg.Done()
+			ctx := contexts[i]
+			it := observables[i].Observe(append(opts[:len(opts):len(opts)], WithContext(ctx))...)
+
+			select {
+			case <-ctx.Done():
 				return
-			}
-			once.Do(func() {
-				defer close(next)
-				if item.Error() {
-					next <- item
+			case item, ok := <-it:
+				if !ok || !atomic.CompareAndSwapInt32(&winner, -1, int32(i)) {
 					return
 				}
-				next <- item
+				for j, cancel := range cancels {
+					if j != i {
+						cancel()
+					}
+				}
 				for {
+					if !item.SendContext(ctx, next) {
+						return
+					}
+					if item.Error() && option.getErrorStrategy() == StopOnError {
+						return
+					}
 					select {
 					case <-ctx.Done():
 						return
-					case item, ok := <-it:
+					case item, ok = <-it:
 						if !ok {
 							return
 						}
-						if item.Error() {
-							next <- item
-							return
-						}
-						next <- item
 					}
 				}
-			})
+			}
 		}
-	}
 
-	for _, o := range observables {
-		go f(o)
+		for i := range observables {
+			go handler(i)
+		}
+		wg.Wait()
 	}
 
-	return &ObservableImpl{
-		iterable: newChannelIterable(next),
-	}
+	return customObservableOperator(f, opts...)
 }
 
 // CombineLatest combines the latest item emitted by each Observable via a specified function
 // and emit items based on the results of this function.
+// It completes once all the Observables have completed, or as soon as one of them completes without emitting.
 func CombineLatest(f FuncN, observables []Observable, opts ...Option) Observable {
-	option := parseOptions(opts...)
-	ctx := option.buildContext()
-	next := option.buildChannel()
-
-	go func() {
-		size := uint32(len(observables))
-		var counter uint32
+	combine := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		size := len(observables)
+		remaining := size
 		s := make([]interface{}, size)
+		emitted := make([]bool, size)
 		mutex := sync.Mutex{}
 		wg := sync.WaitGroup{}
-		wg.Add(int(size))
-		errCh := make(chan struct{})
+		wg.Add(size)
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
 
-		handler := func(ctx context.Context, it Iterable, i int) {
+		handler := func(it Iterable, i int) {
 			defer wg.Done()
-			observe := it.Observe(opts...)
+			observe := it.Observe(append(opts[:len(opts):len(opts)], WithContext(ctx))...)
 			for {
 				select {
 				case <-ctx.Done():
 					return
 				case item, ok := <-observe:
 					if !ok {
+						mutex.Lock()
+						// The items can't be combined anymore if an Observable completes without emitting
+						if !emitted[i] {
+							cancel()
+						}
+						mutex.Unlock()
 						return
 					}
 					if item.Error() {
-						next <- item
-						errCh <- struct{}{}
+						item.SendContext(ctx, next)
+						cancel()
 						return
 					}
-					if s[i] == nil {
-						atomic.AddUint32(&counter, 1)
-					}
 					mutex.Lock()
+					if !emitted[i] {
+						emitted[i] = true
+						remaining--
+					}
 					s[i] = item.V
-					if atomic.LoadUint32(&counter) == size {
-						next <- Of(f(s...))
+					if remaining == 0 {
+						values := make([]interface{}, size)
+						copy(values, s)
+						Of(f(values...)).SendContext(ctx, next)
 					}
 					mutex.Unlock()
 				}
 			}
 		}
 
-		ctx, cancel := context.WithCancel(ctx)
 		for i, o := range observables {
-			go handler(ctx, o, i)
+			go handler(o, i)
 		}
-
-		go func() {
-			for range errCh {
-				cancel()
-			}
-		}()
-
 		wg.Wait()
-		close(next)
-		close(errCh)
-	}()
-
-	return &ObservableImpl{
-		iterable: newChannelIterable(next),
 	}
+
+	return customObservableOperator(combine, opts...)
 }
 
 // Concat emits the emissions from two or more Observables without interleaving them.
+// Each Observable is only observed once the previous one has completed.
 func Concat(observables []Observable, opts ...Option) Observable {
-	option := parseOptions(opts...)
-	ctx := option.buildContext()
-	next := option.buildChannel()
-
-	go func() {
-		defer close(next)
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
 		for _, obs := range observables {
-			observe := obs.Observe(opts...)
-		loop:
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case item, ok := <-observe:
-					if !ok {
-						break loop
-					}
-					if item.Error() {
-						next <- item
-						return
-					}
-					next <- item
-				}
+			if !concatObserve(ctx, obs, next, option, opts...) {
+				return
+			}
+		}
+	}
+
+	return customObservableOperator(f, opts...)
+}
+
+// concatObserve forwards the items of an Observable until it completes. It returns false if
+// the concatenation has to be stopped. The Observable is unsubscribed once it returns.
+func concatObserve(ctx context.Context, obs Observable, next chan Item, option Option, opts ...Option) bool {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	observe := obs.Observe(append(opts, WithContext(ctx))...)
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case item, ok := <-observe:
+			if !ok {
+				return true
+			}
+			if !item.SendContext(ctx, next) {
+				return false
+			}
+			if item.Error() && option.getErrorStrategy() == StopOnError {
+				return false
 			}
 		}
-	}()
-	return &ObservableImpl{
-		iterable: newChannelIterable(next),
 	}
 }
 
 // Create creates an Observable from scratch by calling observer methods programmatically.
+// It is a cold Observable: the producers are called for each observer, which receives its own sequence.
+// With WithPublishStrategy, the producers are called once on connect and their items are multicasted.
 func Create(f []Producer, opts ...Option) Observable {
 	return &ObservableImpl{
 		iterable: newCreateIterable(f, opts...),
 	}
 }
 
+// CreateWithEmitter creates an Observable from a function emitting the items through an Emitter.
+// The function is called for each observer. It may return before the Observable is terminated, for
+// example once callbacks are registered on a callback-based source. If it panics, the recovered value
+// is emitted as an error.
+func CreateWithEmitter(f func(emitter Emitter), opts ...Option) Observable {
+	return &ObservableImpl{
+		iterable: newEmitterIterable(f, opts...),
+	}
+}
+
 // Defer does not create the Observable until the observer subscribes,
 // and creates a fresh Observable for each observer.
 func Defer(f []Producer, opts ...Option) Observable {
@@ -175,53 +205,325 @@ func Defer(f []Producer, opts ...Option) Observable {
 	}
 }
 
+// DeferObservable does not create the Observable until the observer subscribes: the factory is called
+// for each observer so that each of them observes a fresh Observable.
+func DeferObservable(factory func() Observable, opts ...Option) Observable {
+	return &ObservableImpl{
+		iterable: newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
+			return factory().Observe(mergeOptions(opts, propagatedOptions)...)
+		}),
+	}
+}
+
 // Empty creates an Observable with no item and terminate immediately.
-func Empty() Observable {
-	next := make(chan Item)
-	close(next)
+// Each observer receives a fresh termination, so that it can be observed more than once.
+func Empty(opts ...Option) Observable {
 	return &ObservableImpl{
-		iterable: newChannelIterable(next),
+		iterable: newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
+			next := parseOptions(mergeOptions(opts, propagatedOptions)...).buildChannel()
+			close(next)
+			return next
+		}),
 	}
 }
 
-// FromChannel creates a cold observable from a channel.
+// FromChannel creates a hot Observable from a channel. The channel is shared by the observers, each item
+// being received by a single one of them: use WithPublishStrategy to multicast the items.
 func FromChannel(next <-chan Item, opts ...Option) Observable {
 	return &ObservableImpl{
 		iterable: newChannelIterable(next, opts...),
 	}
 }
 
+// FromCSV creates an observable emitting the records read from r as []string, or as map[string]string with
+// WithCSVHeader. The delimiter is set using WithCSVDelimiter.
+// A record which cannot be parsed is emitted as a *csv.ParseError: it stops the Observable with the StopOnError
+// strategy, and it is skipped with WithCSVSkipInvalidRecords. Any other read error stops the Observable.
+// As with FromReader, r is consumed once.
+func FromCSV(r io.Reader, opts ...Option) Observable {
+	config := parseCSVOptions(opts...)
+	reader := csv.NewReader(r)
+	if config.delimiter != 0 {
+		reader.Comma = config.delimiter
+	}
+	it := &csvIterator{
+		reader:      reader,
+		header:      config.header,
+		skipInvalid: config.skipInvalid,
+		stopOnError: parseOptions(opts...).getErrorStrategy() == StopOnError,
+	}
+	return FromIterator(func() Iterator {
+		return it
+	}, opts...)
+}
+
+// FromCron creates an Observable emitting the scheduled times of a cron expression, in its location:
+//   - Five fields: minute, hour, day of month, month and day of week, each being *, a value, a range or a list
+//     of them, with an optional step (e.g. "*/15 9-17 * * MON-FRI").
+//   - Or a descriptor: @yearly, @monthly, @weekly, @daily or @hourly.
+//
+// The expression can be prefixed by CRON_TZ=<location>, e.g. "CRON_TZ=Europe/Paris 0 8 * * *", the local time
+// being used otherwise. Each observer has its own schedule, which stops once its context is cancelled.
+// An invalid expression is emitted as an IllegalInputError.
+func FromCron(spec string, opts ...Option) Observable {
+	schedule, err := parseCron(spec)
+	if err != nil {
+		return Thrown(IllegalInputError{error: "cron: " + err.Error()})
+	}
+	metrics := parseOptions(opts...).getMetrics()
+	return &ObservableImpl{
+		iterable: newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
+			option := parseOptions(mergeOptions(opts, propagatedOptions)...)
+			ctx := option.buildContext()
+			buffer := newObserverBuffer(ctx, option, 0, metrics)
+			clock := option.getClock()
+
+			go func() {
+				defer buffer.close()
+				// The times missed by a slow observer are skipped
+				for t := schedule.next(clock.Now()); !t.IsZero(); t = schedule.next(clock.Now()) {
+					timer := clock.NewTimer(t.Sub(clock.Now()))
+					select {
+					case <-ctx.Done():
+						timer.Stop()
+						return
+					case <-timer.C():
+						if !buffer.send(Of(t)) {
+							return
+						}
+					}
+				}
+			}()
+			return buffer.ch
+		}),
+	}
+}
+
 // FromEventSource creates a hot observable from a channel.
 func FromEventSource(next <-chan Item, opts ...Option) Observable {
 	option := parseOptions(opts...)
 
 	return &ObservableImpl{
-		iterable: newEventSourceIterable(option.buildContext(), next, option.getBackPressureStrategy()),
+		iterable: newEventSourceIterable(option.buildContext(), next, option.getBackPressureStrategy(), option.getMetrics()),
 	}
 }
 
-// Interval creates an Observable emitting incremental integers infinitely between
-// each given time interval.
-func Interval(interval Duration, opts ...Option) Observable {
-	option := parseOptions(opts...)
-	next := option.buildChannel()
-	ctx := option.buildContext()
+// FromIterator creates a cold observable from an Iterator.
+// newIterator is called for each observer so that each of them iterates over the whole sequence.
+// A value implementing error is emitted as an error.
+func FromIterator(newIterator func() Iterator, opts ...Option) Observable {
+	return &ObservableImpl{
+		iterable: newIteratorIterable(newIterator, opts...),
+	}
+}
+
+// FromJSONDecoder creates an observable emitting the values decoded from a JSON stream, e.g. JSON lines.
+// Each value is decoded into a fresh value returned by factory, or into an interface{} if factory is nil.
+// As with FromReader, the decoder is consumed once. A decoding error is emitted as an error and stops the
+// Observable, io.EOF completes it.
+func FromJSONDecoder(decoder *json.Decoder, factory func() interface{}, opts ...Option) Observable {
+	it := &jsonDecoderIterator{decoder: decoder, factory: factory}
+	return FromIterator(func() Iterator {
+		return it
+	}, opts...)
+}
+
+// FromPaginatedFetch creates a cold observable emitting the items of all the pages returned by fetch, e.g. from
+// a paginated HTTP API. Each observer fetches the first page with an empty cursor, then the next pages with the
+// cursor returned by the previous one, lazily once the items of the previous page are consumed, until fetch
+// returns an empty cursor. A failed fetch is retried using the policy set with WithFetchBackOff, waiting at least
+// the delay of a RetryAfterError; once the policy stops, or if the error is wrapped by backoff.Permanent, the
+// error is emitted and stops the Observable. An item implementing error is emitted as an error.
+func FromPaginatedFetch(fetch func(cursor string) (items []interface{}, next string, err error),
+	opts ...Option) Observable {
+	return &ObservableImpl{
+		iterable: newPaginatedIterable(fetch, opts...),
+	}
+}
+
+// FromReader creates an observable emitting the content of a reader as []byte chunks of at most chunkSize bytes.
+// The reader is consumed once, the next observers resuming where the previous ones stopped. A read error is emitted
+// as an error and io.EOF completes the Observable. A blocking read is not interrupted by the context cancellation.
+func FromReader(r io.Reader, chunkSize int, opts ...Option) Observable {
+	if chunkSize <= 0 {
+		return Thrown(IllegalInputError{error: "chunk size must be positive"})
+	}
+	it := &readerIterator{reader: r, chunkSize: chunkSize}
+	return FromIterator(func() Iterator {
+		return it
+	}, opts...)
+}
+
+// FromScanner creates an observable emitting the tokens of a scanner as strings, e.g. the lines with the default
+// split function. As with FromReader, the scanner is consumed once and a scanning error is emitted as an error.
+func FromScanner(scanner *bufio.Scanner, opts ...Option) Observable {
+	it := &scannerIterator{scanner: scanner}
+	return FromIterator(func() Iterator {
+		return it
+	}, opts...)
+}
+
+// FromSQLRows creates an observable emitting the value returned by scan for each row. The rows are closed once
+// iterated, once an error occurred or once the observer context is cancelled. A scan or iteration error is
+// emitted and stops the Observable. The rows are iterated only once, the next observers receiving no item.
+func FromSQLRows(rows *sql.Rows, scan func(*sql.Rows) (interface{}, error), opts ...Option) Observable {
+	return Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		defer rows.Close()
+		for rows.Next() {
+			v, err := scan(rows)
+			if err != nil {
+				Error(err).SendContext(ctx, next)
+				return
+			}
+			if !Of(v).SendContext(ctx, next) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			Error(err).SendContext(ctx, next)
+		}
+	}}, opts...)
+}
+
+// FromSSE creates a cold observable emitting the SSEEvents received from a Server-Sent Events endpoint.
+// Each observer sends its own request, using client or http.DefaultClient if nil. Once the stream ends, the
+// request is sent again after the delay set by the server (3 seconds by default), along with the last event ID.
+// A request failure or an unexpected status code is retried according to the policy set with WithSSEBackOff, then
+// emitted as an error stopping the Observable, whereas the 204 No Content status completes it.
+func FromSSE(client *http.Client, request *http.Request, opts ...Option) Observable {
+	return &ObservableImpl{
+		iterable: newSSEIterable(client, request, opts...),
+	}
+}
+
+// FromSeq creates a cold observable from a range-over-func iterator, e.g. an iter.Seq[any] since Go 1.23. The
+// sequence is iterated for each observer, and stopped once the observer context is cancelled. An error value is
+// emitted as an error item.
+func FromSeq(seq func(yield func(interface{}) bool), opts ...Option) Observable {
+	return Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		seq(func(v interface{}) bool {
+			item := Of(v)
+			if err, isError := v.(error); isError {
+				item = Error(err)
+			}
+			return item.SendContext(ctx, next)
+		})
+	}}, opts...)
+}
 
-	go func() {
-		i := 0
+// FromSignals creates an observable emitting the os.Signal received among signals, or every incoming signal if
+// empty. Each observer is notified from its subscription until its context is cancelled, the Observable never
+// completing otherwise. For example, TakeUntilObservable(FromSignals([]os.Signal{os.Interrupt})) stops a
+// pipeline on interruption.
+func FromSignals(signals []os.Signal, opts ...Option) Observable {
+	return Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		ch := make(chan os.Signal, 1)
+		signal.Notify(ch, signals...)
+		defer signal.Stop(ch)
 		for {
 			select {
-			case <-time.After(interval.duration()):
-				next <- Of(i)
-				i++
 			case <-ctx.Done():
-				close(next)
 				return
+			case sig := <-ch:
+				if !Of(sig).SendContext(ctx, next) {
+					return
+				}
 			}
 		}
-	}()
+	}}, opts...)
+}
+
+// FromSlice creates a cold observable emitting the elements of a slice.
+// Unlike Just, the nested slices and channels are emitted as they are. An element implementing error is emitted as an error.
+func FromSlice(items []interface{}, opts ...Option) Observable {
 	return &ObservableImpl{
-		iterable: newEventSourceIterable(ctx, next, option.getBackPressureStrategy()),
+		iterable: newIteratorIterable(func() Iterator {
+			return &sliceIterator{items: items}
+		}, opts...),
+	}
+}
+
+// FromValueChannel creates a cold observable from a channel of raw values.
+// The values are consumed when an Observer subscribes and a value implementing error is emitted as an error.
+// The Observable completes once the channel is closed.
+func FromValueChannel(ch <-chan interface{}, opts ...Option) Observable {
+	return &ObservableImpl{
+		iterable: newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
+			option := parseOptions(mergeOptions(opts, propagatedOptions)...)
+			next := option.buildChannel()
+			ctx := option.buildContext()
+
+			go func() {
+				defer close(next)
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case v, ok := <-ch:
+						if !ok {
+							return
+						}
+						item := Of(v)
+						if err, isError := v.(error); isError {
+							item = Error(err)
+						}
+						if !item.SendContext(ctx, next) {
+							return
+						}
+					}
+				}
+			}()
+			return next
+		}),
+	}
+}
+
+// Generate creates a cold observable from a loop-like specification: starting from initialState, it emits
+// resultSelector(state) while condition(state) holds, state being then computed by iterate. The loop runs lazily
+// for each observer, from the initial state. If resultSelector is nil, the states are emitted. As with
+// FromIterator, a value implementing error is emitted as an error.
+func Generate(initialState interface{}, condition Predicate, iterate func(interface{}) interface{},
+	resultSelector func(interface{}) interface{}, opts ...Option) Observable {
+	return FromIterator(func() Iterator {
+		return &generateIterator{
+			state:          initialState,
+			condition:      condition,
+			iterate:        iterate,
+			resultSelector: resultSelector,
+		}
+	}, opts...)
+}
+
+// Interval creates an Observable emitting incremental integers infinitely between
+// each given time interval.
+// Each observer has its own sequence, which stops once its context is cancelled.
+func Interval(interval Duration, opts ...Option) Observable {
+	metrics := parseOptions(opts...).getMetrics()
+	return &ObservableImpl{
+		iterable: newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
+			option := parseOptions(mergeOptions(opts, propagatedOptions)...)
+			ctx := option.buildContext()
+			buffer := newObserverBuffer(ctx, option, 0, metrics)
+			clock := option.getClock()
+
+			go func() {
+				defer buffer.close()
+				// The ticks missed by a slow observer are skipped
+				ticker := clock.NewTicker(interval.duration())
+				defer ticker.Stop()
+				for i := 0; ; i++ {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C():
+						if !buffer.send(Of(i)) {
+							return
+						}
+					}
+				}
+			}()
+			return buffer.ch
+		}),
 	}
 }
 
@@ -241,52 +543,101 @@ func JustItem(item interface{}, opts ...Option) Single {
 	}
 }
 
-// Merge combines multiple Observables into one by merging their emissions
+// Merge combines multiple Observables into one by merging their emissions.
+// It completes once all the Observables have completed. With the StopOnError strategy, the first error
+// is propagated and the other Observables are unsubscribed.
 func Merge(observables []Observable, opts ...Option) Observable {
-	option := parseOptions(opts...)
-	ctx := option.buildContext()
-	next := option.buildChannel()
-	wg := sync.WaitGroup{}
-	wg.Add(len(observables))
-
-	f := func(o Observable) {
-		defer wg.Done()
-		observe := o.Observe(opts...)
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case item, ok := <-observe:
-				if !ok {
-					return
+	return merge(observables, false, opts...)
+}
+
+// MergeDelayError combines multiple Observables into one by merging their emissions.
+// Unlike Merge, an error does not interrupt the other Observables: the errors are emitted once all
+// the Observables have terminated.
+func MergeDelayError(observables []Observable, opts ...Option) Observable {
+	return merge(observables, true, opts...)
+}
+
+func merge(observables []Observable, delayErrors bool, opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		wg := sync.WaitGroup{}
+		wg.Add(len(observables))
+		// The mutex serializes the sends so that no item is emitted after an error stopping the Observable
+		mutex := sync.Mutex{}
+		var errs []Item
+
+		send := func(item Item) bool {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if ctx.Err() != nil {
+				return false
+			}
+			if item.Error() {
+				if delayErrors {
+					errs = append(errs, item)
+					return true
 				}
-				if item.Error() {
-					next <- item
-					return
+				if !item.SendContext(ctx, next) {
+					return false
 				}
-				next <- item
+				if option.getErrorStrategy() == StopOnError {
+					cancel()
+				}
+				return true
 			}
+			return item.SendContext(ctx, next)
 		}
-	}
 
-	for _, o := range observables {
-		go f(o)
-	}
+		// The options are built before the handlers run concurrently, each of them observing with it
+		observeOpts := append(opts, WithContext(ctx))
+		handler := func(o Observable) {
+			defer wg.Done()
+			observe := o.Observe(observeOpts...)
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-observe:
+					if !ok {
+						return
+					}
+					if !send(item) || (item.Error() && option.getErrorStrategy() == StopOnError) {
+						return
+					}
+				}
+			}
+		}
 
-	go func() {
+		for _, o := range observables {
+			go handler(o)
+		}
 		wg.Wait()
-		close(next)
-	}()
-	return &ObservableImpl{
-		iterable: newChannelIterable(next),
+		for _, item := range errs {
+			if !item.SendContext(ctx, next) {
+				return
+			}
+		}
 	}
+
+	return customObservableOperator(f, opts...)
 }
 
 // Never creates an Observable that emits no items and does not terminate.
-func Never() Observable {
-	next := make(chan Item)
+// The observer channel is closed only once the observer context is cancelled.
+func Never(opts ...Option) Observable {
 	return &ObservableImpl{
-		iterable: newChannelIterable(next),
+		iterable: newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
+			option := parseOptions(mergeOptions(opts, propagatedOptions)...)
+			next := option.buildChannel()
+			if done := option.buildContext().Done(); done != nil {
+				go func() {
+					<-done
+					close(next)
+				}()
+			}
+			return next
+		}),
 	}
 }
 
@@ -306,52 +657,199 @@ func Range(start, count int, opts ...Option) Observable {
 // Start creates an Observable from one or more directive-like Supplier
 // and emits the result of each operation asynchronously on a new Observable.
 func Start(fs []Supplier, opts ...Option) Observable {
-	option := parseOptions(opts...)
-	next := option.buildChannel()
-	ctx := option.buildContext()
-
-	go func() {
-		defer close(next)
-		for _, f := range fs {
-			select {
-			case <-ctx.Done():
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		for _, supplier := range fs {
+			if !supplier(ctx).SendContext(ctx, next) {
 				return
-			case next <- f(ctx):
 			}
 		}
-	}()
+	}
+
+	return customObservableOperator(f, opts...)
+}
 
+// Throw creates an Observable that emits no items and terminates with an error.
+// Each observer receives the error.
+func Throw(err error, opts ...Option) Observable {
 	return &ObservableImpl{
-		iterable: newChannelIterable(next),
+		iterable: newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
+			next := observerChannel(parseOptions(mergeOptions(opts, propagatedOptions)...), 1)
+			next <- Error(err)
+			close(next)
+			return next
+		}),
 	}
 }
 
 // Thrown creates an Observable that emits no items and terminates with an error.
+// It is equivalent to Throw.
 func Thrown(err error) Observable {
-	next := make(chan Item, 1)
-	next <- Error(err)
-	close(next)
+	return Throw(err)
+}
+
+// Timer returns an Observable that emits an empty struct after a specified delay, then completes.
+// The delay starts once the Observable is observed.
+func Timer(d Duration, opts ...Option) Observable {
 	return &ObservableImpl{
-		iterable: newChannelIterable(next),
+		iterable: newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
+			option := parseOptions(mergeOptions(opts, propagatedOptions)...)
+			next := make(chan Item, 1)
+			ctx := option.buildContext()
+			timer := option.getClock().NewTimer(d.duration())
+
+			go func() {
+				defer close(next)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+				case <-timer.C():
+					Of(struct{}{}).SendContext(ctx, next)
+				}
+			}()
+			return next
+		}),
 	}
 }
 
-// Timer returns an Observable that completes after a specified delay.
-func Timer(d Duration, opts ...Option) Observable {
-	option := parseOptions(opts...)
-	next := make(chan Item, 1)
-	ctx := option.buildContext()
+// Using creates an Observable whose lifetime is bound to a resource. For each observer, a resource is acquired using
+// resourceFactory and the Observable returned by observableFactory is mirrored. The resource is closed once this
+// Observable terminates or the observer context is cancelled, before the observer channel is closed.
+// An error returned by resourceFactory is emitted; the error returned by Close is ignored.
+func Using(resourceFactory func() (io.Closer, error), observableFactory func(io.Closer) Observable, opts ...Option) Observable {
+	return &ObservableImpl{
+		iterable: newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
+			observeOpts := mergeOptions(opts, propagatedOptions)
+			option := parseOptions(observeOpts...)
+			next := option.buildChannel()
+			ctx := option.buildContext()
 
-	go func() {
-		defer close(next)
-		select {
-		case <-ctx.Done():
-			return
-		case <-time.After(d.duration()):
+			go func() {
+				defer close(next)
+				resource, err := resourceFactory()
+				if err != nil {
+					Error(err).SendContext(ctx, next)
+					return
+				}
+				defer func() {
+					_ = resource.Close()
+				}()
+				ctx, cancel := context.WithCancel(ctx)
+				defer cancel()
+				observe := observableFactory(resource).Observe(append(observeOpts, WithContext(ctx))...)
+
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case item, ok := <-observe:
+						if !ok {
+							return
+						}
+						if !item.SendContext(ctx, next) {
+							return
+						}
+						if item.Error() && option.getErrorStrategy() == StopOnError {
+							return
+						}
+					}
+				}
+			}()
+			return next
+		}),
+	}
+}
+
+// Zip combines the items emitted by multiple Observables via a specified function: the n-th items of each
+// Observable are combined together. It completes as soon as the shortest Observable has completed.
+func Zip(f FuncN, observables []Observable, opts ...Option) Observable {
+	iterables := make([]Iterable, len(observables))
+	for i, o := range observables {
+		iterables[i] = o
+	}
+	return zip(iterables, func(_ context.Context, values []interface{}) (interface{}, error) {
+		return f(values...), nil
+	}, opts...)
+}
+
+// zip observes each iterable concurrently and queues the items until they can be zipped.
+func zip(iterables []Iterable, zipper func(context.Context, []interface{}) (interface{}, error), opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		size := len(iterables)
+		if size == 0 {
 			return
 		}
-	}()
-	return &ObservableImpl{
-		iterable: newChannelIterable(next),
+		queues := make([][]interface{}, size)
+		completed := make([]bool, size)
+		mutex := sync.Mutex{}
+		wg := sync.WaitGroup{}
+		wg.Add(size)
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// zipHead must be called with the lock held.
+		zipHead := func() bool {
+			values := make([]interface{}, size)
+			exhausted := false
+			for i := range queues {
+				values[i] = queues[i][0]
+				queues[i] = queues[i][1:]
+				if len(queues[i]) == 0 && completed[i] {
+					exhausted = true
+				}
+			}
+			v, err := zipper(ctx, values)
+			if err != nil {
+				Error(err).SendContext(ctx, next)
+				return false
+			}
+			return Of(v).SendContext(ctx, next) && !exhausted
+		}
+
+		handler := func(it Iterable, i int) {
+			defer wg.Done()
+			observe := it.Observe(append(opts[:len(opts):len(opts)], WithContext(ctx))...)
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-observe:
+					if !ok {
+						mutex.Lock()
+						completed[i] = true
+						// The queued items of the other Observables can't be zipped anymore
+						if len(queues[i]) == 0 {
+							cancel()
+						}
+						mutex.Unlock()
+						return
+					}
+					if item.Error() {
+						item.SendContext(ctx, next)
+						cancel()
+						return
+					}
+					mutex.Lock()
+					queues[i] = append(queues[i], item.V)
+					ready := true
+					for _, queue := range queues {
+						if len(queue) == 0 {
+							ready = false
+							break
+						}
+					}
+					if ready && !zipHead() {
+						cancel()
+					}
+					mutex.Unlock()
+				}
+			}
+		}
+
+		for i, it := range iterables {
+			go handler(it, i)
+		}
+		wg.Wait()
 	}
+
+	return customObservableOperator(f, opts...)
 }
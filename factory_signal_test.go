@@ -0,0 +1,49 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package rxgo
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// notifyUntil sends sig to the current process until done is closed. The signal is also notified to a guard
+// channel, so that the process is not terminated if it is received before the subscription.
+func notifyUntil(t *testing.T, sig syscall.Signal, done <-chan struct{}) {
+	guard := make(chan os.Signal, 1)
+	signal.Notify(guard, sig)
+	go func() {
+		defer signal.Stop(guard)
+		for {
+			assert.NoError(t, syscall.Kill(os.Getpid(), sig))
+			select {
+			case <-done:
+				return
+			case <-time.After(5 * time.Millisecond):
+			}
+		}
+	}()
+}
+
+func Test_FromSignals(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	observe := FromSignals([]os.Signal{syscall.SIGUSR1}, WithContext(ctx)).Observe()
+	notifyUntil(t, syscall.SIGUSR1, ctx.Done())
+	assert.Equal(t, syscall.SIGUSR1, (<-observe).V)
+}
+
+func Test_FromSignals_TakeUntilObservable(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+	obs := Interval(WithDuration(time.Millisecond)).TakeUntilObservable(FromSignals([]os.Signal{syscall.SIGUSR2}))
+	notifyUntil(t, syscall.SIGUSR2, done)
+	Assert(context.Background(), t, obs, HasNoError())
+}
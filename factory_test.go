@@ -1,11 +1,23 @@
 package rxgo
 
 import (
+	"bufio"
 	"context"
+	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -38,6 +50,32 @@ func Test_Amb2(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(1, 2, 3))
 }
 
+func Test_Amb_DisposeLosers(t *testing.T) {
+	ch := make(chan Item)
+	canceled := make(chan struct{})
+	loser := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		<-ctx.Done()
+		close(canceled)
+	}})
+	observe := Amb([]Observable{FromChannel(ch), loser}).Observe()
+	ch <- Of(1)
+	assert.Equal(t, 1, (<-observe).V)
+	<-canceled
+	ch <- Of(2)
+	close(ch)
+	Assert(context.Background(), t, FromChannel(observe), HasItems(2), HasNoError())
+}
+
+func Test_Amb_Error(t *testing.T) {
+	obs := Amb([]Observable{Never(), testObservable(errFoo, 1)})
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+}
+
+func Test_Amb_AllEmpty(t *testing.T) {
+	obs := Amb([]Observable{Empty(), Empty()})
+	Assert(context.Background(), t, obs, IsEmpty(), HasNoError())
+}
+
 func Test_CombineLatest(t *testing.T) {
 	obs := CombineLatest(func(ii ...interface{}) interface{} {
 		sum := 0
@@ -63,6 +101,22 @@ func Test_CombineLatest_Empty(t *testing.T) {
 	Assert(context.Background(), t, obs, IsEmpty())
 }
 
+func Test_CombineLatest_EmptyNever(t *testing.T) {
+	obs := CombineLatest(func(ii ...interface{}) interface{} {
+		return ii
+	}, []Observable{Never(), Empty()})
+	Assert(context.Background(), t, obs, IsEmpty(), HasNoError())
+}
+
+func Test_CombineLatest_NilValues(t *testing.T) {
+	obs := CombineLatest(func(ii ...interface{}) interface{} {
+		return ii
+	}, []Observable{Defer([]Producer{func(_ context.Context, next chan<- Item) {
+		next <- Of(nil)
+	}}), Just("a")()})
+	Assert(context.Background(), t, obs, HasItems([]interface{}{nil, "a"}), HasNoError())
+}
+
 func Test_CombineLatest_Error(t *testing.T) {
 	obs := CombineLatest(func(ii ...interface{}) interface{} {
 		sum := 0
@@ -102,6 +156,38 @@ func Test_Concat_OneEmptyObservable(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(1, 2, 3))
 }
 
+func Test_Concat_Error(t *testing.T) {
+	canceled := make(chan struct{})
+	obs := Concat([]Observable{Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		next <- Error(errFoo)
+		<-ctx.Done()
+		close(canceled)
+	}}), testObservable(1)})
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+	<-canceled
+}
+
+func Test_Concat_ContinueOnError(t *testing.T) {
+	obs := Concat([]Observable{testObservable(1, errFoo, 2), testObservable(3)}, WithErrorStrategy(ContinueOnError))
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasError(errFoo))
+}
+
+func Test_Concat_Subscription(t *testing.T) {
+	subscribed := false
+	second := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		subscribed = true
+		next <- Of(3)
+	}})
+	ch := make(chan Item, 1)
+	observe := Concat([]Observable{FromChannel(ch), second}).Observe()
+	ch <- Of(1)
+	assert.Equal(t, 1, (<-observe).V)
+	assert.False(t, subscribed)
+	close(ch)
+	assert.Equal(t, 3, (<-observe).V)
+	assert.True(t, subscribed)
+}
+
 func Test_Create(t *testing.T) {
 	obs := Create([]Producer{func(ctx context.Context, next chan<- Item) {
 		next <- Of(1)
@@ -111,14 +197,43 @@ func Test_Create(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
 }
 
-func Test_Create_SingleDup(t *testing.T) {
+func Test_Create_Resubscribe(t *testing.T) {
 	obs := Create([]Producer{func(ctx context.Context, next chan<- Item) {
 		next <- Of(1)
 		next <- Of(2)
 		next <- Of(3)
 	}})
 	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
-	Assert(context.Background(), t, obs, IsEmpty(), HasNoError())
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
+}
+
+func Test_Create_ConcurrentSubscriptions(t *testing.T) {
+	obs := Create([]Producer{func(ctx context.Context, next chan<- Item) {
+		for i := 0; i < 100; i++ {
+			next <- Of(i)
+		}
+	}})
+	first := obs.Observe()
+	second := obs.Observe()
+	var expected []interface{}
+	for i := 0; i < 100; i++ {
+		expected = append(expected, i)
+	}
+	// Each subscription receives its own sequence, the items are not shared
+	errs := make(chan error, 2)
+	results := make([][]interface{}, 2)
+	for idx, observe := range []<-chan Item{first, second} {
+		idx, observe := idx, observe
+		go func() {
+			items, err := collect(context.Background(), observe)
+			results[idx] = items
+			errs <- err
+		}()
+	}
+	assert.NoError(t, <-errs)
+	assert.NoError(t, <-errs)
+	assert.Equal(t, expected, results[0])
+	assert.Equal(t, expected, results[1])
 }
 
 func Test_Create_ContextCancelled(t *testing.T) {
@@ -140,6 +255,134 @@ func Test_Create_ContextCancelled(t *testing.T) {
 	}
 }
 
+func Test_CreateWithEmitter(t *testing.T) {
+	obs := CreateWithEmitter(func(emitter Emitter) {
+		emitter.OnNext(1)
+		emitter.OnNext(2)
+		emitter.OnDone()
+		emitter.OnNext(3)
+	})
+	Assert(context.Background(), t, obs, HasItems(1, 2), HasNoError())
+	Assert(context.Background(), t, obs, HasItems(1, 2), HasNoError())
+}
+
+func Test_CreateWithEmitter_Error(t *testing.T) {
+	obs := CreateWithEmitter(func(emitter Emitter) {
+		emitter.OnNext(1)
+		emitter.OnError(errFoo)
+		emitter.OnError(errBar)
+		emitter.OnNext(2)
+		emitter.OnDone()
+	})
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errFoo))
+}
+
+func Test_CreateWithEmitter_Panic(t *testing.T) {
+	obs := CreateWithEmitter(func(emitter Emitter) {
+		emitter.OnNext(1)
+		panic(errFoo)
+	})
+	items, err := obs.ToSlice(0)
+	assert.Equal(t, []interface{}{1}, items)
+	assert.True(t, errors.Is(err, errFoo))
+
+	obs = CreateWithEmitter(func(emitter Emitter) {
+		panic("foo")
+	})
+	_, err = obs.ToSlice(0)
+	assert.Equal(t, "foo", err.(PanicError).Value)
+}
+
+func Test_Create_Panic(t *testing.T) {
+	obs := Create([]Producer{func(ctx context.Context, next chan<- Item) {
+		next <- Of(1)
+		panic("foo")
+	}})
+	items, err := obs.ToSlice(0)
+	assert.Equal(t, []interface{}{1}, items)
+	assert.Equal(t, "foo", err.(PanicError).Value)
+}
+
+func Test_CreateWithEmitter_Callback(t *testing.T) {
+	callbacks := make(chan func(int), 1)
+	obs := CreateWithEmitter(func(emitter Emitter) {
+		// The function returns once the callback is registered
+		callbacks <- func(i int) {
+			if i < 0 {
+				emitter.OnDone()
+				return
+			}
+			emitter.OnNext(i)
+		}
+	})
+	observe := obs.Observe()
+	callback := <-callbacks
+	go func() {
+		callback(1)
+		callback(2)
+		callback(-1)
+	}()
+	Assert(context.Background(), t, FromChannel(observe), HasItems(1, 2), HasNoError())
+}
+
+func Test_CreateWithEmitter_Disposed(t *testing.T) {
+	disposed := make(chan bool)
+	ctx, cancel := context.WithCancel(context.Background())
+	observe := CreateWithEmitter(func(emitter Emitter) {
+		emitter.OnNext(1)
+		// Blocks until the observer is disposed
+		emitter.OnNext(2)
+		disposed <- emitter.IsDisposed()
+	}).Observe(WithContext(ctx))
+	assert.Equal(t, 1, (<-observe).V)
+	cancel()
+	assert.True(t, <-disposed)
+	for range observe {
+	}
+}
+
+func Test_CreateWithEmitter_IsDisposedWhileSending(t *testing.T) {
+	emitters := make(chan Emitter, 1)
+	observe := CreateWithEmitter(func(emitter Emitter) {
+		emitters <- emitter
+		// Blocks until the item is consumed
+		emitter.OnNext(1)
+		emitter.OnDone()
+	}).Observe()
+	emitter := <-emitters
+	time.Sleep(10 * time.Millisecond)
+	checked := make(chan bool)
+	go func() {
+		checked <- emitter.IsDisposed()
+	}()
+	select {
+	case <-time.After(time.Second):
+		assert.FailNow(t, "IsDisposed blocked by OnNext")
+	case disposed := <-checked:
+		assert.False(t, disposed)
+	}
+	Assert(context.Background(), t, FromChannel(observe), HasItems(1), HasNoError())
+}
+
+func Test_CreateWithEmitter_Concurrent(t *testing.T) {
+	obs := CreateWithEmitter(func(emitter Emitter) {
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				emitter.OnNext(i)
+			}(i)
+		}
+		wg.Wait()
+		emitter.OnDone()
+		emitter.OnNext(10)
+	})
+	items, err := obs.ToSlice(0)
+	assert.NoError(t, err)
+	assert.Len(t, items, 10)
+}
+
 func Test_Defer(t *testing.T) {
 	obs := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
 		next <- Of(1)
@@ -149,6 +392,14 @@ func Test_Defer(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
 }
 
+func Test_Defer_Panic(t *testing.T) {
+	obs := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		panic("foo")
+	}})
+	_, err := obs.ToSlice(0)
+	assert.Equal(t, "foo", err.(PanicError).Value)
+}
+
 func Test_Defer_Multiple(t *testing.T) {
 	obs := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
 		next <- Of(1)
@@ -228,9 +479,35 @@ func Test_Defer_Error(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(1, 2), HasError(errFoo))
 }
 
+func Test_DeferObservable(t *testing.T) {
+	calls := 0
+	obs := DeferObservable(func() Observable {
+		calls++
+		return Just(calls)()
+	})
+	assert.Equal(t, 0, calls)
+	Assert(context.Background(), t, obs, HasItems(1), HasNoError())
+	Assert(context.Background(), t, obs, HasItems(2), HasNoError())
+}
+
+func Test_DeferObservable_ContextCanceled(t *testing.T) {
+	canceled := make(chan struct{})
+	obs := DeferObservable(func() Observable {
+		return Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+			<-ctx.Done()
+			close(canceled)
+		}})
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	obs.Observe(WithContext(ctx))
+	cancel()
+	<-canceled
+}
+
 func Test_Empty(t *testing.T) {
 	obs := Empty()
 	Assert(context.Background(), t, obs, IsEmpty())
+	Assert(context.Background(), t, obs, IsEmpty())
 }
 
 func Test_FromChannel(t *testing.T) {
@@ -263,6 +540,532 @@ func Test_FromChannel_ComposedCapacity(t *testing.T) {
 	assert.Equal(t, 12, cap(obs2.Observe()))
 }
 
+type countIterator struct {
+	current, max int
+}
+
+func (it *countIterator) Next(_ context.Context) (interface{}, bool) {
+	if it.current >= it.max {
+		return nil, false
+	}
+	it.current++
+	return it.current, true
+}
+
+func Test_FromIterator(t *testing.T) {
+	obs := FromIterator(func() Iterator {
+		return &countIterator{max: 3}
+	})
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
+}
+
+type panickingIterator struct{}
+
+func (panickingIterator) Next(context.Context) (interface{}, bool) {
+	panic("foo")
+}
+
+func Test_FromIterator_Panic(t *testing.T) {
+	obs := FromIterator(func() Iterator {
+		return panickingIterator{}
+	})
+	_, err := obs.ToSlice(0)
+	assert.Equal(t, "foo", err.(PanicError).Value)
+}
+
+func Test_FromIterator_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	observe := FromIterator(func() Iterator {
+		return &countIterator{max: math.MaxInt32}
+	}).Observe(WithContext(ctx))
+	<-observe
+	cancel()
+	for range observe {
+	}
+}
+
+func Test_FromJSONDecoder(t *testing.T) {
+	decoder := json.NewDecoder(strings.NewReader(`{"a": 1}
+{"a": 2}
+[3]`))
+	obs := FromJSONDecoder(decoder, nil)
+	Assert(context.Background(), t, obs, HasItems(
+		map[string]interface{}{"a": float64(1)},
+		map[string]interface{}{"a": float64(2)},
+		[]interface{}{float64(3)},
+	), HasNoError())
+}
+
+func Test_FromJSONDecoder_Factory(t *testing.T) {
+	type value struct {
+		A int `json:"a"`
+	}
+	decoder := json.NewDecoder(strings.NewReader(`{"a": 1} {"a": 2}`))
+	obs := FromJSONDecoder(decoder, func() interface{} {
+		return &value{}
+	})
+	Assert(context.Background(), t, obs, HasItems(&value{A: 1}, &value{A: 2}), HasNoError())
+}
+
+func Test_FromJSONDecoder_Error(t *testing.T) {
+	decoder := json.NewDecoder(strings.NewReader(`{"a": 1} {"a" 2} {"a": 3}`))
+	obs := FromJSONDecoder(decoder, nil)
+	Assert(context.Background(), t, obs, HasItems(map[string]interface{}{"a": float64(1)}), HasAnError())
+}
+
+// failingReader always fails with errFoo.
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errFoo
+}
+
+func Test_FromPaginatedFetch(t *testing.T) {
+	pages := map[string][]interface{}{
+		"":  {1, 2},
+		"b": {3},
+		"c": {4, 5},
+	}
+	cursors := map[string]string{"": "b", "b": "c"}
+	var mutex sync.Mutex
+	var fetched []string
+	getFetched := func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return fetched
+	}
+	obs := FromPaginatedFetch(func(cursor string) ([]interface{}, string, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		fetched = append(fetched, cursor)
+		return pages[cursor], cursors[cursor], nil
+	})
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3, 4, 5), HasNoError())
+	assert.Equal(t, []string{"", "b", "c"}, getFetched())
+
+	// The next page is fetched once the items of the previous one are consumed
+	mutex.Lock()
+	fetched = nil
+	mutex.Unlock()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	observe := obs.Observe(WithContext(ctx))
+	assert.Equal(t, 1, (<-observe).V)
+	assert.Equal(t, []string{""}, getFetched())
+}
+
+func zeroBackOff() backoff.BackOff {
+	return &backoff.ZeroBackOff{}
+}
+
+func Test_FromPaginatedFetch_Retry(t *testing.T) {
+	var calls int32
+	obs := FromPaginatedFetch(func(cursor string) ([]interface{}, string, error) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			return nil, "", errFoo
+		}
+		return []interface{}{1}, "", nil
+	}, WithFetchBackOff(zeroBackOff))
+	Assert(context.Background(), t, obs, HasItems(1), HasNoError())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func Test_FromPaginatedFetch_RetryAfter(t *testing.T) {
+	var calls int32
+	obs := FromPaginatedFetch(func(cursor string) ([]interface{}, string, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return nil, "", RetryAfterError{RetryAfter: 50 * time.Millisecond, Err: errFoo}
+		}
+		return []interface{}{1}, "", nil
+	}, WithFetchBackOff(zeroBackOff))
+	start := time.Now()
+	Assert(context.Background(), t, obs, HasItems(1), HasNoError())
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(50*time.Millisecond))
+}
+
+func Test_FromPaginatedFetch_RetriesExhausted(t *testing.T) {
+	var calls int32
+	obs := FromPaginatedFetch(func(cursor string) ([]interface{}, string, error) {
+		if cursor == "" {
+			return []interface{}{1}, "b", nil
+		}
+		atomic.AddInt32(&calls, 1)
+		return nil, "", errFoo
+	}, WithFetchBackOff(func() backoff.BackOff {
+		return backoff.WithMaxRetries(&backoff.ZeroBackOff{}, 2)
+	}))
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errFoo))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func Test_FromPaginatedFetch_BackOffPerObserver(t *testing.T) {
+	var policies int32
+	obs := FromPaginatedFetch(func(cursor string) ([]interface{}, string, error) {
+		return []interface{}{1}, "", nil
+	}, WithFetchBackOff(func() backoff.BackOff {
+		atomic.AddInt32(&policies, 1)
+		return &backoff.ZeroBackOff{}
+	}))
+	wg := sync.WaitGroup{}
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Assert(context.Background(), t, obs, HasItems(1), HasNoError())
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(3), atomic.LoadInt32(&policies))
+}
+
+func Test_FromPaginatedFetch_Permanent(t *testing.T) {
+	var calls int32
+	obs := FromPaginatedFetch(func(cursor string) ([]interface{}, string, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, "", backoff.Permanent(errFoo)
+	})
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func Test_FromPaginatedFetch_HTTP(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"items":[1,2],"next":"b"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"items":[3]}`))
+	}))
+	defer server.Close()
+
+	obs := FromPaginatedFetch(func(cursor string) ([]interface{}, string, error) {
+		response, err := server.Client().Get(server.URL + "?cursor=" + cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			err := HTTPStatusError{StatusCode: response.StatusCode, Status: response.Status}
+			if delay, ok := ParseRetryAfter(response.Header.Get("Retry-After"), time.Now()); ok {
+				return nil, "", RetryAfterError{RetryAfter: delay, Err: err}
+			}
+			return nil, "", err
+		}
+		var page struct {
+			Items []interface{} `json:"items"`
+			Next  string        `json:"next"`
+		}
+		if err := json.NewDecoder(response.Body).Decode(&page); err != nil {
+			return nil, "", backoff.Permanent(err)
+		}
+		return page.Items, page.Next, nil
+	}, WithFetchBackOff(zeroBackOff))
+	Assert(context.Background(), t, obs, HasItems(1.0, 2.0, 3.0), HasNoError())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func Test_ParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	delay, ok := ParseRetryAfter("120", now)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, delay)
+
+	delay, ok = ParseRetryAfter("Mon, 01 Jan 2024 12:00:30 GMT", now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, delay)
+
+	delay, ok = ParseRetryAfter("Mon, 01 Jan 2024 11:00:00 GMT", now)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), delay)
+
+	_, ok = ParseRetryAfter("soon", now)
+	assert.False(t, ok)
+	_, ok = ParseRetryAfter("-1", now)
+	assert.False(t, ok)
+}
+
+func Test_FromReader(t *testing.T) {
+	obs := FromReader(strings.NewReader("abcdefg"), 3)
+	Assert(context.Background(), t, obs, HasItems([]byte("abc"), []byte("def"), []byte("g")), HasNoError())
+	// The reader is consumed
+	Assert(context.Background(), t, obs, IsEmpty(), HasNoError())
+}
+
+func Test_FromReader_Error(t *testing.T) {
+	obs := FromReader(io.MultiReader(strings.NewReader("ab"), failingReader{}), 4)
+	Assert(context.Background(), t, obs, HasItems([]byte("ab")), HasError(errFoo))
+}
+
+func Test_FromReader_InvalidChunkSize(t *testing.T) {
+	Assert(context.Background(), t, FromReader(strings.NewReader("a"), 0), HasError(IllegalInputError{error: "chunk size must be positive"}))
+}
+
+func Test_FromScanner(t *testing.T) {
+	obs := FromScanner(bufio.NewScanner(strings.NewReader("foo\nbar\n\nbaz")))
+	Assert(context.Background(), t, obs, HasItems("foo", "bar", "", "baz"), HasNoError())
+}
+
+func Test_FromScanner_Error(t *testing.T) {
+	obs := FromScanner(bufio.NewScanner(io.MultiReader(strings.NewReader("foo\nbar"), failingReader{})))
+	Assert(context.Background(), t, obs, HasItems("foo", "bar"), HasError(errFoo))
+}
+
+func testSQLRows(t *testing.T, query string) *sql.Rows {
+	db, err := sql.Open("rxgo", "")
+	assert.NoError(t, err)
+	rows, err := db.Query(query)
+	assert.NoError(t, err)
+	return rows
+}
+
+func scanInt(rows *sql.Rows) (interface{}, error) {
+	var n int
+	err := rows.Scan(&n)
+	return n, err
+}
+
+func Test_FromSQLRows(t *testing.T) {
+	closed := atomic.LoadInt32(&testSQLDriver.closedRows)
+	obs := FromSQLRows(testSQLRows(t, "3"), scanInt)
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
+	assert.Equal(t, closed+1, atomic.LoadInt32(&testSQLDriver.closedRows))
+}
+
+func Test_FromSQLRows_IterationError(t *testing.T) {
+	obs := FromSQLRows(testSQLRows(t, "-2"), scanInt)
+	Assert(context.Background(), t, obs, HasItems(1, 2), HasError(errFoo))
+}
+
+func Test_FromSQLRows_ScanError(t *testing.T) {
+	obs := FromSQLRows(testSQLRows(t, "3"), func(rows *sql.Rows) (interface{}, error) {
+		var s []int
+		return s, rows.Scan(&s)
+	})
+	Assert(context.Background(), t, obs, IsEmpty(), HasAnError())
+}
+
+func Test_FromSQLRows_ContextCanceled(t *testing.T) {
+	closed := atomic.LoadInt32(&testSQLDriver.closedRows)
+	ctx, cancel := context.WithCancel(context.Background())
+	observe := FromSQLRows(testSQLRows(t, "1000"), scanInt).Observe(WithContext(ctx))
+	<-observe
+	cancel()
+	for range observe {
+	}
+	assert.Equal(t, closed+1, atomic.LoadInt32(&testSQLDriver.closedRows))
+}
+
+func Test_FromSSE(t *testing.T) {
+	var connections int32
+	lastEventIDs := make(chan string, 3)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastEventIDs <- r.Header.Get("Last-Event-ID")
+		switch atomic.AddInt32(&connections, 1) {
+		case 1:
+			fmt.Fprint(w, "retry: 1\n: comment\n\nid: 1\ndata: foo\ndata: bar\n\nevent: custom\ndata:baz\n\ndata: incomplete")
+		case 2:
+			fmt.Fprint(w, "id: 2\r\ndata: qux\r\n\r\n")
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+	obs := FromSSE(nil, request)
+	Assert(context.Background(), t, obs, HasItems(
+		SSEEvent{ID: "1", Event: "message", Data: "foo\nbar"},
+		SSEEvent{ID: "1", Event: "custom", Data: "baz"},
+		SSEEvent{ID: "2", Event: "message", Data: "qux"},
+	), HasNoError())
+	assert.Equal(t, "", <-lastEventIDs)
+	assert.Equal(t, "1", <-lastEventIDs)
+	assert.Equal(t, "2", <-lastEventIDs)
+}
+
+func Test_FromSSE_StatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+	obs := FromSSE(server.Client(), request, WithSSEBackOff(func() backoff.BackOff {
+		return backoff.WithMaxRetries(&backoff.ZeroBackOff{}, 2)
+	}))
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(HTTPStatusError{
+		StatusCode: http.StatusServiceUnavailable,
+		Status:     "503 Service Unavailable",
+	}))
+}
+
+func Test_FromSSE_Retry(t *testing.T) {
+	var connections int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&connections, 1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			// The lines end with a carriage return only
+			fmt.Fprint(w, "retry: 1\rid: 1\rdata: foo\r\r")
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+	obs := FromSSE(nil, request, WithSSEBackOff(zeroBackOff))
+	Assert(context.Background(), t, obs, HasItems(SSEEvent{ID: "1", Event: "message", Data: "foo"}), HasNoError())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&connections))
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func Test_FromSSE_RequestError(t *testing.T) {
+	var attempts int32
+	client := &http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		atomic.AddInt32(&attempts, 1)
+		return nil, errFoo
+	})}
+	request, err := http.NewRequest(http.MethodGet, "http://example.com/events", nil)
+	assert.NoError(t, err)
+	obs := FromSSE(client, request, WithSSEBackOff(func() backoff.BackOff {
+		return backoff.WithMaxRetries(&backoff.ZeroBackOff{}, 2)
+	}))
+	Assert(context.Background(), t, obs, IsEmpty(), HasAnError())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func Test_FromSSE_ContextCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "data: foo\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+	observe := FromSSE(nil, request).Observe(WithContext(ctx))
+	assert.Equal(t, SSEEvent{Event: "message", Data: "foo"}, (<-observe).V)
+	cancel()
+	for item := range observe {
+		assert.Fail(t, "unexpected item", item)
+	}
+}
+
+func Test_FromSlice(t *testing.T) {
+	obs := FromSlice([]interface{}{1, []int{2, 3}, 4})
+	Assert(context.Background(), t, obs, HasItems(1, []int{2, 3}, 4), HasNoError())
+	Assert(context.Background(), t, obs, HasItems(1, []int{2, 3}, 4), HasNoError())
+}
+
+func Test_FromSlice_Error(t *testing.T) {
+	obs := FromSlice([]interface{}{1, errFoo, 2})
+	Assert(context.Background(), t, obs, HasItems(1, 2), HasError(errFoo))
+}
+
+func Test_FromValueChannel(t *testing.T) {
+	ch := make(chan interface{})
+	go func() {
+		ch <- 1
+		ch <- 2
+		ch <- errFoo
+		close(ch)
+	}()
+	obs := FromValueChannel(ch)
+	Assert(context.Background(), t, obs, HasItems(1, 2), HasError(errFoo))
+}
+
+func Test_FromValueChannel_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	observe := FromValueChannel(make(chan interface{})).Observe(WithContext(ctx))
+	cancel()
+	for range observe {
+	}
+}
+
+func Test_FromCSV(t *testing.T) {
+	obs := FromCSV(strings.NewReader("a,b\n1,2\n3,4\n"))
+	Assert(context.Background(), t, obs, HasItems([]string{"a", "b"}, []string{"1", "2"}, []string{"3", "4"}), HasNoError())
+}
+
+func Test_FromCSV_Header(t *testing.T) {
+	obs := FromCSV(strings.NewReader("a;b\n1;2\n3;4\n"), WithCSVHeader(), WithCSVDelimiter(';'))
+	Assert(context.Background(), t, obs, HasItems(
+		map[string]string{"a": "1", "b": "2"},
+		map[string]string{"a": "3", "b": "4"},
+	), HasNoError())
+}
+
+func Test_FromCSV_InvalidRecord(t *testing.T) {
+	const input = "a,b\n1,2\n3\n5,6\n"
+
+	obs := FromCSV(strings.NewReader(input), WithCSVHeader())
+	Assert(context.Background(), t, obs, HasItems(map[string]string{"a": "1", "b": "2"}), HasAnError())
+
+	obs = FromCSV(strings.NewReader(input), WithCSVHeader(), WithErrorStrategy(ContinueOnError))
+	Assert(context.Background(), t, obs, HasItems(
+		map[string]string{"a": "1", "b": "2"},
+		map[string]string{"a": "5", "b": "6"},
+	), HasAnError())
+
+	obs = FromCSV(strings.NewReader(input), WithCSVHeader(), WithCSVSkipInvalidRecords())
+	Assert(context.Background(), t, obs, HasItems(
+		map[string]string{"a": "1", "b": "2"},
+		map[string]string{"a": "5", "b": "6"},
+	), HasNoError())
+}
+
+func Test_FromCSV_ReadError(t *testing.T) {
+	obs := FromCSV(io.MultiReader(strings.NewReader("a,b\n"), failingReader{}), WithCSVSkipInvalidRecords())
+	Assert(context.Background(), t, obs, HasItems([]string{"a", "b"}), HasError(errFoo))
+}
+
+func Test_FromCron_TestScheduler(t *testing.T) {
+	s := NewTestScheduler(time.Date(2020, 1, 1, 0, 0, 30, 0, time.UTC))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	observe := FromCron("CRON_TZ=UTC */10 * * * *", WithClock(s), WithContext(ctx)).Observe(WithBufferedChannel(3))
+	for i := 1; i <= 3; i++ {
+		// The next time is scheduled once the previous one is emitted
+		s.BlockUntil(1)
+		s.AdvanceTimeBy(10 * time.Minute)
+		assert.Equal(t, time.Date(2020, 1, 1, 0, 10*i, 0, 0, time.UTC), (<-observe).V)
+	}
+}
+
+func Test_FromCron_Location(t *testing.T) {
+	s := NewTestScheduler(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	observe := FromCron("CRON_TZ=Etc/GMT-2 @daily", WithClock(s), WithContext(ctx)).Observe()
+	s.BlockUntil(1)
+	s.AdvanceTimeBy(24 * time.Hour)
+	item := <-observe
+	assert.True(t, item.V.(time.Time).Equal(time.Date(2020, 1, 1, 22, 0, 0, 0, time.UTC)))
+}
+
+func Test_FromCron_Invalid(t *testing.T) {
+	Assert(context.Background(), t, FromCron("* * *"), IsEmpty(), HasError(IllegalInputError{error: `cron: expected 5 fields, found 3 in "* * *"`}))
+}
+
 func Test_FromEventSource_ObservationAfterAllSent(t *testing.T) {
 	const max = 10
 	next := make(chan Item, max)
@@ -307,6 +1110,115 @@ func Test_FromEventSource_Drop(t *testing.T) {
 	}))
 }
 
+func Test_FromEventSource_Latest(t *testing.T) {
+	next := make(chan Item, 10)
+	obs := FromEventSource(next, WithBackPressureStrategy(Latest))
+	observe := obs.Observe()
+
+	for i := 0; i < 10; i++ {
+		next <- Of(i)
+	}
+	close(next)
+	time.Sleep(50 * time.Millisecond)
+
+	items, err := collect(context.Background(), observe)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{9}, items)
+}
+
+func Test_FromEventSource_Fail(t *testing.T) {
+	next := make(chan Item, 10)
+	obs := FromEventSource(next, WithBackPressureStrategy(Fail))
+	observe := obs.Observe()
+
+	for i := 0; i < 10; i++ {
+		next <- Of(i)
+	}
+	close(next)
+	time.Sleep(50 * time.Millisecond)
+
+	items, err := collect(context.Background(), observe)
+	assert.NoError(t, err)
+	// The item accepted before the observer lagged is delivered first
+	assert.Equal(t, []interface{}{0, BackpressureError{error: "observer not ready"}}, items)
+}
+
+func Test_FromEventSource_LateObserver(t *testing.T) {
+	next := make(chan Item)
+	obs := FromEventSource(next)
+	first := obs.Observe()
+	next <- Of(1)
+	assert.Equal(t, 1, (<-first).V)
+
+	second := obs.Observe()
+	go func() {
+		next <- Of(2)
+		close(next)
+	}()
+	assert.Equal(t, 2, (<-first).V)
+	items, err := collect(context.Background(), second)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{2}, items)
+}
+
+func Test_FromEventSource_DisposedObserver(t *testing.T) {
+	next := make(chan Item)
+	obs := FromEventSource(next)
+	ctx, cancel := context.WithCancel(context.Background())
+	// Never consumed: the source would be blocked until the observer is disposed
+	obs.Observe(WithContext(ctx))
+	observe := obs.Observe()
+
+	go func() {
+		next <- Of(1)
+		cancel()
+		next <- Of(2)
+		close(next)
+	}()
+	items, err := collect(context.Background(), observe)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2}, items)
+}
+
+func Test_Generate(t *testing.T) {
+	obs := Generate(1, func(i interface{}) bool {
+		return i.(int) <= 100
+	}, func(i interface{}) interface{} {
+		return i.(int) * 3
+	}, func(i interface{}) interface{} {
+		return fmt.Sprintf("%d", i)
+	})
+	Assert(context.Background(), t, obs, HasItems("1", "3", "9", "27", "81"), HasNoError())
+	// Each observer runs the loop from the initial state
+	Assert(context.Background(), t, obs, HasItems("1", "3", "9", "27", "81"), HasNoError())
+}
+
+func Test_Generate_Lazy(t *testing.T) {
+	iterations := 0
+	obs := Generate(0, func(interface{}) bool {
+		return true
+	}, func(i interface{}) interface{} {
+		iterations++
+		return i.(int) + 1
+	}, nil)
+	assert.Equal(t, 0, iterations)
+	Assert(context.Background(), t, obs.Take(3), HasItems(0, 1, 2), HasNoError())
+}
+
+func Test_Generate_Error(t *testing.T) {
+	obs := Generate(1, func(i interface{}) bool {
+		return i.(int) <= 3
+	}, func(i interface{}) interface{} {
+		return i.(int) + 1
+	}, func(i interface{}) interface{} {
+		if i == 2 {
+			return errFoo
+		}
+		return i
+	})
+	Assert(context.Background(), t, obs, HasItems(1, 3), HasError(errFoo))
+}
+
 func Test_Interval(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	obs := Interval(WithDuration(time.Nanosecond), WithContext(ctx))
@@ -317,6 +1229,52 @@ func Test_Interval(t *testing.T) {
 	Assert(context.Background(), t, obs, IsNotEmpty())
 }
 
+func Test_Interval_TestScheduler(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	observe := Interval(WithDuration(time.Hour), WithContext(ctx), WithClock(s)).Observe(WithBufferedChannel(3))
+	s.BlockUntil(1)
+	s.AdvanceTimeBy(3 * time.Hour)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, i, (<-observe).V)
+	}
+}
+
+func Test_Interval_ObserverContextCanceled(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	obs := Interval(WithDuration(time.Hour), WithClock(s))
+	ctx, cancel := context.WithCancel(context.Background())
+	observe := obs.Observe(WithContext(ctx))
+	s.BlockUntil(1)
+	s.AdvanceTimeBy(time.Hour)
+	assert.Equal(t, 0, (<-observe).V)
+	cancel()
+	for range observe {
+	}
+	// The ticker of the observer goroutine is stopped
+	s.AdvanceTimeBy(time.Hour)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	assert.Empty(t, s.timers)
+}
+
+func Test_Interval_IndependentObservers(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	obs := Interval(WithDuration(time.Hour), WithClock(s), WithContext(ctx))
+	observe1 := obs.Observe()
+	s.BlockUntil(1)
+	s.AdvanceTimeBy(time.Hour)
+	assert.Equal(t, 0, (<-observe1).V)
+	observe2 := obs.Observe()
+	s.BlockUntil(2)
+	s.AdvanceTimeBy(time.Hour)
+	assert.Equal(t, 1, (<-observe1).V)
+	assert.Equal(t, 0, (<-observe2).V)
+}
+
 func Test_JustItem(t *testing.T) {
 	single := JustItem(1)
 	Assert(context.Background(), t, single, HasItem(1), HasNoError())
@@ -379,6 +1337,52 @@ func Test_Merge_Error(t *testing.T) {
 	Assert(context.Background(), t, obs, IsNotEmpty(), HasError(errFoo))
 }
 
+func Test_Merge_ErrorStopsOtherObservables(t *testing.T) {
+	canceled := make(chan struct{})
+	obs := Merge([]Observable{Thrown(errFoo), Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		<-ctx.Done()
+		close(canceled)
+	}})})
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+	<-canceled
+}
+
+func Test_Merge_ContinueOnError(t *testing.T) {
+	obs := Merge([]Observable{testObservable(1, errFoo, 2), testObservable(3)}, WithErrorStrategy(ContinueOnError))
+	Assert(context.Background(), t, obs, HasItemsNoOrder(1, 2, 3), HasError(errFoo))
+}
+
+func Test_MergeDelayError(t *testing.T) {
+	observe := MergeDelayError([]Observable{testObservable(1, errFoo), testObservable(2, 3)}).Observe()
+	var items []interface{}
+	var errs []error
+	for item := range observe {
+		if item.Error() {
+			errs = append(errs, item.E)
+			continue
+		}
+		assert.Empty(t, errs, "an item was emitted after an error")
+		items = append(items, item.V)
+	}
+	assert.ElementsMatch(t, []interface{}{1, 2, 3}, items)
+	assert.Equal(t, []error{errFoo}, errs)
+}
+
+func Test_Merge_ContextCanceled(t *testing.T) {
+	canceled := make(chan struct{})
+	obs := Merge([]Observable{Never(), Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		<-ctx.Done()
+		close(canceled)
+	}})})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	observe := obs.Observe(WithContext(ctx))
+	cancel()
+	<-canceled
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
 func Test_Merge_Interval(t *testing.T) {
 	var obs []Observable
 	ctx, cancel := context.WithCancel(context.Background())
@@ -400,6 +1404,19 @@ func Test_Merge_Interval(t *testing.T) {
 	Assert(ctx, t, Merge(obs), HasNoError(), HasItemsNoOrder(10, 11, 12, 20, 21, 22))
 }
 
+func Test_Never(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	observe := Never().Observe(WithContext(ctx))
+	select {
+	case <-observe:
+		assert.FailNow(t, "observable terminated")
+	case <-time.After(5 * time.Millisecond):
+	}
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
 func Test_Range(t *testing.T) {
 	obs := Range(5, 3)
 	Assert(context.Background(), t, obs, HasItems(5, 6, 7, 8))
@@ -417,6 +1434,15 @@ func Test_Range_MaximumExceeded(t *testing.T) {
 	Assert(context.Background(), t, obs, HasAnError())
 }
 
+func Test_Range_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	observe := Range(0, 10).Observe(WithContext(ctx))
+	<-observe
+	cancel()
+	for range observe {
+	}
+}
+
 func Test_Start(t *testing.T) {
 	obs := Start([]Supplier{func(ctx context.Context) Item {
 		return Of(1)
@@ -426,6 +1452,12 @@ func Test_Start(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItemsNoOrder(1, 2))
 }
 
+func Test_Throw(t *testing.T) {
+	obs := Throw(errFoo)
+	Assert(context.Background(), t, obs, HasError(errFoo))
+	Assert(context.Background(), t, obs, HasError(errFoo))
+}
+
 func Test_Thrown(t *testing.T) {
 	obs := Thrown(errFoo)
 	Assert(context.Background(), t, obs, HasError(errFoo))
@@ -440,6 +1472,29 @@ func Test_Timer(t *testing.T) {
 	}
 }
 
+func Test_Timer_TestScheduler(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	observe := Timer(WithDuration(time.Hour), WithClock(s)).Observe()
+	s.AdvanceTimeBy(time.Minute)
+	select {
+	case <-observe:
+		assert.FailNow(t, "observable closed too early")
+	default:
+	}
+	s.AdvanceTimeBy(time.Hour)
+	item, ok := <-observe
+	assert.True(t, ok)
+	assert.Equal(t, struct{}{}, item.V)
+	_, ok = <-observe
+	assert.False(t, ok)
+}
+
+func Test_Timer_Item(t *testing.T) {
+	obs := Timer(WithDuration(time.Nanosecond))
+	Assert(context.Background(), t, obs, HasItems(struct{}{}), HasNoError())
+	Assert(context.Background(), t, obs, HasItems(struct{}{}), HasNoError())
+}
+
 func Test_Timer_Empty(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	obs := Timer(WithDuration(time.Hour), WithContext(ctx))
@@ -453,3 +1508,116 @@ func Test_Timer_Empty(t *testing.T) {
 	case <-obs.Observe():
 	}
 }
+
+type testResource struct {
+	closed int32
+}
+
+func (r *testResource) Close() error {
+	atomic.AddInt32(&r.closed, 1)
+	return nil
+}
+
+func (r *testResource) isClosed() bool {
+	return atomic.LoadInt32(&r.closed) == 1
+}
+
+func Test_Using(t *testing.T) {
+	resources := make([]*testResource, 0)
+	obs := Using(func() (io.Closer, error) {
+		r := &testResource{}
+		resources = append(resources, r)
+		return r, nil
+	}, func(closer io.Closer) Observable {
+		assert.False(t, closer.(*testResource).isClosed())
+		return Just(1, 2, 3)()
+	})
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
+	assert.Len(t, resources, 2)
+	for _, r := range resources {
+		assert.True(t, r.isClosed())
+	}
+}
+
+func Test_Using_Error(t *testing.T) {
+	r := &testResource{}
+	obs := Using(func() (io.Closer, error) {
+		return r, nil
+	}, func(io.Closer) Observable {
+		return testObservable(1, errFoo, 2)
+	})
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errFoo))
+	assert.True(t, r.isClosed())
+}
+
+func Test_Using_ResourceError(t *testing.T) {
+	obs := Using(func() (io.Closer, error) {
+		return nil, errFoo
+	}, func(io.Closer) Observable {
+		assert.FailNow(t, "observable factory called")
+		return nil
+	})
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+}
+
+func Test_Using_Dispose(t *testing.T) {
+	r := &testResource{}
+	ctx, cancel := context.WithCancel(context.Background())
+	obs := Using(func() (io.Closer, error) {
+		return r, nil
+	}, func(io.Closer) Observable {
+		return Never()
+	})
+	observe := obs.Observe(WithContext(ctx))
+	cancel()
+	for range observe {
+	}
+	assert.True(t, r.isClosed())
+}
+
+func Test_Zip(t *testing.T) {
+	obs := Zip(func(ii ...interface{}) interface{} {
+		sum := 0
+		for _, v := range ii {
+			sum += v.(int)
+		}
+		return sum
+	}, []Observable{testObservable(1, 2, 3), testObservable(10, 20, 30, 40), testObservable(100, 200, 300)})
+	Assert(context.Background(), t, obs, HasItems(111, 222, 333), HasNoError())
+}
+
+func Test_Zip_QueuedItems(t *testing.T) {
+	ch := make(chan Item)
+	fastDone := make(chan struct{})
+	fast := Defer([]Producer{func(_ context.Context, next chan<- Item) {
+		defer close(fastDone)
+		next <- Of(1)
+		next <- Of(2)
+		next <- Of(3)
+	}})
+	go func() {
+		<-fastDone
+		ch <- Of("a")
+		ch <- Of("b")
+		close(ch)
+	}()
+	obs := Zip(func(ii ...interface{}) interface{} {
+		return fmt.Sprintf("%v%v", ii[0], ii[1])
+	}, []Observable{fast, FromChannel(ch)})
+	Assert(context.Background(), t, obs, HasItems("1a", "2b"), HasNoError())
+}
+
+func Test_Zip_Error(t *testing.T) {
+	obs := Zip(func(ii ...interface{}) interface{} {
+		return ii
+	}, []Observable{testObservable(1, 2), testObservable(errFoo)})
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+}
+
+func Test_Zip_Empty(t *testing.T) {
+	obs := Zip(func(ii ...interface{}) interface{} {
+		return ii
+	}, []Observable{Never(), Empty()})
+	Assert(context.Background(), t, obs, IsEmpty(), HasNoError())
+}
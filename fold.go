@@ -0,0 +1,87 @@
+package rxgo
+
+import "context"
+
+// fold describes an aggregation shared by the terminal operators (Reduce, Count, Sum*, Average*, Max, Min).
+//
+// Each item is folded into the accumulator using step. In parallel mode, every worker folds its own accumulator
+// and the partial accumulators are then folded together using step as well, so step must be associative to be
+// run in parallel. Once the Observable completes, result turns the accumulator and the number of folded items
+// into the value to emit, if any.
+type fold struct {
+	seed   interface{}
+	step   Func2
+	result func(acc interface{}, count int64) (interface{}, bool)
+}
+
+func (f fold) operatorFactory() func() operator {
+	return func() operator {
+		return &foldOperator{
+			fold: f,
+			acc:  f.seed,
+		}
+	}
+}
+
+type foldOperator struct {
+	fold  fold
+	acc   interface{}
+	count int64
+	// failed is set when an error was emitted so that no result is emitted afterwards.
+	// With ContinueOnError, it is reset by the next item or partial accumulator.
+	failed bool
+}
+
+func (op *foldOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	v, err := op.fold.step(ctx, op.acc, item.V)
+	if err != nil {
+		Error(err).SendContext(ctx, dst)
+		op.failed = true
+		operatorOptions.stop()
+		return
+	}
+	op.acc = v
+	op.count++
+	op.failed = false
+}
+
+func (op *foldOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+	op.failed = true
+}
+
+func (op *foldOperator) end(ctx context.Context, dst chan<- Item) {
+	if op.failed {
+		return
+	}
+	if v, ok := op.fold.result(op.acc, op.count); ok {
+		Of(v).SendContext(ctx, dst)
+	}
+}
+
+func (op *foldOperator) gatherNext(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	partial := item.V.(*foldOperator)
+	op.failed = false
+	if partial.count == 0 {
+		return
+	}
+	if op.count == 0 {
+		op.acc = partial.acc
+		op.count = partial.count
+		return
+	}
+	v, err := op.fold.step(ctx, op.acc, partial.acc)
+	if err != nil {
+		Error(err).SendContext(ctx, dst)
+		op.failed = true
+		operatorOptions.stop()
+		return
+	}
+	op.acc = v
+	op.count += partial.count
+}
+
+// nonEmptyResult emits the accumulator unless no item was folded.
+func nonEmptyResult(acc interface{}, count int64) (interface{}, bool) {
+	return acc, count != 0
+}
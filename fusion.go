@@ -0,0 +1,133 @@
+package rxgo
+
+import "context"
+
+// fusableOperator is a stateless operator emitting at most one item per call. Adjacent fusable operators are run
+// in a single goroutine, without a channel between them.
+type fusableOperator interface {
+	operator
+	fusable()
+}
+
+func (op *mapOperator) fusable() {}
+
+func (op *filterOperator) fusable() {}
+
+// fusion holds the fusable operators of an Observable along with the source they observe.
+type fusion struct {
+	source    Iterable
+	operators []func() operator
+	option    Option
+	// batchSize is the largest batch size set on the operators
+	batchSize int
+}
+
+// fuse returns the fusion of the operators of iterable with the operator created by operatorFactory, or nil if
+// they can't be fused.
+func fuse(iterable Iterable, operatorFactory func() operator, option Option) *fusion {
+	o, ok := iterable.(*ObservableImpl)
+	if !ok || o.fusion == nil || !isFusable(operatorFactory, option) {
+		return nil
+	}
+	if option.getErrorStrategy() != o.fusion.option.getErrorStrategy() ||
+		option.getPanicStrategy() != o.fusion.option.getPanicStrategy() {
+		return nil
+	}
+	operators := make([]func() operator, 0, len(o.fusion.operators)+1)
+	operators = append(operators, o.fusion.operators...)
+	batchSize := option.getBatchSize()
+	if o.fusion.batchSize > batchSize {
+		batchSize = o.fusion.batchSize
+	}
+	return &fusion{
+		source:    o.fusion.source,
+		operators: append(operators, operatorFactory),
+		option:    option,
+		batchSize: batchSize,
+	}
+}
+
+// newFusion returns the fusion of an Observable made of a single operator, or nil if it is not fusable.
+func newFusion(iterable Iterable, operatorFactory func() operator, option Option) *fusion {
+	if !isFusable(operatorFactory, option) {
+		return nil
+	}
+	return &fusion{
+		source:    iterable,
+		operators: []func() operator{operatorFactory},
+		option:    option,
+		batchSize: option.getBatchSize(),
+	}
+}
+
+// isFusable returns whether an operator runs sequentially without instrumentation. The fused operators are not
+// run with their own context or channel capacity: an operator set with one of them is not fused.
+func isFusable(operatorFactory func() operator, option Option) bool {
+	if _, ok := operatorFactory().(fusableOperator); !ok {
+		return false
+	}
+	parallel, _ := option.getPool()
+	serialized, _ := option.isSerialized()
+	capacity, _ := option.getChannelCapacity()
+	logger, _ := option.getLogger()
+	return !parallel && !serialized && !capacity && !option.isEagerObservation() && !option.isStreamErrors() &&
+		option.getMetrics() == nil && option.getTracer() == nil && logger == nil &&
+		option.buildContext() == context.Background()
+}
+
+// operator returns an operator running the fused operators.
+func (f *fusion) operator() operator {
+	operators := make([]operator, 0, len(f.operators))
+	for _, operatorFactory := range f.operators {
+		operators = append(operators, operatorFactory())
+	}
+	return &fusedOperator{
+		operators: operators,
+		out:       make(chan Item, 1),
+		option:    f.option,
+	}
+}
+
+// fusedOperator runs operators one after the other, each one emitting at most one item to the next one.
+type fusedOperator struct {
+	operators []operator
+	out       chan Item
+	option    Option
+}
+
+func (op *fusedOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	op.push(ctx, 0, item, dst, operatorOptions)
+}
+
+func (op *fusedOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	op.push(ctx, 0, item, dst, operatorOptions)
+}
+
+func (op *fusedOperator) end(ctx context.Context, dst chan<- Item) {
+	for i, o := range op.operators {
+		o.end(ctx, op.out)
+		op.pull(ctx, i+1, dst, operatorOptions{stop: func() {}, complete: func() {}})
+	}
+}
+
+func (op *fusedOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
+// push processes an item from the operator at index.
+func (op *fusedOperator) push(ctx context.Context, index int, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	if index == len(op.operators) {
+		item.SendContext(ctx, dst)
+		return
+	}
+	process(ctx, op.operators[index], item, op.out, operatorOptions, op.option)
+	op.pull(ctx, index+1, dst, operatorOptions)
+}
+
+// pull pushes the item emitted by the previous operator, if any, to the operator at index.
+func (op *fusedOperator) pull(ctx context.Context, index int, dst chan<- Item, operatorOptions operatorOptions) {
+	select {
+	case item := <-op.out:
+		op.push(ctx, index, item, dst, operatorOptions)
+	default:
+	}
+}
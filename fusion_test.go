@@ -0,0 +1,100 @@
+package rxgo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Fusion(t *testing.T) {
+	obs := testObservable(1, 2, 3, 4).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i.(int) * 10, nil
+		}).
+		Filter(func(i interface{}) bool {
+			return i.(int) != 20
+		}).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i.(int) + 1, nil
+		})
+	Assert(context.Background(), t, obs, HasItems(11, 31, 41), HasNoError())
+	assert.Len(t, obs.(*ObservableImpl).fusion.operators, 3)
+}
+
+func Test_Fusion_Error(t *testing.T) {
+	obs := testObservable(1, 2, 3).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			if i == 2 {
+				return nil, errFoo
+			}
+			return i, nil
+		}).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i.(int) * 10, nil
+		})
+	Assert(context.Background(), t, obs, HasItems(10), HasError(errFoo))
+}
+
+func Test_Fusion_ContinueOnError(t *testing.T) {
+	obs := testObservable(1, 2, 3).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			if i == 2 {
+				return nil, errFoo
+			}
+			return i, nil
+		}, WithErrorStrategy(ContinueOnError)).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i.(int) * 10, nil
+		}, WithErrorStrategy(ContinueOnError))
+	Assert(context.Background(), t, obs, HasItems(10, 30), HasError(errFoo))
+	assert.NotNil(t, obs.(*ObservableImpl).fusion)
+}
+
+func Test_Fusion_Panic(t *testing.T) {
+	obs := testObservable(1, 2).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		}, WithPanicStrategy(RecoverPanic)).
+		Filter(func(i interface{}) bool {
+			if i == 2 {
+				panic(errFoo)
+			}
+			return true
+		}, WithPanicStrategy(RecoverPanic))
+	ch := obs.Observe()
+	assert.Equal(t, 1, (<-ch).V)
+	assert.Equal(t, errFoo, (<-ch).E.(PanicError).Value)
+}
+
+func Test_Fusion_EndOfStatefulOperator(t *testing.T) {
+	obs := testObservable(1, 2, 3).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i.(int) * 10, nil
+		}).
+		Filter(func(i interface{}) bool {
+			return i.(int) > 10
+		}).
+		BufferWithCount(5)
+	Assert(context.Background(), t, obs, HasItems([]interface{}{20, 30}), HasNoError())
+}
+
+func Test_Fusion_NotFused(t *testing.T) {
+	identity := func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	}
+	source := testObservable(1)
+
+	for name, obs := range map[string]Observable{
+		"capacity":       source.Map(identity, WithBufferedChannel(1)).Map(identity),
+		"context":        source.Map(identity, WithContext(context.TODO())).Map(identity),
+		"error strategy": source.Map(identity, WithErrorStrategy(ContinueOnError)).Map(identity),
+		"pool":           source.Map(identity).Map(identity, WithPool(2)),
+		"metrics":        source.Map(identity).Map(identity, WithMetrics(&recordingMetrics{})),
+		"stateful":       source.Map(identity).BufferWithCount(1),
+	} {
+		if fusion := obs.(*ObservableImpl).fusion; fusion != nil {
+			assert.Len(t, fusion.operators, 1, name)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Package sink implements the loop shared by the functions of the connector packages writing the items of an
+// Observable to an external system.
+package sink
+
+import (
+	"context"
+
+	"github.com/reactivex/rxgo/v2"
+)
+
+// Run observes the items with observe until the Observable terminates, passing each one to send. The observation
+// is bound to ctx and stopped once Run returns. It returns the number of messages sent, as counted by send, along
+// with the first error: emitted by the Observable, returned by send, or the error of ctx once cancelled.
+func Run(ctx context.Context, observe func(opts ...rxgo.Option) <-chan rxgo.Item, send func(ctx context.Context, v interface{}) (int, error), opts ...rxgo.Option) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	sent := 0
+	for item := range observe(append(opts[:len(opts):len(opts)], rxgo.WithContext(ctx))...) {
+		if item.Error() {
+			return sent, item.E
+		}
+		n, err := send(ctx, item.V)
+		sent += n
+		if err != nil {
+			return sent, err
+		}
+	}
+	return sent, ctx.Err()
+}
+
+// Bytes returns the payload of an item being either a []byte or a string.
+func Bytes(v interface{}) ([]byte, bool) {
+	switch v := v.(type) {
+	case []byte:
+		return v, true
+	case string:
+		return []byte(v), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,54 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/reactivex/rxgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var errFoo = errors.New("foo")
+
+func count(_ context.Context, v interface{}) (int, error) {
+	if v == "fail" {
+		return 0, errFoo
+	}
+	return 1, nil
+}
+
+func Test_Run(t *testing.T) {
+	n, err := Run(context.Background(), rxgo.Just("foo", "bar")().Observe, count)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
+
+func Test_Run_Error(t *testing.T) {
+	n, err := Run(context.Background(), rxgo.Just("foo", errFoo, "bar")().Observe, count)
+	assert.Equal(t, errFoo, err)
+	assert.Equal(t, 1, n)
+
+	n, err = Run(context.Background(), rxgo.Just("foo", "fail", "bar")().Observe, count)
+	assert.Equal(t, errFoo, err)
+	assert.Equal(t, 1, n)
+}
+
+func Test_Run_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	n, err := Run(ctx, rxgo.Never().Observe, count)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 0, n)
+}
+
+func Test_Bytes(t *testing.T) {
+	b, ok := Bytes("foo")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("foo"), b)
+	b, ok = Bytes([]byte("bar"))
+	assert.True(t, ok)
+	assert.Equal(t, []byte("bar"), b)
+	_, ok = Bytes(1)
+	assert.False(t, ok)
+}
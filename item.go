@@ -19,6 +19,12 @@ type (
 		V         interface{}
 	}
 
+	// PairItem holds two consecutive items, see Pairwise.
+	PairItem struct {
+		Previous interface{}
+		Current  interface{}
+	}
+
 	// CloseChannelStrategy indicates a strategy on whether to close a channel.
 	CloseChannelStrategy uint32
 )
@@ -46,42 +52,85 @@ func SendItems(ctx context.Context, ch chan<- Item, strategy CloseChannelStrateg
 	if strategy == CloseChannel {
 		defer close(ch)
 	}
-	send(ctx, ch, items...)
+	send(ctx, func(item Item) bool {
+		return item.SendContext(ctx, ch)
+	}, items...)
 }
 
-func send(ctx context.Context, ch chan<- Item, items ...interface{}) {
+// send emits the items, the channels and slices being flattened. It returns false as soon as emit does, or once the
+// context is cancelled.
+func send(ctx context.Context, emit func(Item) bool, items ...interface{}) bool {
 	for _, currentItem := range items {
 		switch item := currentItem.(type) {
 		default:
 			rt := reflect.TypeOf(item)
 			switch rt.Kind() {
 			default:
-				Of(item).SendContext(ctx, ch)
+				if !emit(Of(item)) {
+					return false
+				}
 			case reflect.Chan:
-				in := reflect.ValueOf(currentItem)
+				cases := []reflect.SelectCase{
+					{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+					{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(currentItem)},
+				}
 				for {
-					v, ok := in.Recv()
+					chosen, v, ok := reflect.Select(cases)
+					if chosen == 0 {
+						return false
+					}
 					if !ok {
-						return
+						break
 					}
-					currentItem := v.Interface()
-					switch item := currentItem.(type) {
+					sent := false
+					switch item := v.Interface().(type) {
 					default:
-						Of(item).SendContext(ctx, ch)
+						sent = emit(Of(item))
 					case error:
-						Error(item).SendContext(ctx, ch)
+						sent = emit(Error(item))
+					}
+					if !sent {
+						return false
 					}
 				}
 			case reflect.Slice:
 				s := reflect.ValueOf(currentItem)
 				for i := 0; i < s.Len(); i++ {
-					send(ctx, ch, s.Index(i).Interface())
+					if !send(ctx, emit, s.Index(i).Interface()) {
+						return false
+					}
 				}
 			}
 		case error:
-			Error(item).SendContext(ctx, ch)
+			if !emit(Error(item)) {
+				return false
+			}
 		}
 	}
+	return true
+}
+
+// WithContext returns a copy of the item whose value carries ctx, e.g. a context holding a trace span, to be emitted
+// by a source. The value is unwrapped by the operators run sequentially, the items they emit carrying the context
+// in turn, or the span started by an operator created with WithTracer. Its cancellation is not taken into account.
+// An error item does not carry a context.
+func (i Item) WithContext(ctx context.Context) Item {
+	if i.E != nil {
+		return i
+	}
+	if v, ok := i.V.(contextValue); ok {
+		i.V = v.v
+	}
+	i.V = contextValue{ctx: ctx, v: i.V}
+	return i
+}
+
+// Context returns the context carried by the item, or context.Background() if none.
+func (i Item) Context() context.Context {
+	if v, ok := i.V.(contextValue); ok {
+		return v.ctx
+	}
+	return context.Background()
 }
 
 // Error checks if an item is an error.
@@ -97,6 +146,9 @@ func (i Item) SendBlocking(ch chan<- Item) {
 // SendContext sends an item and blocks until it is sent or a context canceled.
 // It returns a boolean to indicate whether the item was sent.
 func (i Item) SendContext(ctx context.Context, ch chan<- Item) bool {
+	if c, ok := ctx.(*itemContext); ok && c.dst == ch {
+		i = c.carry(i)
+	}
 	select {
 	case <-ctx.Done():
 		return false
@@ -115,3 +167,15 @@ func (i Item) SendNonBlocking(ch chan<- Item) bool {
 		return true
 	}
 }
+
+// observerChannel builds a channel compatible with a backpressure strategy.
+func observerChannel(option Option, capacity int) chan Item {
+	capacity += option.getBufferedChannelCapacity()
+	switch option.getBackPressureStrategy() {
+	case Latest, Fail:
+		if capacity == 0 {
+			capacity = 1
+		}
+	}
+	return make(chan Item, capacity)
+}
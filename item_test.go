@@ -3,6 +3,7 @@ package rxgo
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -31,6 +32,25 @@ func Test_SendItems_SliceWithError(t *testing.T) {
 	Assert(context.Background(), t, FromChannel(ch), HasItems(1, 3), HasError(errFoo))
 }
 
+func Test_SendItems_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	source := make(chan int)
+	ch := make(chan Item)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		SendItems(ctx, ch, CloseChannel, source, 1, 2)
+	}()
+	cancel()
+	select {
+	case <-time.After(time.Second):
+		assert.FailNow(t, "SendItems not stopped")
+	case <-done:
+	}
+	_, ok := <-ch
+	assert.False(t, ok)
+}
+
 func Test_Item_SendBlocking(t *testing.T) {
 	ch := make(chan Item, 1)
 	defer close(ch)
@@ -52,3 +72,24 @@ func Test_Item_SendNonBlocking(t *testing.T) {
 	assert.True(t, Of(5).SendNonBlocking(ch))
 	assert.False(t, Of(5).SendNonBlocking(ch))
 }
+
+func Test_Item_Context(t *testing.T) {
+	item := Of(1)
+	assert.Equal(t, context.Background(), item.Context())
+
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "span")
+	traced := item.WithContext(ctx)
+	assert.Equal(t, ctx, traced.Context())
+	assert.Equal(t, context.Background(), item.Context())
+	assert.Equal(t, Error(errFoo), Error(errFoo).WithContext(ctx))
+
+	// The value is unwrapped by the operators, whose functions receive the context
+	ch := make(chan Item, 1)
+	ch <- traced
+	close(ch)
+	obs := FromChannel(ch).Map(func(ctx context.Context, i interface{}) (interface{}, error) {
+		return []interface{}{i, ctx.Value(key{})}, nil
+	})
+	Assert(context.Background(), t, obs, HasItems([]interface{}{1, "span"}), HasNoError())
+}
@@ -22,7 +22,7 @@ func newChannelIterable(next <-chan Item, opts ...Option) Iterable {
 }
 
 func (i *channelIterable) Observe(opts ...Option) <-chan Item {
-	mergedOptions := append(i.opts, opts...)
+	mergedOptions := mergeOptions(i.opts, opts)
 	option := parseOptions(mergedOptions...)
 
 	if !option.isConnectable() {
@@ -69,7 +69,7 @@ func (i *channelIterable) produce(ctx context.Context) {
 			}
 			i.mutex.RLock()
 			for _, subscriber := range i.subscribers {
-				subscriber <- item
+				item.SendContext(ctx, subscriber)
 			}
 			i.mutex.RUnlock()
 		}
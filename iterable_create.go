@@ -5,8 +5,10 @@ import (
 	"sync"
 )
 
+// createIterable runs the producers for each observer, so that each of them observes its own sequence. Once
+// connectable, the producers run once on connect, their items being multicasted to the observers.
 type createIterable struct {
-	next                   <-chan Item
+	fs                     []Producer
 	opts                   []Option
 	subscribers            []chan Item
 	mutex                  sync.RWMutex
@@ -14,33 +16,24 @@ type createIterable struct {
 }
 
 func newCreateIterable(fs []Producer, opts ...Option) Iterable {
-	option := parseOptions(opts...)
-	next := option.buildChannel()
-	ctx := option.buildContext()
-
-	go func() {
-		defer close(next)
-		for _, f := range fs {
-			f(ctx, next)
-		}
-	}()
-
 	return &createIterable{
+		fs:   fs,
 		opts: opts,
-		next: next,
 	}
 }
 
 func (i *createIterable) Observe(opts ...Option) <-chan Item {
-	mergedOptions := append(i.opts, opts...)
+	mergedOptions := mergeOptions(i.opts, opts)
 	option := parseOptions(mergedOptions...)
 
 	if !option.isConnectable() {
-		return i.next
+		next := option.buildChannel()
+		go i.run(option.buildContext(), next, option)
+		return next
 	}
 
 	if option.isConnectOperation() {
-		i.connect(option.buildContext())
+		i.connect(option)
 		return nil
 	}
 
@@ -51,16 +44,28 @@ func (i *createIterable) Observe(opts ...Option) <-chan Item {
 	return ch
 }
 
-func (i *createIterable) connect(ctx context.Context) {
+// run runs the producers, a panic being emitted as a PanicError unless the panics are propagated.
+func (i *createIterable) run(ctx context.Context, next chan Item, option Option) {
+	defer close(next)
+	defer recoverPanic(ctx, next, option)
+	for _, f := range i.fs {
+		f(ctx, next)
+	}
+}
+
+func (i *createIterable) connect(option Option) {
 	i.mutex.Lock()
 	if !i.producerAlreadyCreated {
-		go i.produce(ctx)
+		ctx := option.buildContext()
+		next := option.buildChannel()
+		go i.run(ctx, next, option)
+		go i.produce(ctx, next)
 		i.producerAlreadyCreated = true
 	}
 	i.mutex.Unlock()
 }
 
-func (i *createIterable) produce(ctx context.Context) {
+func (i *createIterable) produce(ctx context.Context, next <-chan Item) {
 	defer func() {
 		i.mutex.RLock()
 		for _, subscriber := range i.subscribers {
@@ -73,13 +78,13 @@ func (i *createIterable) produce(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
-		case item, ok := <-i.next:
+		case item, ok := <-next:
 			if !ok {
 				return
 			}
 			i.mutex.RLock()
 			for _, subscriber := range i.subscribers {
-				subscriber <- item
+				item.SendContext(ctx, subscriber)
 			}
 			i.mutex.RUnlock()
 		}
@@ -13,12 +13,13 @@ func newDeferIterable(f []Producer, opts ...Option) Iterable {
 }
 
 func (i *deferIterable) Observe(opts ...Option) <-chan Item {
-	option := parseOptions(append(i.opts, opts...)...)
+	option := parseOptions(mergeOptions(i.opts, opts)...)
 	next := option.buildChannel()
 	ctx := option.buildContext()
 
 	go func() {
 		defer close(next)
+		defer recoverPanic(ctx, next, option)
 		for _, f := range i.fs {
 			f(ctx, next)
 		}
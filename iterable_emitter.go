@@ -0,0 +1,124 @@
+package rxgo
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Emitter is the interface given to the function of CreateWithEmitter to emit items.
+// It enforces the Observable contract: nothing is emitted once OnError or OnDone has been called,
+// or once the observer has been disposed. It is safe for concurrent use.
+type Emitter interface {
+	// OnNext emits an item. It blocks until the item is consumed or the observer is disposed.
+	OnNext(i interface{})
+	// OnError emits an error and terminates the Observable.
+	OnError(err error)
+	// OnDone terminates the Observable.
+	OnDone()
+	// IsDisposed returns whether the Observable was terminated or the observer disposed.
+	IsDisposed() bool
+}
+
+type emitterIterable struct {
+	f    func(emitter Emitter)
+	opts []Option
+}
+
+func newEmitterIterable(f func(emitter Emitter), opts ...Option) Iterable {
+	return &emitterIterable{
+		f:    f,
+		opts: opts,
+	}
+}
+
+func (i *emitterIterable) Observe(opts ...Option) <-chan Item {
+	option := parseOptions(mergeOptions(i.opts, opts)...)
+	e := &emitter{
+		ctx:  option.buildContext(),
+		next: option.buildChannel(),
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		select {
+		case <-e.ctx.Done():
+			e.terminate(nil)
+		case <-e.done:
+		}
+	}()
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				e.OnError(newPanicError(r))
+			}
+		}()
+		i.f(e)
+	}()
+
+	return e.next
+}
+
+type emitter struct {
+	ctx  context.Context
+	next chan Item
+	// mutex orders the registration of the senders with the termination, it is never held while sending
+	mutex sync.Mutex
+	// terminated is set atomically once the emitter is terminated, under mutex
+	terminated uint32
+	// sending counts the OnNext calls sending an item, waited for before next is closed
+	sending sync.WaitGroup
+	done    chan struct{}
+}
+
+func (e *emitter) OnNext(i interface{}) {
+	if !e.startSending() {
+		return
+	}
+	defer e.sending.Done()
+	Of(i).SendContext(e.ctx, e.next)
+}
+
+// startSending registers a sender, unless the emitter is terminated.
+func (e *emitter) startSending() bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if atomic.LoadUint32(&e.terminated) == 1 {
+		return false
+	}
+	e.sending.Add(1)
+	return true
+}
+
+func (e *emitter) OnError(err error) {
+	item := Error(err)
+	e.terminate(&item)
+}
+
+func (e *emitter) OnDone() {
+	e.terminate(nil)
+}
+
+func (e *emitter) IsDisposed() bool {
+	return atomic.LoadUint32(&e.terminated) == 1 || e.ctx.Err() != nil
+}
+
+// terminate closes the observer channel, after having sent the last item if not nil. The items being sent by
+// OnNext are sent before.
+func (e *emitter) terminate(last *Item) {
+	e.mutex.Lock()
+	if atomic.LoadUint32(&e.terminated) == 1 {
+		e.mutex.Unlock()
+		return
+	}
+	atomic.StoreUint32(&e.terminated, 1)
+	e.mutex.Unlock()
+
+	e.sending.Wait()
+	if last != nil {
+		last.SendContext(e.ctx, e.next)
+	}
+	close(e.next)
+	close(e.done)
+}
@@ -7,15 +7,31 @@ import (
 
 type eventSourceIterable struct {
 	sync.RWMutex
-	observers []chan Item
+	ctx       context.Context
+	observers []*eventSourceObserver
 	disposed  bool
 	opts      []Option
+	metrics   Metrics
 }
 
-func newEventSourceIterable(ctx context.Context, next <-chan Item, strategy BackpressureStrategy, opts ...Option) Iterable {
+// eventSourceObserver is an observer of an eventSourceIterable, removed once its context is cancelled.
+type eventSourceObserver struct {
+	buffer *observerBuffer
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (o *eventSourceObserver) close() {
+	o.buffer.close()
+	o.cancel()
+}
+
+func newEventSourceIterable(ctx context.Context, next <-chan Item, strategy BackpressureStrategy, metrics Metrics, opts ...Option) Iterable {
 	it := &eventSourceIterable{
-		observers: make([]chan Item, 0),
-		opts:      opts,
+		ctx:       ctx,
+		observers: make([]*eventSourceObserver, 0),
+		opts:      append(opts, WithBackPressureStrategy(strategy)),
+		metrics:   metrics,
 	}
 
 	go func() {
@@ -29,23 +45,17 @@ func newEventSourceIterable(ctx context.Context, next <-chan Item, strategy Back
 					it.closeAllObservers()
 					return
 				}
-				it.RLock()
-				switch strategy {
-				default:
-					fallthrough
-				case Block:
-					for _, observer := range it.observers {
-						observer <- item
-					}
-				case Drop:
-					for _, observer := range it.observers {
-						select {
-						default:
-						case observer <- item:
-						}
+				it.Lock()
+				observers := it.observers[:0]
+				for _, observer := range it.observers {
+					if observer.buffer.send(item) {
+						observers = append(observers, observer)
+					} else {
+						observer.close()
 					}
 				}
-				it.RUnlock()
+				it.observers = observers
+				it.Unlock()
 			}
 		}
 	}()
@@ -56,22 +66,53 @@ func newEventSourceIterable(ctx context.Context, next <-chan Item, strategy Back
 func (i *eventSourceIterable) closeAllObservers() {
 	i.Lock()
 	for _, observer := range i.observers {
-		close(observer)
+		observer.close()
 	}
+	i.observers = nil
 	i.disposed = true
 	i.Unlock()
 }
 
+func (i *eventSourceIterable) removeObserver(observer *eventSourceObserver) {
+	i.Lock()
+	defer i.Unlock()
+	for idx, o := range i.observers {
+		if o == observer {
+			i.observers = append(i.observers[:idx], i.observers[idx+1:]...)
+			observer.close()
+			return
+		}
+	}
+}
+
 func (i *eventSourceIterable) Observe(opts ...Option) <-chan Item {
-	option := parseOptions(append(i.opts, opts...)...)
-	next := option.buildChannel()
+	option := parseOptions(mergeOptions(i.opts, opts)...)
+	parent := option.buildContext()
+	ctx, cancel := context.WithCancel(parent)
+	observer := &eventSourceObserver{
+		// The pending items are still forwarded once the observer is closed
+		buffer: newObserverBuffer(parent, option, 0, i.metrics),
+		ctx:    ctx,
+		cancel: cancel,
+	}
 
 	i.Lock()
 	if i.disposed {
-		close(next)
-	} else {
-		i.observers = append(i.observers, next)
+		i.Unlock()
+		observer.close()
+		return observer.buffer.ch
 	}
+	i.observers = append(i.observers, observer)
 	i.Unlock()
-	return next
+
+	// The observer is removed as soon as its context is cancelled, so that it no longer blocks the source
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-i.ctx.Done():
+			cancel()
+		}
+		i.removeObserver(observer)
+	}()
+	return observer.buffer.ch
 }
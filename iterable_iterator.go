@@ -0,0 +1,233 @@
+package rxgo
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+)
+
+// Iterator is a pull-based sequence of values.
+type Iterator interface {
+	// Next returns the next value and true, or false once the sequence is exhausted.
+	Next(ctx context.Context) (interface{}, bool)
+}
+
+type iteratorIterable struct {
+	newIterator func() Iterator
+	opts        []Option
+}
+
+func newIteratorIterable(newIterator func() Iterator, opts ...Option) Iterable {
+	return &iteratorIterable{
+		newIterator: newIterator,
+		opts:        opts,
+	}
+}
+
+func (i *iteratorIterable) Observe(opts ...Option) <-chan Item {
+	option := parseOptions(mergeOptions(i.opts, opts)...)
+	next := option.buildChannel()
+	ctx := option.buildContext()
+	it := i.newIterator()
+
+	go func() {
+		defer close(next)
+		defer recoverPanic(ctx, next, option)
+		for {
+			v, ok := it.Next(ctx)
+			if !ok {
+				return
+			}
+			item := Of(v)
+			if err, isError := v.(error); isError {
+				item = Error(err)
+			}
+			if !item.SendContext(ctx, next) {
+				return
+			}
+		}
+	}()
+	return next
+}
+
+type sliceIterator struct {
+	items []interface{}
+	index int
+}
+
+func (it *sliceIterator) Next(ctx context.Context) (interface{}, bool) {
+	if it.index >= len(it.items) || ctx.Err() != nil {
+		return nil, false
+	}
+	v := it.items[it.index]
+	it.index++
+	return v, true
+}
+
+// generateIterator runs the loop of Generate.
+type generateIterator struct {
+	state          interface{}
+	condition      Predicate
+	iterate        func(interface{}) interface{}
+	resultSelector func(interface{}) interface{}
+	started        bool
+	done           bool
+}
+
+func (it *generateIterator) Next(ctx context.Context) (interface{}, bool) {
+	if it.done || ctx.Err() != nil {
+		return nil, false
+	}
+	if it.started {
+		it.state = it.iterate(it.state)
+	}
+	it.started = true
+	if !it.condition(it.state) {
+		it.done = true
+		return nil, false
+	}
+	if it.resultSelector == nil {
+		return it.state, true
+	}
+	return it.resultSelector(it.state), true
+}
+
+// csvIterator emits the records of a CSV reader, see FromCSV.
+type csvIterator struct {
+	mutex       sync.Mutex
+	reader      *csv.Reader
+	header      bool
+	columns     []string
+	skipInvalid bool
+	stopOnError bool
+	done        bool
+}
+
+func (it *csvIterator) Next(ctx context.Context) (interface{}, bool) {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+	for !it.done && ctx.Err() == nil {
+		record, err := it.reader.Read()
+		if err == io.EOF {
+			it.done = true
+			return nil, false
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				it.done = true
+				return err, true
+			}
+			if it.skipInvalid {
+				continue
+			}
+			it.done = it.stopOnError
+			return err, true
+		}
+		if !it.header {
+			return record, true
+		}
+		if it.columns == nil {
+			it.columns = record
+			continue
+		}
+		// The number of fields is checked by the reader: it is the same for each record
+		m := make(map[string]string, len(it.columns))
+		for i, column := range it.columns {
+			m[column] = record[i]
+		}
+		return m, true
+	}
+	return nil, false
+}
+
+// jsonDecoderIterator emits the values decoded from a JSON stream, see FromJSONDecoder.
+type jsonDecoderIterator struct {
+	mutex   sync.Mutex
+	decoder *json.Decoder
+	factory func() interface{}
+	done    bool
+}
+
+func (it *jsonDecoderIterator) Next(ctx context.Context) (interface{}, bool) {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+	if it.done || ctx.Err() != nil {
+		return nil, false
+	}
+	if it.factory == nil {
+		var v interface{}
+		if err := it.decoder.Decode(&v); err != nil {
+			return it.fail(err)
+		}
+		return v, true
+	}
+	v := it.factory()
+	if err := it.decoder.Decode(v); err != nil {
+		return it.fail(err)
+	}
+	return v, true
+}
+
+func (it *jsonDecoderIterator) fail(err error) (interface{}, bool) {
+	it.done = true
+	if err == io.EOF {
+		return nil, false
+	}
+	return err, true
+}
+
+// readerIterator reads chunks from a reader, see FromReader.
+type readerIterator struct {
+	mutex     sync.Mutex
+	reader    io.Reader
+	chunkSize int
+	// err is the error returned by the last read, io.EOF once the sequence is exhausted.
+	err error
+}
+
+func (it *readerIterator) Next(ctx context.Context) (interface{}, bool) {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+	for it.err == nil && ctx.Err() == nil {
+		buf := make([]byte, it.chunkSize)
+		n, err := it.reader.Read(buf)
+		it.err = err
+		if n > 0 {
+			return buf[:n], true
+		}
+	}
+	if it.err != nil && it.err != io.EOF {
+		err := it.err
+		it.err = io.EOF
+		return err, true
+	}
+	return nil, false
+}
+
+// scannerIterator emits the tokens of a scanner, see FromScanner.
+type scannerIterator struct {
+	mutex   sync.Mutex
+	scanner *bufio.Scanner
+	done    bool
+}
+
+func (it *scannerIterator) Next(ctx context.Context) (interface{}, bool) {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+	if it.done || ctx.Err() != nil {
+		return nil, false
+	}
+	if it.scanner.Scan() {
+		return it.scanner.Text(), true
+	}
+	it.done = true
+	if err := it.scanner.Err(); err != nil {
+		return err, true
+	}
+	return nil, false
+}
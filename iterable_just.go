@@ -15,9 +15,16 @@ func newJustIterable(items ...interface{}) func(opts ...Option) Iterable {
 }
 
 func (i *justIterable) Observe(opts ...Option) <-chan Item {
-	option := parseOptions(append(i.opts, opts...)...)
+	option := parseOptions(mergeOptions(i.opts, opts)...)
 	next := option.buildChannel()
+	ctx := option.buildContext()
+	sender := newBatchSender(ctx, next, opts...)
 
-	go SendItems(option.buildContext(), next, CloseChannel, i.items)
+	go func() {
+		defer close(next)
+		if send(ctx, sender.send, i.items...) {
+			sender.flush()
+		}
+	}()
 	return next
 }
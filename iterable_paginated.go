@@ -0,0 +1,118 @@
+package rxgo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// defaultFetchBackOff creates the policy of FromPaginatedFetch unless one is set using WithFetchBackOff.
+func defaultFetchBackOff() backoff.BackOff {
+	return ExponentialBackOff(500*time.Millisecond, 30*time.Second, 2, 0.5, 5)
+}
+
+type paginatedIterable struct {
+	fetch func(cursor string) ([]interface{}, string, error)
+	opts  []Option
+}
+
+func newPaginatedIterable(fetch func(cursor string) ([]interface{}, string, error), opts ...Option) Iterable {
+	return &paginatedIterable{
+		fetch: fetch,
+		opts:  opts,
+	}
+}
+
+func (i *paginatedIterable) Observe(opts ...Option) <-chan Item {
+	option := parseOptions(mergeOptions(i.opts, opts)...)
+	next := option.buildChannel()
+	ctx := option.buildContext()
+	clock := option.getClock()
+	policy := parseFetchBackOff(mergeOptions(i.opts, opts)...)()
+
+	go func() {
+		defer close(next)
+		cursor := ""
+		for {
+			items, nextCursor, err := i.fetchPage(ctx, cursor, policy, clock)
+			if err != nil {
+				if ctx.Err() == nil {
+					Error(err).SendContext(ctx, next)
+				}
+				return
+			}
+			for _, v := range items {
+				item := Of(v)
+				if err, isError := v.(error); isError {
+					item = Error(err)
+				}
+				if !item.SendContext(ctx, next) {
+					return
+				}
+			}
+			if nextCursor == "" {
+				return
+			}
+			cursor = nextCursor
+		}
+	}()
+	return next
+}
+
+// fetchPage fetches the page of a cursor, retrying according to policy. It returns the last error once the
+// policy stops, or the error of ctx if it is cancelled meanwhile.
+func (i *paginatedIterable) fetchPage(ctx context.Context, cursor string, policy backoff.BackOff,
+	clock Clock) ([]interface{}, string, error) {
+	policy.Reset()
+	for {
+		items, next, err := i.fetch(cursor)
+		if err == nil {
+			return items, next, nil
+		}
+		var permanent *backoff.PermanentError
+		if errors.As(err, &permanent) {
+			return nil, "", permanent.Err
+		}
+		delay := policy.NextBackOff()
+		if delay == backoff.Stop {
+			return nil, "", err
+		}
+		// The server may require a longer delay than the policy
+		var retryAfter RetryAfterError
+		if errors.As(err, &retryAfter) && retryAfter.RetryAfter > delay {
+			delay = retryAfter.RetryAfter
+		}
+		timer := clock.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, "", ctx.Err()
+		case <-timer.C():
+		}
+	}
+}
+
+// ParseRetryAfter parses the value of a Retry-After header, either a number of seconds or an HTTP date, into the
+// delay to wait from now. It returns false if the value is invalid.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if delay := date.Sub(now); delay > 0 {
+		return delay, true
+	}
+	return 0, true
+}
@@ -0,0 +1,147 @@
+package rxgo
+
+import (
+	"context"
+	"sync"
+)
+
+// publishIterable shares a single subscription to the source among all its observers.
+type publishIterable struct {
+	source  Observable
+	opts    []Option
+	mutex   sync.Mutex
+	subject *subject
+	// producing is the subject fed by the running connection, if any
+	producing *subject
+}
+
+func newPublishIterable(source Observable, opts ...Option) *publishIterable {
+	return &publishIterable{
+		source:  source,
+		opts:    opts,
+		subject: newSubject(nil, opts...),
+	}
+}
+
+func (i *publishIterable) Observe(opts ...Option) <-chan Item {
+	option := parseOptions(opts...)
+	if option.isConnectOperation() {
+		i.connect(option.buildContext())
+		return nil
+	}
+
+	i.mutex.Lock()
+	s := i.subject
+	i.mutex.Unlock()
+	return s.Observe(opts...)
+}
+
+// renew returns the subject of the next connection, re-created if the previous connection terminated it.
+func (i *publishIterable) renew() *subject {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	if i.subject.isTerminated() {
+		i.subject = newSubject(nil, i.opts...)
+	}
+	return i.subject
+}
+
+func (i *publishIterable) connect(ctx context.Context) {
+	s := i.renew()
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	if i.producing == s {
+		return
+	}
+	i.producing = s
+	go i.produce(ctx, s)
+}
+
+func (i *publishIterable) produce(ctx context.Context, s *subject) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer func() {
+		i.mutex.Lock()
+		if i.producing == s {
+			i.producing = nil
+		}
+		i.mutex.Unlock()
+	}()
+
+	observe := i.source.Observe(append(i.opts, WithContext(ctx))...)
+	for item := range observe {
+		if item.Error() {
+			s.OnError(item.E)
+			return
+		}
+		s.OnNext(item.V)
+	}
+	s.OnCompleted()
+}
+
+// autoConnectIterable connects a publishIterable once a given number of observers have subscribed.
+type autoConnectIterable struct {
+	publish   *publishIterable
+	mutex     sync.Mutex
+	count     int
+	observers int
+}
+
+func (i *autoConnectIterable) Observe(opts ...Option) <-chan Item {
+	observe := i.publish.Observe(opts...)
+
+	i.mutex.Lock()
+	i.count++
+	if i.count == i.observers {
+		i.publish.connect(context.Background())
+	}
+	i.mutex.Unlock()
+	return observe
+}
+
+// refCountIterable connects a publishIterable when the first observer subscribes and disconnects it when the last
+// one unsubscribes.
+type refCountIterable struct {
+	publish    *publishIterable
+	mutex      sync.Mutex
+	count      int
+	disconnect Disposable
+}
+
+func (i *refCountIterable) Observe(opts ...Option) <-chan Item {
+	option := parseOptions(opts...)
+	ctx := option.buildContext()
+	next := option.buildChannel()
+
+	i.mutex.Lock()
+	i.count++
+	var observe <-chan Item
+	if i.count == 1 {
+		// The first observer is registered to the subject of the new connection, not to a terminated one
+		observe = i.publish.renew().Observe(opts...)
+		ctx, cancel := context.WithCancel(context.Background())
+		i.publish.connect(ctx)
+		i.disconnect = Disposable(cancel)
+	} else {
+		observe = i.publish.Observe(opts...)
+	}
+	i.mutex.Unlock()
+
+	go func() {
+		defer close(next)
+		defer i.release()
+		for item := range observe {
+			item.SendContext(ctx, next)
+		}
+	}()
+	return next
+}
+
+func (i *refCountIterable) release() {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.count--
+	if i.count == 0 {
+		i.disconnect()
+	}
+}
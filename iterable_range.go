@@ -14,19 +14,20 @@ func newRangeIterable(start, count int, opts ...Option) Iterable {
 }
 
 func (i *rangeIterable) Observe(opts ...Option) <-chan Item {
-	option := parseOptions(append(i.opts, opts...)...)
+	option := parseOptions(mergeOptions(i.opts, opts)...)
 	ctx := option.buildContext()
 	next := option.buildChannel()
 
+	sender := newBatchSender(ctx, next, opts...)
+
 	go func() {
+		defer close(next)
 		for idx := i.start; idx <= i.start+i.count; idx++ {
-			select {
-			case <-ctx.Done():
+			if !sender.send(Of(idx)) {
 				return
-			case next <- Of(idx):
 			}
 		}
-		close(next)
+		sender.flush()
 	}()
 	return next
 }
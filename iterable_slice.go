@@ -13,14 +13,17 @@ func newSliceIterable(items []Item, opts ...Option) Iterable {
 }
 
 func (i *sliceIterable) Observe(opts ...Option) <-chan Item {
-	option := parseOptions(append(i.opts, opts...)...)
+	option := parseOptions(mergeOptions(i.opts, opts)...)
 	next := option.buildChannel()
+	ctx := option.buildContext()
 
 	go func() {
+		defer close(next)
 		for _, item := range i.items {
-			next <- item
+			if !item.SendContext(ctx, next) {
+				return
+			}
 		}
-		close(next)
 	}()
 	return next
 }
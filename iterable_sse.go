@@ -0,0 +1,206 @@
+package rxgo
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// defaultSSERetry is the reconnection delay of FromSSE until the server sets it using a retry field.
+const defaultSSERetry = 3 * time.Second
+
+// defaultSSEBackOff creates the policy of FromSSE unless one is set using WithSSEBackOff.
+func defaultSSEBackOff() backoff.BackOff {
+	return ExponentialBackOff(time.Second, 30*time.Second, 2, 0.5, 5)
+}
+
+// SSEEvent is an event received from a Server-Sent Events endpoint, see FromSSE.
+type SSEEvent struct {
+	// ID is the last event ID set by the server.
+	ID string
+	// Event is the event type, "message" by default.
+	Event string
+	// Data is the event data, the lines being joined with "\n".
+	Data string
+}
+
+type sseIterable struct {
+	client  *http.Client
+	request *http.Request
+	opts    []Option
+}
+
+// sseState is the state of a Server-Sent Events stream, kept across the reconnections.
+type sseState struct {
+	lastEventID string
+	retry       time.Duration
+}
+
+func newSSEIterable(client *http.Client, request *http.Request, opts ...Option) Iterable {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &sseIterable{
+		client:  client,
+		request: request,
+		opts:    opts,
+	}
+}
+
+func (i *sseIterable) Observe(opts ...Option) <-chan Item {
+	option := parseOptions(mergeOptions(i.opts, opts)...)
+	next := option.buildChannel()
+	ctx := option.buildContext()
+	clock := option.getClock()
+	policy := parseSSEBackOff(mergeOptions(i.opts, opts)...)()
+
+	go func() {
+		defer close(next)
+		state := &sseState{retry: defaultSSERetry}
+		for {
+			reconnect, err := i.connect(ctx, state, policy, next)
+			if !reconnect {
+				return
+			}
+			delay := state.retry
+			if err != nil {
+				if delay = policy.NextBackOff(); delay == backoff.Stop {
+					Error(err).SendContext(ctx, next)
+					return
+				}
+			}
+			timer := clock.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C():
+			}
+		}
+	}()
+	return next
+}
+
+// connect emits the events of a connection. It returns true if the connection has to be retried, along with the
+// error if it failed, or if the stream ended.
+func (i *sseIterable) connect(ctx context.Context, state *sseState, policy backoff.BackOff,
+	next chan<- Item) (bool, error) {
+	request := i.request.Clone(ctx)
+	request.Header.Set("Accept", "text/event-stream")
+	request.Header.Set("Cache-Control", "no-cache")
+	if state.lastEventID != "" {
+		request.Header.Set("Last-Event-ID", state.lastEventID)
+	}
+
+	response, err := i.client.Do(request)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNoContent:
+		// The server asks the client to stop reconnecting
+		return false, nil
+	default:
+		return true, HTTPStatusError{StatusCode: response.StatusCode, Status: response.Status}
+	}
+
+	policy.Reset()
+	if !readSSEEvents(ctx, response.Body, state, next) {
+		return false, nil
+	}
+	return ctx.Err() == nil, nil
+}
+
+// readSSEEvents emits the events read from r until r ends. It returns false if an event could not be sent.
+func readSSEEvents(ctx context.Context, r io.Reader, state *sseState, next chan<- Item) bool {
+	reader := bufio.NewReader(r)
+	var event string
+	var data strings.Builder
+	hasData := false
+	afterCR := false
+
+	for {
+		line, err := readSSELine(reader, &afterCR)
+		if err != nil {
+			// An incomplete event is discarded
+			return true
+		}
+
+		if line == "" {
+			if hasData {
+				if event == "" {
+					event = "message"
+				}
+				if !Of(SSEEvent{ID: state.lastEventID, Event: event, Data: data.String()}).SendContext(ctx, next) {
+					return false
+				}
+			}
+			event = ""
+			data.Reset()
+			hasData = false
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			// Comment
+			continue
+		}
+
+		field, value := line, ""
+		if idx := strings.IndexByte(line, ':'); idx >= 0 {
+			field, value = line[:idx], strings.TrimPrefix(line[idx+1:], " ")
+		}
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			hasData = true
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				state.lastEventID = value
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(value, 10, 63); err == nil {
+				state.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
+
+// readSSELine reads a line ended by "\r\n", "\n" or "\r". afterCR tells whether the previous line ended with a
+// "\r", a "\n" following it being skipped: the line is returned without waiting for the next byte.
+func readSSELine(reader *bufio.Reader, afterCR *bool) (string, error) {
+	var line []byte
+	for {
+		c, err := reader.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		if *afterCR {
+			*afterCR = false
+			if c == '\n' {
+				continue
+			}
+		}
+		switch c {
+		case '\r':
+			*afterCR = true
+			return string(line), nil
+		case '\n':
+			return string(line), nil
+		}
+		line = append(line, c)
+	}
+}
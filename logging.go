@@ -0,0 +1,79 @@
+package rxgo
+
+import "sync/atomic"
+
+// Logger logs structured messages, the arguments being key-value pairs, e.g. a *slog.Logger.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+}
+
+// subscriptionID is the ID of the last subscription logged.
+var subscriptionID uint64
+
+// instrument records the activity of the Observables created with WithMetrics or WithLogger. operatorFactory
+// is nil if the Observable is not created from an operator.
+func instrument(iterable Iterable, option Option, operatorFactory func() operator) Iterable {
+	iterable = meter(iterable, option)
+	logger, sampling := option.getLogger()
+	if logger == nil {
+		return iterable
+	}
+	var attrs []interface{}
+	if operatorFactory != nil {
+		attrs = []interface{}{"operator", operatorName(operatorFactory())}
+	}
+	return &loggedIterable{iterable: iterable, logger: logger, sampling: sampling, attrs: attrs}
+}
+
+// loggedIterable logs the lifecycle of the subscriptions to an iterable.
+type loggedIterable struct {
+	iterable Iterable
+	logger   Logger
+	sampling int
+	attrs    []interface{}
+}
+
+func (i *loggedIterable) Observe(opts ...Option) <-chan Item {
+	option := parseOptions(opts...)
+	next := option.buildChannel()
+	ctx := option.buildContext()
+	attrs := append([]interface{}{"subscription", atomic.AddUint64(&subscriptionID, 1)}, i.attrs...)
+	with := func(args ...interface{}) []interface{} {
+		return append(args, attrs...)
+	}
+
+	i.logger.Info("subscribe", attrs...)
+	observe := i.iterable.Observe(opts...)
+	go func() {
+		defer close(next)
+		emitted := 0
+		failed := false
+		for item := range observe {
+			failed = item.Error()
+			if failed {
+				i.logger.Info("error", with("error", item.E)...)
+			} else {
+				if emitted%i.sampling == 0 {
+					value := item.V
+					if v, ok := value.(contextValue); ok {
+						value = v.v
+					}
+					i.logger.Debug("next", with("index", emitted, "value", value)...)
+				}
+				emitted++
+			}
+			if !item.SendContext(ctx, next) {
+				i.logger.Info("dispose", with("emitted", emitted)...)
+				return
+			}
+		}
+		switch {
+		case ctx.Err() != nil:
+			i.logger.Info("dispose", with("emitted", emitted)...)
+		case !failed:
+			i.logger.Info("complete", with("emitted", emitted)...)
+		}
+	}()
+	return next
+}
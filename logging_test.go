@@ -0,0 +1,93 @@
+package rxgo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingLogger records the messages logged, formatted with their arguments.
+type recordingLogger struct {
+	mutex    sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) log(level, msg string, args ...interface{}) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.messages = append(l.messages, strings.TrimSpace(fmt.Sprintln(append([]interface{}{level, msg}, args...)...)))
+}
+
+func (l *recordingLogger) Debug(msg string, args ...interface{}) {
+	l.log("debug", msg, args...)
+}
+
+func (l *recordingLogger) Info(msg string, args ...interface{}) {
+	l.log("info", msg, args...)
+}
+
+func (l *recordingLogger) logged() []string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.messages
+}
+
+func Test_WithLogger(t *testing.T) {
+	logger := &recordingLogger{}
+	obs := testObservable(1, 2, 3).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	}, WithLogger(logger, 2))
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3))
+
+	messages := logger.logged()
+	assert.Len(t, messages, 4)
+	assert.Regexp(t, `^info subscribe subscription \d+ operator map$`, messages[0])
+	assert.Regexp(t, `^debug next index 0 value 1 subscription \d+ operator map$`, messages[1])
+	assert.Regexp(t, `^debug next index 2 value 3 subscription \d+ operator map$`, messages[2])
+	assert.Regexp(t, `^info complete emitted 3 subscription \d+ operator map$`, messages[3])
+}
+
+func Test_WithLogger_Error(t *testing.T) {
+	logger := &recordingLogger{}
+	obs := testObservable(1, errFoo).Filter(func(interface{}) bool {
+		return false
+	}, WithLogger(logger, 0))
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+
+	messages := logger.logged()
+	assert.Len(t, messages, 2)
+	assert.Regexp(t, `^info error error foo subscription \d+ operator filter$`, messages[1])
+}
+
+func Test_WithLogger_Dispose(t *testing.T) {
+	logger := &recordingLogger{}
+	ctx, cancel := context.WithCancel(context.Background())
+	observe := Interval(WithDuration(time.Millisecond)).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	}, WithLogger(logger, 1)).Observe(WithContext(ctx))
+	<-observe
+	cancel()
+	for range observe {
+	}
+
+	messages := logger.logged()
+	assert.Regexp(t, `^info dispose emitted \d+ subscription \d+ operator map$`, messages[len(messages)-1])
+}
+
+func Test_WithLogger_SubscriptionID(t *testing.T) {
+	logger := &recordingLogger{}
+	obs := Just(1)().Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	}, WithLogger(logger, 1))
+	Assert(context.Background(), t, obs, HasItems(1))
+	Assert(context.Background(), t, obs, HasItems(1))
+
+	messages := logger.logged()
+	assert.Len(t, messages, 6)
+	assert.NotEqual(t, messages[0], messages[3])
+}
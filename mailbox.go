@@ -0,0 +1,99 @@
+package rxgo
+
+import (
+	"context"
+	"sync"
+)
+
+// mailbox holds the items handed off by ObserveOn to an observer, see WithMailbox. A single task drains it at a
+// time, so that the order of the items is kept whatever the scheduler.
+type mailbox struct {
+	mutex    sync.Mutex
+	ring     *ringBuffer
+	capacity int
+	strategy BackpressureStrategy
+	// scheduled is set while a task draining the mailbox is scheduled or running
+	scheduled bool
+	closed    bool
+	// space is signaled once an item is taken, for the Block strategy
+	space chan struct{}
+	// drained is closed once the mailbox is closed and empty
+	drained chan struct{}
+}
+
+func newMailbox(capacity int, strategy BackpressureStrategy) *mailbox {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &mailbox{
+		ring:     newRingBuffer(capacity),
+		capacity: capacity,
+		strategy: strategy,
+		space:    make(chan struct{}, 1),
+		drained:  make(chan struct{}),
+	}
+}
+
+// push adds an item according to the overflow strategy. It returns whether a task draining the mailbox has to be
+// scheduled, and false as second value if the observer has to be terminated: either ctx is done, or the mailbox is
+// full with the Fail strategy, a BackpressureError being then added.
+func (m *mailbox) push(ctx context.Context, item Item) (bool, bool) {
+	for {
+		m.mutex.Lock()
+		pushed := m.ring.push(item)
+		terminated := false
+		if !pushed {
+			switch m.strategy {
+			case Block:
+				m.mutex.Unlock()
+				select {
+				case <-ctx.Done():
+					return false, false
+				case <-m.space:
+				}
+				continue
+			case Latest:
+				m.ring.pop()
+				m.ring.push(item)
+			case Fail:
+				m.ring.pop()
+				m.ring.push(Error(BackpressureError{error: "observer not ready"}))
+				terminated = true
+			}
+		}
+		schedule := !m.scheduled
+		m.scheduled = true
+		m.mutex.Unlock()
+		return schedule, !terminated
+	}
+}
+
+// take removes the oldest item. Once empty, it returns false and the mailbox has to be scheduled again.
+func (m *mailbox) take() (Item, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	item, _, ok := m.ring.peek()
+	if !ok {
+		m.scheduled = false
+		if m.closed {
+			close(m.drained)
+		}
+		return Item{}, false
+	}
+	m.ring.pop()
+	select {
+	case m.space <- struct{}{}:
+	default:
+	}
+	return item, true
+}
+
+// close marks the end of the items, drained being closed once they are taken.
+func (m *mailbox) close() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.closed = true
+	if !m.scheduled {
+		close(m.drained)
+	}
+}
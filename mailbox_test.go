@@ -0,0 +1,89 @@
+package rxgo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func takeAll(m *mailbox) []interface{} {
+	var items []interface{}
+	for {
+		item, ok := m.take()
+		if !ok {
+			return items
+		}
+		if item.Error() {
+			items = append(items, item.E)
+		} else {
+			items = append(items, item.V)
+		}
+	}
+}
+
+func Test_Mailbox_Latest(t *testing.T) {
+	m := newMailbox(2, Latest)
+	for i := 0; i < 5; i++ {
+		schedule, accepted := m.push(context.Background(), Of(i))
+		assert.Equal(t, i == 0, schedule)
+		assert.True(t, accepted)
+	}
+	assert.Equal(t, []interface{}{3, 4}, takeAll(m))
+}
+
+func Test_Mailbox_Drop(t *testing.T) {
+	m := newMailbox(2, Drop)
+	for i := 0; i < 5; i++ {
+		_, accepted := m.push(context.Background(), Of(i))
+		assert.True(t, accepted)
+	}
+	assert.Equal(t, []interface{}{0, 1}, takeAll(m))
+}
+
+func Test_Mailbox_Fail(t *testing.T) {
+	m := newMailbox(2, Fail)
+	m.push(context.Background(), Of(0))
+	m.push(context.Background(), Of(1))
+	_, accepted := m.push(context.Background(), Of(2))
+	assert.False(t, accepted)
+	assert.Equal(t, []interface{}{1, BackpressureError{error: "observer not ready"}}, takeAll(m))
+}
+
+func Test_Mailbox_Block(t *testing.T) {
+	m := newMailbox(1, Block)
+	m.push(context.Background(), Of(0))
+	pushed := make(chan bool)
+	go func() {
+		_, accepted := m.push(context.Background(), Of(1))
+		pushed <- accepted
+	}()
+	item, _ := m.take()
+	assert.Equal(t, 0, item.V)
+	// The pending push completes once the item is taken
+	assert.True(t, <-pushed)
+	assert.Equal(t, []interface{}{1}, takeAll(m))
+
+	m.push(context.Background(), Of(2))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, accepted := m.push(ctx, Of(3))
+	assert.False(t, accepted)
+}
+
+func Test_Mailbox_Close(t *testing.T) {
+	m := newMailbox(1, Block)
+	m.push(context.Background(), Of(0))
+	m.close()
+	select {
+	case <-m.drained:
+		assert.FailNow(t, "mailbox not drained yet")
+	default:
+	}
+	assert.Equal(t, []interface{}{0}, takeAll(m))
+	<-m.drained
+
+	m = newMailbox(1, Block)
+	m.close()
+	<-m.drained
+}
@@ -0,0 +1,349 @@
+// Package marble provides a marble diagram DSL to test Observables on the virtual time of a rxgo.TestScheduler.
+//
+// A diagram is a string where each character is a frame:
+//
+//	'-'      a frame without any event
+//	'a'...   a value (looked up in the Values, the character itself by default)
+//	'(ab)'   several events emitted in the same frame; the group takes a single frame
+//	'|'      the completion
+//	'#'      the error (the "#" entry of the Values, DefaultError by default)
+//	'^'      the subscription point of a hot Observable (frame zero)
+//
+// Spaces are ignored. The time-based operators must be given the clock of the test, using
+// rxgo.WithClock(m.Scheduler()), and durations expressed in frames, using m.Frames(n).
+package marble
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/reactivex/rxgo/v2"
+)
+
+// DefaultError is the error emitted on a '#' when the Values have no "#" entry.
+var DefaultError = errors.New("error")
+
+// frameDuration is the virtual duration of a frame.
+const frameDuration = time.Millisecond
+
+// settleTimeout bounds the real time Flush waits for a frame to settle, a missing event failing the test instead
+// of hanging it.
+const settleTimeout = time.Second
+
+// quietPeriod is the real time a frame has to stay settled, letting the operators take the items in flight, e.g.
+// Delay reading the time of an item, before the time moves forward.
+const quietPeriod = time.Millisecond
+
+// T is the subset of testing.TB used to report the failures, e.g. a *testing.T.
+type T interface {
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+}
+
+// Values maps the characters of a diagram to the values emitted. The "#" key maps to the error emitted.
+type Values map[string]interface{}
+
+// Event is an event of an Observable, at a given frame.
+type Event struct {
+	Frame     int
+	Value     interface{}
+	Err       error
+	Completed bool
+}
+
+func (e Event) String() string {
+	switch {
+	case e.Completed:
+		return fmt.Sprintf("%d: |", e.Frame)
+	case e.Err != nil:
+		return fmt.Sprintf("%d: #%v", e.Frame, e.Err)
+	default:
+		return fmt.Sprintf("%d: %v", e.Frame, e.Value)
+	}
+}
+
+// Test runs marble tests on the virtual time of its own TestScheduler.
+type Test struct {
+	t            T
+	scheduler    *rxgo.TestScheduler
+	start        time.Time
+	started      chan struct{}
+	frames       int
+	expectations []*Expectation
+	mutex        sync.Mutex
+	// cold counts the cold Observables, subscribed the ones subscribed at least once
+	cold       int
+	subscribed int
+	// busy counts the sources emitting, i.e. not waiting for the frame of their next event
+	busy int
+}
+
+// New creates a marble Test.
+func New(t T) *Test {
+	start := time.Time{}
+	return &Test{
+		t:         t,
+		scheduler: rxgo.NewTestScheduler(start),
+		start:     start,
+		started:   make(chan struct{}),
+	}
+}
+
+// Scheduler returns the TestScheduler of the test, to be passed to the time-based operators using rxgo.WithClock.
+func (m *Test) Scheduler() *rxgo.TestScheduler {
+	return m.scheduler
+}
+
+// Frames returns the virtual duration of n frames.
+func (m *Test) Frames(n int) time.Duration {
+	return time.Duration(n) * frameDuration
+}
+
+// Hot creates a hot Observable emitting the events of the diagram, whether it is observed or not.
+// Frame zero is the '^' character if any, the first character otherwise; the events before it are not emitted.
+func (m *Test) Hot(diagram string, values Values) rxgo.Observable {
+	events := m.parse(diagram, values, true)
+	ch := make(chan rxgo.Item)
+
+	m.update(func() { m.busy++ })
+	go func() {
+		<-m.started
+		m.emit(context.Background(), m.start, events, ch)
+		close(ch)
+	}()
+	return rxgo.FromEventSource(ch)
+}
+
+// Cold creates a cold Observable emitting the events of the diagram; frame zero is the time of each subscription.
+func (m *Test) Cold(diagram string, values Values) rxgo.Observable {
+	events := m.parse(diagram, values, false)
+	m.update(func() { m.cold++ })
+	var once sync.Once
+	return rxgo.Defer([]rxgo.Producer{func(ctx context.Context, next chan<- rxgo.Item) {
+		m.update(func() {
+			m.busy++
+			once.Do(func() { m.subscribed++ })
+		})
+		m.emit(ctx, m.scheduler.Now(), events, next)
+	}})
+}
+
+// emit sends the events on their frame, the caller having counted the source as busy.
+func (m *Test) emit(ctx context.Context, origin time.Time, events []Event, next chan<- rxgo.Item) {
+	defer m.update(func() { m.busy-- })
+	for _, e := range events {
+		if e.Frame < 0 {
+			continue
+		}
+		due := origin.Add(m.Frames(e.Frame))
+		timer := m.scheduler.NewTimer(due.Sub(m.scheduler.Now()))
+		m.update(func() { m.busy-- })
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			m.update(func() { m.busy++ })
+			return
+		case <-timer.C():
+		}
+		m.update(func() { m.busy++ })
+		switch {
+		case e.Completed:
+			return
+		case e.Err != nil:
+			rxgo.Error(e.Err).SendContext(ctx, next)
+			return
+		default:
+			if !rxgo.Of(e.Value).SendContext(ctx, next) {
+				return
+			}
+		}
+	}
+}
+
+// Expectation records the events of an Observable until the test is flushed.
+type Expectation struct {
+	m        *Test
+	mutex    sync.Mutex
+	actual   []Event
+	expected []Event
+}
+
+// ExpectObservable subscribes to an Observable at frame zero and records its events.
+func (m *Test) ExpectObservable(obs rxgo.Observable) *Expectation {
+	e := &Expectation{m: m}
+	m.expectations = append(m.expectations, e)
+	observe := obs.Observe()
+
+	go func() {
+		failed := false
+		for item := range observe {
+			event := Event{Frame: m.frame()}
+			if item.Error() {
+				event.Err = item.E
+			} else {
+				event.Value = item.V
+			}
+			failed = item.Error()
+			e.record(event)
+		}
+		if !failed {
+			e.record(Event{Frame: m.frame(), Completed: true})
+		}
+	}()
+	return e
+}
+
+// ToBe sets the events expected, checked once the test is flushed.
+func (e *Expectation) ToBe(diagram string, values Values) {
+	e.expected = e.m.parse(diagram, values, false)
+}
+
+func (e *Expectation) record(event Event) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.actual = append(e.actual, event)
+}
+
+// recorded returns whether the events expected until frame were recorded.
+func (e *Expectation) recorded(frame int) bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	n := 0
+	for _, event := range e.expected {
+		if event.Frame <= frame {
+			n++
+		}
+	}
+	return len(e.actual) >= n
+}
+
+// Flush runs the virtual time until the end of the longest diagram, then checks the expectations.
+//
+// The time moves forward once the frame settled: the sources wait for the frame of their next event and the
+// events expected until the frame were recorded, for a millisecond of real time. A frame not settling within a
+// second of real time, e.g. on a missing event, fails the test once the diagrams ran.
+func (m *Test) Flush() {
+	close(m.started)
+	// The cold Observables are subscribed at frame zero
+	m.settle(func() bool { return m.subscribed == m.cold }, -1)
+	for i := 0; i <= m.frames; i++ {
+		m.settle(func() bool { return true }, i)
+		m.scheduler.AdvanceTimeBy(frameDuration)
+	}
+
+	for _, e := range m.expectations {
+		e.mutex.Lock()
+		if !reflect.DeepEqual(e.expected, e.actual) {
+			m.t.Errorf("expected events %v, actual %v", e.expected, e.actual)
+		}
+		e.mutex.Unlock()
+	}
+}
+
+// settle waits until ready holds, the sources are waiting and the events expected until frame were recorded, for
+// quietPeriod, or until settleTimeout.
+func (m *Test) settle(ready func() bool, frame int) {
+	deadline := time.Now().Add(settleTimeout)
+	var since time.Time
+	for now := time.Now(); now.Before(deadline); now = time.Now() {
+		m.mutex.Lock()
+		settled := ready() && m.busy == 0
+		m.mutex.Unlock()
+		for _, e := range m.expectations {
+			settled = settled && e.recorded(frame)
+		}
+		switch {
+		case !settled:
+			since = time.Time{}
+		case since.IsZero():
+			since = now
+		case now.Sub(since) >= quietPeriod:
+			return
+		}
+		time.Sleep(10 * time.Microsecond)
+	}
+}
+
+func (m *Test) update(f func()) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	f()
+}
+
+func (m *Test) frame() int {
+	return int(m.scheduler.Now().Sub(m.start) / frameDuration)
+}
+
+func (m *Test) parse(diagram string, values Values, hot bool) []Event {
+	events, frames, err := parse(diagram, values, hot)
+	if err != nil {
+		m.t.Fatalf("invalid diagram %q: %v", diagram, err)
+	}
+	if frames > m.frames {
+		m.frames = frames
+	}
+	return events
+}
+
+func parse(diagram string, values Values, hot bool) ([]Event, int, error) {
+	events := make([]Event, 0)
+	frame := 0
+	origin := 0
+	inGroup := false
+
+	value := func(marble string) interface{} {
+		if v, ok := values[marble]; ok {
+			return v
+		}
+		return marble
+	}
+
+	for _, c := range diagram {
+		switch c {
+		case ' ':
+			continue
+		case '-':
+		case '(':
+			if inGroup {
+				return nil, 0, errors.New("nested group")
+			}
+			inGroup = true
+			continue
+		case ')':
+			if !inGroup {
+				return nil, 0, errors.New("unopened group")
+			}
+			inGroup = false
+		case '^':
+			if !hot {
+				return nil, 0, errors.New("subscription point in a cold diagram")
+			}
+			origin = frame
+		case '|':
+			events = append(events, Event{Frame: frame, Completed: true})
+		case '#':
+			err, ok := values["#"].(error)
+			if !ok {
+				err = DefaultError
+			}
+			events = append(events, Event{Frame: frame, Err: err})
+		default:
+			events = append(events, Event{Frame: frame, Value: value(string(c))})
+		}
+		if !inGroup {
+			frame++
+		}
+	}
+	if inGroup {
+		return nil, 0, errors.New("unclosed group")
+	}
+
+	for i := range events {
+		events[i].Frame -= origin
+	}
+	return events, frame - origin, nil
+}
@@ -0,0 +1,72 @@
+package marble
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/reactivex/rxgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var errFoo = errors.New("foo")
+
+func double(_ context.Context, i interface{}) (interface{}, error) {
+	return i.(int) * 2, nil
+}
+
+func Test_Hot_Map(t *testing.T) {
+	m := New(t)
+	source := m.Hot("--a--b--|", Values{"a": 1, "b": 2})
+	m.ExpectObservable(source.Map(double)).ToBe("--x--y--|", Values{"x": 2, "y": 4})
+	m.Flush()
+}
+
+func Test_Hot_SubscriptionPoint(t *testing.T) {
+	m := New(t)
+	source := m.Hot("-a-^-b-|", nil)
+	m.ExpectObservable(source).ToBe("--b-|", nil)
+	m.Flush()
+}
+
+func Test_Cold_Error(t *testing.T) {
+	m := New(t)
+	source := m.Cold("-a-#", Values{"#": errFoo})
+	m.ExpectObservable(source).ToBe("-a-#", Values{"#": errFoo})
+	m.Flush()
+}
+
+func Test_Cold_Group(t *testing.T) {
+	m := New(t)
+	source := m.Cold("-(ab)-|", nil)
+	m.ExpectObservable(source).ToBe("-(ab)-|", nil)
+	m.Flush()
+}
+
+func Test_Cold_Delay(t *testing.T) {
+	m := New(t)
+	source := m.Cold("a-b|", nil)
+	delayed := source.Delay(rxgo.WithDuration(m.Frames(2)), rxgo.WithClock(m.Scheduler()))
+	m.ExpectObservable(delayed).ToBe("--a-(b|)", nil)
+	m.Flush()
+}
+
+func Test_Parse(t *testing.T) {
+	events, frames, err := parse(" -a-(b|)", Values{"a": 1}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, frames)
+	assert.Equal(t, []Event{
+		{Frame: 1, Value: 1},
+		{Frame: 3, Value: "b"},
+		{Frame: 3, Completed: true},
+	}, events)
+}
+
+func Test_Parse_Invalid(t *testing.T) {
+	for _, diagram := range []string{"-(a", "a)", "((a))"} {
+		_, _, err := parse(diagram, nil, false)
+		assert.Error(t, err, diagram)
+	}
+	_, _, err := parse("-^-a", nil, false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package rxgo
+
+import (
+	"context"
+	"time"
+)
+
+// Metrics records the activity of an Observable, see WithMetrics. Its methods may be called concurrently.
+type Metrics interface {
+	// ItemEmitted is called once an item is sent to an observer.
+	ItemEmitted()
+	// ErrorEmitted is called once an error is sent to an observer.
+	ErrorEmitted()
+	// Completed is called once the Observable completes without error.
+	Completed()
+	// ItemDropped is called when an item is dropped by the backpressure strategy.
+	ItemDropped()
+	// ItemProcessed is called once an operator has processed an item, with the time it took.
+	ItemProcessed(d time.Duration)
+}
+
+// meteredIterable records the items emitted by an iterable.
+type meteredIterable struct {
+	iterable Iterable
+	metrics  Metrics
+}
+
+// meter records the items emitted by the operators created with WithMetrics. The metrics are not propagated to
+// the parent Observables: they are only read from the options passed at creation.
+func meter(iterable Iterable, option Option) Iterable {
+	metrics := option.getMetrics()
+	if metrics == nil {
+		return iterable
+	}
+	return &meteredIterable{iterable: iterable, metrics: metrics}
+}
+
+func (i *meteredIterable) Observe(opts ...Option) <-chan Item {
+	option := parseOptions(opts...)
+	next := option.buildChannel()
+	ctx := option.buildContext()
+	observe := i.iterable.Observe(opts...)
+
+	go func() {
+		defer close(next)
+		failed := false
+		for item := range observe {
+			if !item.SendContext(ctx, next) {
+				return
+			}
+			failed = item.Error()
+			if failed {
+				i.metrics.ErrorEmitted()
+			} else {
+				i.metrics.ItemEmitted()
+			}
+		}
+		if !failed && ctx.Err() == nil {
+			i.metrics.Completed()
+		}
+	}()
+	return next
+}
+
+// meteredOperator records the processing latency of an operator.
+type meteredOperator struct {
+	operator
+	metrics Metrics
+	clock   Clock
+}
+
+func meterOperator(operatorFactory func() operator, option Option) func() operator {
+	metrics := option.getMetrics()
+	if metrics == nil {
+		return operatorFactory
+	}
+	clock := option.getClock()
+	return func() operator {
+		return &meteredOperator{
+			operator: operatorFactory(),
+			metrics:  metrics,
+			clock:    clock,
+		}
+	}
+}
+
+func (op *meteredOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	start := op.clock.Now()
+	defer func() {
+		op.metrics.ItemProcessed(op.clock.Now().Sub(start))
+	}()
+	op.operator.next(ctx, item, dst, operatorOptions)
+}
@@ -0,0 +1,136 @@
+package rxgo
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingMetrics struct {
+	mutex     sync.Mutex
+	emitted   int
+	errors    int
+	completed int
+	dropped   int
+	processed []time.Duration
+}
+
+func (m *recordingMetrics) ItemEmitted() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.emitted++
+}
+
+func (m *recordingMetrics) ErrorEmitted() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.errors++
+}
+
+func (m *recordingMetrics) Completed() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.completed++
+}
+
+func (m *recordingMetrics) ItemDropped() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.dropped++
+}
+
+func (m *recordingMetrics) ItemProcessed(d time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.processed = append(m.processed, d)
+}
+
+func (m *recordingMetrics) counts() (emitted, errors, completed, dropped int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.emitted, m.errors, m.completed, m.dropped
+}
+
+func Test_WithMetrics(t *testing.T) {
+	metrics := &recordingMetrics{}
+	obs := testObservable(1, 2, 3).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i.(int) * 10, nil
+	}, WithMetrics(metrics))
+	Assert(context.Background(), t, obs, HasItems(10, 20, 30), HasNoError())
+
+	emitted, errors, completed, dropped := metrics.counts()
+	assert.Equal(t, 3, emitted)
+	assert.Equal(t, 0, errors)
+	assert.Equal(t, 1, completed)
+	assert.Equal(t, 0, dropped)
+	assert.Len(t, metrics.processed, 3)
+}
+
+func Test_WithMetrics_Error(t *testing.T) {
+	metrics := &recordingMetrics{}
+	obs := testObservable(1, errFoo, 3).Filter(func(interface{}) bool {
+		return true
+	}, WithMetrics(metrics))
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errFoo))
+
+	emitted, errors, completed, _ := metrics.counts()
+	assert.Equal(t, 1, emitted)
+	assert.Equal(t, 1, errors)
+	assert.Equal(t, 0, completed)
+}
+
+func Test_WithMetrics_ProcessingLatency(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	metrics := &recordingMetrics{}
+	obs := testObservable(1, 2).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		s.AdvanceTimeBy(time.Duration(i.(int)) * time.Second)
+		return i, nil
+	}, WithMetrics(metrics), WithClock(s))
+	Assert(context.Background(), t, obs, HasItems(1, 2))
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, metrics.processed)
+}
+
+func Test_WithMetrics_NotPropagated(t *testing.T) {
+	mapMetrics := &recordingMetrics{}
+	filterMetrics := &recordingMetrics{}
+	obs := testObservable(1, 2, 3, 4).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		}, WithMetrics(mapMetrics)).
+		Filter(func(i interface{}) bool {
+			return i.(int)%2 == 0
+		}, WithMetrics(filterMetrics))
+	Assert(context.Background(), t, obs, HasItems(2, 4))
+
+	emitted, _, _, _ := mapMetrics.counts()
+	assert.Equal(t, 4, emitted)
+	emitted, _, _, _ = filterMetrics.counts()
+	assert.Equal(t, 2, emitted)
+}
+
+func Test_WithMetrics_StreamErrors(t *testing.T) {
+	obs := testObservable(1).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return nil, errFoo
+	}, WithMetrics(&recordingMetrics{}), WithStreamErrors())
+	_, err := obs.BlockingFirst(context.Background())
+	assert.Equal(t, "map", err.(StreamError).Operator)
+}
+
+func Test_WithMetrics_Dropped(t *testing.T) {
+	metrics := &recordingMetrics{}
+	s := PublishSubject(WithBackPressureStrategy(Drop), WithMetrics(metrics))
+	observe := s.Observe()
+	// The observer channel holds a single item
+	s.OnNext(1)
+	s.OnNext(2)
+	s.OnNext(3)
+	s.OnCompleted()
+	assert.Equal(t, 1, (<-observe).V)
+
+	emitted, _, _, dropped := metrics.counts()
+	assert.Equal(t, 1, emitted)
+	assert.Equal(t, 2, dropped)
+}
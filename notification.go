@@ -0,0 +1,20 @@
+package rxgo
+
+// NotificationKind is the kind of event a Notification represents.
+type NotificationKind uint32
+
+const (
+	// NextNotification is the notification of an item.
+	NextNotification NotificationKind = iota
+	// ErrorNotification is the notification of an error.
+	ErrorNotification
+	// CompletedNotification is the notification of the completion.
+	CompletedNotification
+)
+
+// Notification represents an event of an Observable as a value, see Materialize.
+type Notification struct {
+	Kind  NotificationKind
+	Value interface{}
+	Error error
+}
@@ -2,6 +2,10 @@ package rxgo
 
 import (
 	"context"
+	"io"
+	"reflect"
+	"runtime/debug"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -22,74 +26,180 @@ type Observable interface {
 	AverageInt32(opts ...Option) Single
 	AverageInt64(opts ...Option) Single
 	BackOffRetry(backOffCfg backoff.BackOff, opts ...Option) Observable
+	BlockingFirst(ctx context.Context, opts ...Option) (interface{}, error)
+	BlockingForEach(ctx context.Context, f func(interface{}) error, opts ...Option) error
+	BlockingLast(ctx context.Context, opts ...Option) (interface{}, error)
+	BlockingSubscribe(ctx context.Context, observer Observer, opts ...Option) error
 	BufferWithCount(count int, opts ...Option) Observable
+	BufferWithCountAndSkip(count, skip int, opts ...Option) Observable
 	BufferWithTime(timespan Duration, opts ...Option) Observable
 	BufferWithTimeOrCount(timespan Duration, count int, opts ...Option) Observable
+	ConcatWith(other Observable, opts ...Option) Observable
 	Connect() Disposable
 	Contains(equal Predicate, opts ...Option) Single
 	Count(opts ...Option) Single
 	Debounce(timespan Duration, opts ...Option) Observable
 	DefaultIfEmpty(defaultValue interface{}, opts ...Option) Observable
+	Delay(timespan Duration, opts ...Option) Observable
+	DelaySubscription(timespan Duration, opts ...Option) Observable
+	Dematerialize(opts ...Option) Observable
 	Distinct(apply Func, opts ...Option) Observable
 	DistinctUntilChanged(apply Func, opts ...Option) Observable
+	DistinctUntilChangedWithComparator(comparator Comparator, opts ...Option) Observable
+	DistinctWithCapacity(apply Func, capacity int, opts ...Option) Observable
+	DoFinally(finallyFunc func(), opts ...Option) Observable
 	DoOnCompleted(completedFunc CompletedFunc, opts ...Option) Disposed
+	DoOnDispose(disposeFunc func(), opts ...Option) Observable
 	DoOnError(errFunc ErrFunc, opts ...Option) Disposed
 	DoOnNext(nextFunc NextFunc, opts ...Option) Disposed
+	DoOnSubscribe(subscribeFunc func(), opts ...Option) Observable
 	ElementAt(index uint, opts ...Option) Single
 	Error(opts ...Option) error
 	Errors(opts ...Option) []error
+	Expand(apply ItemToObservable, maxConcurrency int, opts ...Option) Observable
 	Filter(apply Predicate, opts ...Option) Observable
 	First(opts ...Option) OptionalSingle
 	FirstOrDefault(defaultValue interface{}, opts ...Option) Single
 	FlatMap(apply ItemToObservable, opts ...Option) Observable
 	ForEach(nextFunc NextFunc, errFunc ErrFunc, completedFunc CompletedFunc, opts ...Option) Disposed
 	GroupBy(length int, distribution func(Item) int, opts ...Option) Observable
+	GroupByDynamic(keySelector Func, opts ...Option) Observable
 	IgnoreElements(opts ...Option) Observable
 	Join(joiner Func2, right Observable, timeExtractor func(interface{}) time.Time, window Duration, opts ...Option) Observable
 	Last(opts ...Option) OptionalSingle
 	LastOrDefault(defaultValue interface{}, opts ...Option) Single
+	Lift(operator ObserverOperator, opts ...Option) Observable
 	Map(apply Func, opts ...Option) Observable
 	Marshal(marshaller Marshaller, opts ...Option) Observable
+	Materialize(opts ...Option) Observable
 	Max(comparator Comparator, opts ...Option) OptionalSingle
+	MergeWith(other Observable, opts ...Option) Observable
 	Min(comparator Comparator, opts ...Option) OptionalSingle
+	ObserveOn(scheduler Scheduler, opts ...Option) Observable
 	OnErrorResumeNext(resumeSequence ErrorToObservable, opts ...Option) Observable
 	OnErrorReturn(resumeFunc ErrorFunc, opts ...Option) Observable
 	OnErrorReturnItem(resume interface{}, opts ...Option) Observable
+	Pairwise(opts ...Option) Observable
+	Pipe(operators ...OperatorFunc) Observable
+	Publish(opts ...Option) ConnectableObservable
 	Reduce(apply Func2, opts ...Option) OptionalSingle
 	Repeat(count int64, frequency Duration, opts ...Option) Observable
+	RepeatWhen(handler func(completions Observable) Observable, opts ...Option) Observable
 	Retry(count int, shouldRetry func(error) bool, opts ...Option) Observable
+	RetryWhen(handler func(errors Observable) Observable, opts ...Option) Observable
 	Run(opts ...Option) Disposed
 	Sample(iterable Iterable, opts ...Option) Observable
+	SampleWithTime(timespan Duration, opts ...Option) Observable
 	Scan(apply Func2, opts ...Option) Observable
+	ScanWithSeed(apply Func2, seed interface{}, opts ...Option) Observable
 	SequenceEqual(iterable Iterable, opts ...Option) Single
 	Send(output chan<- Item, opts ...Option)
 	Serialize(from int, identifier func(interface{}) int, opts ...Option) Observable
+	Share(opts ...Option) Observable
 	Skip(nth uint, opts ...Option) Observable
 	SkipLast(nth uint, opts ...Option) Observable
+	SkipUntil(notifier Observable, opts ...Option) Observable
 	SkipWhile(apply Predicate, opts ...Option) Observable
 	StartWith(iterable Iterable, opts ...Option) Observable
+	StartWithItems(items []interface{}, opts ...Option) Observable
+	StartWithObservable(other Observable, opts ...Option) Observable
+	Subscribe(observer Observer, opts ...Option) Disposed
+	SubscribeAwait(observer Observer, opts ...Option) *Subscription
+	SubscribeOn(scheduler Scheduler, opts ...Option) Observable
+	SubscribeWithContext(ctx context.Context, observer Observer, opts ...Option) Disposed
 	SumFloat32(opts ...Option) OptionalSingle
 	SumFloat64(opts ...Option) OptionalSingle
 	SumInt64(opts ...Option) OptionalSingle
+	SwitchIfEmpty(other Observable, opts ...Option) Observable
+	SwitchMap(apply ItemToObservable, opts ...Option) Observable
 	Take(nth uint, opts ...Option) Observable
 	TakeLast(nth uint, opts ...Option) Observable
 	TakeUntil(apply Predicate, opts ...Option) Observable
+	TakeUntilObservable(notifier Observable, opts ...Option) Observable
 	TakeWhile(apply Predicate, opts ...Option) Observable
+	Tap(nextFunc NextFunc, errFunc ErrFunc, completedFunc CompletedFunc, opts ...Option) Observable
+	ThrottleFirst(timespan Duration, opts ...Option) Observable
+	ThrottleLast(timespan Duration, opts ...Option) Observable
 	TimeInterval(opts ...Option) Observable
+	Timeout(timespan Duration, opts ...Option) Observable
+	TimeoutAt(deadline time.Time, opts ...Option) Observable
+	TimeoutWith(timespan Duration, fallback Observable, opts ...Option) Observable
 	Timestamp(opts ...Option) Observable
+	ToChannel(opts ...Option) <-chan interface{}
+	ToCompletable(opts ...Option) Completable
+	ToList(opts ...Option) Single
 	ToMap(keySelector Func, opts ...Option) Single
 	ToMapWithValueSelector(keySelector, valueSelector Func, opts ...Option) Single
+	ToMultimap(keySelector Func, opts ...Option) Single
+	ToMultimapWithValueSelector(keySelector, valueSelector Func, opts ...Option) Single
+	ToOptionalSingle(opts ...Option) OptionalSingle
+	ToSeq(ctx context.Context, opts ...Option) func(yield func(interface{}) bool)
+	ToSingle(opts ...Option) Single
 	ToSlice(initialCapacity int, opts ...Option) ([]interface{}, error)
 	Unmarshal(unmarshaller Unmarshaller, factory func() interface{}, opts ...Option) Observable
 	WindowWithCount(count int, opts ...Option) Observable
 	WindowWithTime(timespan Duration, opts ...Option) Observable
 	WindowWithTimeOrCount(timespan Duration, count int, opts ...Option) Observable
+	WithLatestFrom(other Observable, combiner Func2, opts ...Option) Observable
+	WriteTo(w io.Writer, marshaller Marshaller, opts ...Option) (int, error)
 	ZipFromIterable(iterable Iterable, zipper Func2, opts ...Option) Observable
 }
 
 // ObservableImpl implements Observable.
 type ObservableImpl struct {
 	iterable Iterable
+	// drainable is set if the Observable is an operator handling the drain of a Subscription
+	drainable bool
+	// fusion is set if the operators of the Observable can be fused with the next operator
+	fusion *fusion
+}
+
+// ConnectableObservable is an Observable sharing a single subscription to its source among all its observers.
+// It only starts emitting items once Connect is called.
+type ConnectableObservable interface {
+	Observable
+	AutoConnect(observers int) Observable
+	RefCount() Observable
+}
+
+// ConnectableObservableImpl implements ConnectableObservable.
+type ConnectableObservableImpl struct {
+	*ObservableImpl
+	publish *publishIterable
+}
+
+// GroupedObservable is an Observable emitting the items of a group, as emitted by GroupByDynamic.
+type GroupedObservable interface {
+	Observable
+	// Key returns the key shared by the items of the group.
+	Key() interface{}
+}
+
+// GroupedObservableImpl implements GroupedObservable.
+type GroupedObservableImpl struct {
+	*ObservableImpl
+	key interface{}
+}
+
+// Key returns the key shared by the items of the group.
+func (g *GroupedObservableImpl) Key() interface{} {
+	return g.key
+}
+
+// AutoConnect returns an Observable connecting the ConnectableObservable once the given number of observers
+// have subscribed, or right away if this number is not positive. Unlike RefCount, it never disconnects.
+func (c *ConnectableObservableImpl) AutoConnect(observers int) Observable {
+	if observers <= 0 {
+		c.publish.connect(context.Background())
+		return &ObservableImpl{iterable: c.publish}
+	}
+	return &ObservableImpl{iterable: &autoConnectIterable{publish: c.publish, observers: observers}}
+}
+
+// RefCount returns an Observable connecting the ConnectableObservable when the first observer subscribes
+// and disconnecting it when the last observer unsubscribes.
+func (c *ConnectableObservableImpl) RefCount() Observable {
+	return &ObservableImpl{iterable: &refCountIterable{publish: c.publish}}
 }
 
 func defaultErrorFuncOperator(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
@@ -99,26 +209,41 @@ func defaultErrorFuncOperator(ctx context.Context, item Item, dst chan<- Item, o
 
 func customObservableOperator(f func(ctx context.Context, next chan Item, option Option, opts ...Option), opts ...Option) Observable {
 	option := parseOptions(opts...)
+	f = traceCustomOperator(f, option)
 
 	if option.isEagerObservation() {
 		next := option.buildChannel()
 		ctx := option.buildContext()
-		go f(ctx, next, option, opts...)
-		return &ObservableImpl{iterable: newChannelIterable(next)}
+		go runCustomOperator(ctx, f, next, option, opts...)
+		return &ObservableImpl{iterable: instrument(newChannelIterable(next), option, nil)}
 	}
 
 	return &ObservableImpl{
-		iterable: newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
-			mergedOptions := append(opts, propagatedOptions...)
+		iterable: instrument(newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
+			mergedOptions := mergeOptions(opts, propagatedOptions)
 			option := parseOptions(mergedOptions...)
 			next := option.buildChannel()
 			ctx := option.buildContext()
-			go f(ctx, next, option, mergedOptions...)
+			go runCustomOperator(ctx, f, next, option, mergedOptions...)
 			return next
-		}),
+		}), option, nil),
+		// The drain is propagated to the sources observed by f
+		drainable: true,
 	}
 }
 
+// runCustomOperator runs f with its own context, propagated to the Observables it observes, so that
+// they stop producing as soon as f returns (e.g. after an error or once the observer is disposed).
+// next is closed once f returns, a PanicError being emitted beforehand if f panicked.
+func runCustomOperator(ctx context.Context, f func(ctx context.Context, next chan Item, option Option, opts ...Option), next chan Item, option Option, opts ...Option) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer close(next)
+	defer cancel()
+	defer recoverPanic(ctx, next, option)
+	// The sources do not emit the values carrying a context unwrapped by the operators run sequentially
+	f(ctx, next, option, append(opts, WithContext(ctx), requestItemContexts(false))...)
+}
+
 type operator interface {
 	next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions)
 	err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions)
@@ -128,6 +253,7 @@ type operator interface {
 
 func observable(iterable Iterable, operatorFactory func() operator, forceSeq, bypassGather bool, opts ...Option) Observable {
 	option := parseOptions(opts...)
+	operatorFactory = trackErrors(traceOperator(meterOperator(operatorFactory, option), option), option)
 	parallel, _ := option.getPool()
 
 	if option.isEagerObservation() {
@@ -136,22 +262,36 @@ func observable(iterable Iterable, operatorFactory func() operator, forceSeq, by
 		if forceSeq || !parallel {
 			runSequential(ctx, next, iterable, operatorFactory, option, opts...)
 		} else {
-			runParallel(ctx, next, iterable.Observe(opts...), operatorFactory, bypassGather, option, opts...)
+			runParallel(ctx, next, iterable.Observe(opts...), nil, operatorFactory, bypassGather, option, opts...)
 		}
-		return &ObservableImpl{iterable: newChannelIterable(next)}
+		return &ObservableImpl{iterable: instrument(newChannelIterable(next), option, operatorFactory)}
 	}
 
 	if forceSeq || !parallel {
+		// Adjacent fusable operators run in the goroutine of the last one
+		fused := fuse(iterable, operatorFactory, option)
+		if fused == nil {
+			fused = newFusion(iterable, operatorFactory, option)
+		}
+		source, factory := iterable, operatorFactory
+		if fused != nil {
+			source, factory = fused.source, fused.operator
+			if fused.batchSize > option.getBatchSize() {
+				opts = append(opts[:len(opts):len(opts)], WithBatchSize(fused.batchSize))
+			}
+		}
 		return &ObservableImpl{
-			iterable: newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
-				mergedOptions := append(opts, propagatedOptions...)
+			iterable: instrument(newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
+				mergedOptions := mergeOptions(opts, propagatedOptions)
 				option := parseOptions(mergedOptions...)
 
 				next := option.buildChannel()
 				ctx := option.buildContext()
-				runSequential(ctx, next, iterable, operatorFactory, option, mergedOptions...)
+				runSequential(ctx, next, source, factory, option, mergedOptions...)
 				return next
-			}),
+			}), option, operatorFactory),
+			drainable: true,
+			fusion:    fused,
 		}
 	}
 
@@ -160,7 +300,7 @@ func observable(iterable Iterable, operatorFactory func() operator, forceSeq, by
 		fromCh := make(chan Item, 1)
 		obs := &ObservableImpl{
 			iterable: newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
-				mergedOptions := append(opts, propagatedOptions...)
+				mergedOptions := mergeOptions(opts, propagatedOptions)
 				option := parseOptions(mergedOptions...)
 
 				next := option.buildChannel()
@@ -176,31 +316,33 @@ func observable(iterable Iterable, operatorFactory func() operator, forceSeq, by
 							return
 						}
 						Of(firstItemID.V.(int)).SendContext(ctx, fromCh)
-						runParallel(ctx, next, observe, operatorFactory, bypassGather, option, mergedOptions...)
+						runParallel(ctx, next, observe, nil, operatorFactory, bypassGather, option, mergedOptions...)
 					}
 				}()
 				runFirstItem(ctx, f, firstItemIDCh, observe, next, operatorFactory, bypassGather, option, mergedOptions...)
 				return next
 			}),
 		}
-		return obs.serialize(fromCh, f)
+		return &ObservableImpl{iterable: instrument(obs.serialize(fromCh, f), option, operatorFactory)}
 	}
 
 	return &ObservableImpl{
-		iterable: newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
-			mergedOptions := append(opts, propagatedOptions...)
+		iterable: instrument(newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
+			mergedOptions := mergeOptions(opts, propagatedOptions)
 			option := parseOptions(mergedOptions...)
 
 			next := option.buildChannel()
 			ctx := option.buildContext()
-			runParallel(ctx, next, iterable.Observe(mergedOptions...), operatorFactory, bypassGather, option, mergedOptions...)
+			runParallel(ctx, next, iterable.Observe(mergedOptions...), drainSource(iterable, option), operatorFactory, bypassGather, option, mergedOptions...)
 			return next
-		}),
+		}), option, operatorFactory),
+		drainable: true,
 	}
 }
 
 func single(iterable Iterable, operatorFactory func() operator, forceSeq, bypassGather bool, opts ...Option) Single {
 	option := parseOptions(opts...)
+	operatorFactory = trackErrors(traceOperator(meterOperator(operatorFactory, option), option), option)
 	parallel, _ := option.getPool()
 
 	if option.isEagerObservation() {
@@ -209,14 +351,14 @@ func single(iterable Iterable, operatorFactory func() operator, forceSeq, bypass
 		if forceSeq || !parallel {
 			runSequential(ctx, next, iterable, operatorFactory, option, opts...)
 		} else {
-			runParallel(ctx, next, iterable.Observe(opts...), operatorFactory, bypassGather, option, opts...)
+			runParallel(ctx, next, iterable.Observe(opts...), nil, operatorFactory, bypassGather, option, opts...)
 		}
-		return &SingleImpl{iterable: newChannelIterable(next)}
+		return &SingleImpl{iterable: instrument(newChannelIterable(next), option, operatorFactory)}
 	}
 
 	return &SingleImpl{
-		iterable: newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
-			mergedOptions := append(opts, propagatedOptions...)
+		iterable: instrument(newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
+			mergedOptions := mergeOptions(opts, propagatedOptions)
 			option = parseOptions(mergedOptions...)
 
 			next := option.buildChannel()
@@ -224,15 +366,16 @@ func single(iterable Iterable, operatorFactory func() operator, forceSeq, bypass
 			if forceSeq || !parallel {
 				runSequential(ctx, next, iterable, operatorFactory, option, mergedOptions...)
 			} else {
-				runParallel(ctx, next, iterable.Observe(mergedOptions...), operatorFactory, bypassGather, option, mergedOptions...)
+				runParallel(ctx, next, iterable.Observe(mergedOptions...), drainSource(iterable, option), operatorFactory, bypassGather, option, mergedOptions...)
 			}
 			return next
-		}),
+		}), option, operatorFactory),
 	}
 }
 
 func optionalSingle(iterable Iterable, operatorFactory func() operator, forceSeq, bypassGather bool, opts ...Option) OptionalSingle {
 	option := parseOptions(opts...)
+	operatorFactory = trackErrors(traceOperator(meterOperator(operatorFactory, option), option), option)
 	parallel, _ := option.getPool()
 
 	if option.isEagerObservation() {
@@ -241,14 +384,14 @@ func optionalSingle(iterable Iterable, operatorFactory func() operator, forceSeq
 		if forceSeq || !parallel {
 			runSequential(ctx, next, iterable, operatorFactory, option, opts...)
 		} else {
-			runParallel(ctx, next, iterable.Observe(opts...), operatorFactory, bypassGather, option, opts...)
+			runParallel(ctx, next, iterable.Observe(opts...), nil, operatorFactory, bypassGather, option, opts...)
 		}
-		return &OptionalSingleImpl{iterable: newChannelIterable(next)}
+		return &OptionalSingleImpl{iterable: instrument(newChannelIterable(next), option, operatorFactory)}
 	}
 
 	return &OptionalSingleImpl{
-		iterable: newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
-			mergedOptions := append(opts, propagatedOptions...)
+		iterable: instrument(newFactoryIterable(func(propagatedOptions ...Option) <-chan Item {
+			mergedOptions := mergeOptions(opts, propagatedOptions)
 			option = parseOptions(mergedOptions...)
 
 			next := option.buildChannel()
@@ -256,51 +399,264 @@ func optionalSingle(iterable Iterable, operatorFactory func() operator, forceSeq
 			if forceSeq || !parallel {
 				runSequential(ctx, next, iterable, operatorFactory, option, mergedOptions...)
 			} else {
-				runParallel(ctx, next, iterable.Observe(mergedOptions...), operatorFactory, bypassGather, option, mergedOptions...)
+				runParallel(ctx, next, iterable.Observe(mergedOptions...), drainSource(iterable, option), operatorFactory, bypassGather, option, mergedOptions...)
 			}
 			return next
-		}),
+		}), option, operatorFactory),
+	}
+}
+
+// process passes an item to an operator. Unless the panics are propagated, a panic (typically in a user
+// function) is converted into a PanicError handled by the operator like any other error.
+func process(ctx context.Context, op operator, item Item, dst chan<- Item, operatorOptions operatorOptions, option Option) {
+	ctx, item = withItemContext(ctx, item, dst, operatorOptions)
+	if option.getPanicStrategy() == RecoverPanic {
+		defer func() {
+			if r := recover(); r != nil {
+				op.err(ctx, Error(newPanicError(r)), dst, operatorOptions)
+			}
+		}()
+	}
+	if item.Error() {
+		op.err(ctx, item, dst, operatorOptions)
+	} else {
+		op.next(ctx, item, dst, operatorOptions)
+	}
+}
+
+// recoverPanic emits a PanicError if the goroutine sending to next panicked, unless the panics are propagated.
+// It has to be deferred before next is closed.
+func recoverPanic(ctx context.Context, next chan<- Item, option Option) {
+	if option.getPanicStrategy() != RecoverPanic {
+		return
+	}
+	if r := recover(); r != nil {
+		Error(newPanicError(r)).SendContext(ctx, next)
+	}
+}
+
+// call calls f and returns a PanicError if it panicked, unless the panics are propagated.
+func call(option Option, f func()) (err error) {
+	if option.getPanicStrategy() == RecoverPanic {
+		defer func() {
+			if r := recover(); r != nil {
+				err = newPanicError(r)
+			}
+		}()
+	}
+	f()
+	return nil
+}
+
+// processGathered is the equivalent of process for the items gathered from the parallel operators.
+func processGathered(ctx context.Context, op operator, item Item, dst chan<- Item, operatorOptions operatorOptions, option Option) {
+	if option.getPanicStrategy() == RecoverPanic {
+		defer func() {
+			if r := recover(); r != nil {
+				op.err(ctx, Error(newPanicError(r)), dst, operatorOptions)
+			}
+		}()
+	}
+	op.gatherNext(ctx, item, dst, operatorOptions)
+}
+
+// trackedOperator is an operator whose errors are wrapped into StreamErrors, see WithStreamErrors.
+// It is only tracked when run sequentially.
+type trackedOperator struct {
+	operator
+	name  string
+	stack []byte
+}
+
+// trackErrors captures the stack of the operator creation if WithStreamErrors is set.
+func trackErrors(operatorFactory func() operator, option Option) func() operator {
+	if !option.isStreamErrors() {
+		return operatorFactory
+	}
+	stack := debug.Stack()
+	return func() operator {
+		op := operatorFactory()
+		return &trackedOperator{
+			operator: op,
+			name:     operatorName(op),
+			stack:    stack,
+		}
+	}
+}
+
+// operatorName returns the name of an operator, e.g. "map" for a mapOperator.
+func operatorName(op operator) string {
+	for unwrapped := false; !unwrapped; {
+		switch wrapped := op.(type) {
+		case *meteredOperator:
+			op = wrapped.operator
+		case *tracedOperator:
+			op = wrapped.operator
+		case *trackedOperator:
+			op = wrapped.operator
+		default:
+			unwrapped = true
+		}
 	}
+	return strings.TrimSuffix(reflect.TypeOf(op).Elem().Name(), "Operator")
+}
+
+// trackerSync is sent by the operator goroutine once an item is processed.
+type trackerSync struct{}
+
+// errorTracker forwards the items emitted by a tracked operator, wrapping its errors into StreamErrors.
+type errorTracker struct {
+	op   *trackedOperator
+	in   chan Item
+	done chan struct{}
+	// index is the index of the item being processed, -1 once the source completed. It is only written by the
+	// operator goroutine while no item is pending in the in channel.
+	index int
+}
+
+func newErrorTracker(ctx context.Context, op *trackedOperator, next chan Item) *errorTracker {
+	t := &errorTracker{
+		op:   op,
+		in:   make(chan Item),
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer close(t.done)
+		for item := range t.in {
+			if _, ok := item.V.(trackerSync); ok {
+				continue
+			}
+			if item.Error() {
+				if _, tracked := item.E.(StreamError); !tracked {
+					item = Error(StreamError{
+						Operator: t.op.name,
+						Index:    t.index,
+						Stack:    t.op.stack,
+						Err:      item.E,
+					})
+				}
+			}
+			// The items are drained once the context is cancelled
+			item.SendContext(ctx, next)
+		}
+	}()
+	return t
+}
+
+// sync waits for the items emitted while processing the current item to be forwarded.
+func (t *errorTracker) sync(ctx context.Context) {
+	Of(trackerSync{}).SendContext(ctx, t.in)
+}
+
+func (t *errorTracker) close() {
+	close(t.in)
+	<-t.done
 }
 
 func runSequential(ctx context.Context, next chan Item, iterable Iterable, operatorFactory func() operator, option Option, opts ...Option) {
-	observe := iterable.Observe(opts...)
+	// The source is observed with its own context so that it can be cancelled when the iterable is reset
+	sourceCtx, cancelSource := context.WithCancel(ctx)
+	// The source emits the values carrying a context, unwrapped when processed
+	opts = append(opts[:len(opts):len(opts)], requestItemContexts(true))
+	if size := option.getBatchSize(); size > 1 {
+		opts = append(opts, requestBatches(size))
+	}
+	observe := iterable.Observe(append(opts, WithContext(sourceCtx))...)
+	drain := drainSource(iterable, option)
 	go func() {
+		defer func() {
+			cancelSource()
+		}()
 		op := operatorFactory()
-		stopped := false
+		dst := next
+		var tracker *errorTracker
+		processed := 0
+		if t, ok := op.(*trackedOperator); ok {
+			op = t.operator
+			tracker = newErrorTracker(ctx, t, next)
+			dst = tracker.in
+		}
+		stopped, failed := false, false
 		operator := operatorOptions{
+			stopOnError: option.getErrorStrategy() == StopOnError,
 			stop: func() {
 				if option.getErrorStrategy() == StopOnError {
-					stopped = true
+					stopped, failed = true, true
 				}
 			},
+			complete: func() {
+				stopped = true
+			},
+			forwardContexts: option.isItemContextRequested(),
 			resetIterable: func(newIterable Iterable) {
-				observe = newIterable.Observe(opts...)
+				cancelSource()
+				sourceCtx, cancelSource = context.WithCancel(ctx)
+				observe = newIterable.Observe(append(opts, WithContext(sourceCtx))...)
 			},
 		}
 
+		handle := func(i Item) {
+			if tracker == nil {
+				process(ctx, op, i, next, operator, option)
+				return
+			}
+			tracker.index = processed
+			if i.Error() {
+				if _, tracked := i.E.(StreamError); !tracked {
+					i = Error(StreamError{Index: processed, Err: i.E})
+				}
+			}
+			process(ctx, op, i, dst, operator, option)
+			tracker.sync(ctx)
+			processed++
+		}
+
 	loop:
 		for !stopped {
+			// Once drained, the source is not observed anymore and the operator completes
+			select {
+			case <-drain:
+				break loop
+			default:
+			}
 			select {
 			case <-ctx.Done():
 				break loop
+			case <-drain:
+				break loop
 			case i, ok := <-observe:
 				if !ok {
 					break loop
 				}
-				if i.Error() {
-					op.err(ctx, i, next, operator)
-				} else {
-					op.next(ctx, i, next, operator)
+				batch, batched := i.V.(*itemBatch)
+				if !batched {
+					handle(i)
+					continue
+				}
+				for _, i := range batch.items {
+					if stopped {
+						break
+					}
+					handle(i)
 				}
+				batch.release()
 			}
 		}
-		op.end(ctx, next)
+		if tracker != nil {
+			tracker.index = -1
+		}
+		// An operator stopped on an error emits no result afterwards
+		if !failed {
+			op.end(ctx, dst)
+		}
+		if tracker != nil {
+			tracker.close()
+		}
 		close(next)
 	}()
 }
 
-func runParallel(ctx context.Context, next chan Item, observe <-chan Item, operatorFactory func() operator, bypassGather bool, option Option, opts ...Option) {
+func runParallel(ctx context.Context, next chan Item, observe <-chan Item, drain <-chan struct{}, operatorFactory func() operator, bypassGather bool, option Option, opts ...Option) {
 	wg := sync.WaitGroup{}
 	_, pool := option.getPool()
 	wg.Add(pool)
@@ -309,18 +665,26 @@ func runParallel(ctx context.Context, next chan Item, observe <-chan Item, opera
 	if bypassGather {
 		gather = next
 	} else {
-		gather = make(chan Item, 1)
+		capacity := option.getBufferedChannelCapacity()
+		if capacity < 1 {
+			capacity = 1
+		}
+		gather = make(chan Item, capacity)
 
 		// Gather
 		go func() {
 			op := operatorFactory()
-			stopped := false
+			stopped, failed := false, false
 			operator := operatorOptions{
+				stopOnError: option.getErrorStrategy() == StopOnError,
 				stop: func() {
 					if option.getErrorStrategy() == StopOnError {
-						stopped = true
+						stopped, failed = true, true
 					}
 				},
+				complete: func() {
+					stopped = true
+				},
 				resetIterable: func(newIterable Iterable) {
 					observe = newIterable.Observe(opts...)
 				},
@@ -332,25 +696,39 @@ func runParallel(ctx context.Context, next chan Item, observe <-chan Item, opera
 				if item.Error() {
 					op.err(ctx, item, next, operator)
 				} else {
-					op.gatherNext(ctx, item, next, operator)
+					processGathered(ctx, op, item, next, operator, option)
 				}
 			}
-			op.end(ctx, next)
+			if !failed {
+				op.end(ctx, next)
+			}
 			close(next)
 		}()
 	}
 
+	if scheduler := option.getScheduler(); scheduler != nil {
+		go func() {
+			scatterScheduled(ctx, scheduler, observe, drain, gather, pool, operatorFactory, bypassGather, option, opts...)
+			close(gather)
+		}()
+		return
+	}
+
 	// Scatter
 	for i := 0; i < pool; i++ {
 		go func() {
 			op := operatorFactory()
 			stopped := false
 			operator := operatorOptions{
+				stopOnError: option.getErrorStrategy() == StopOnError,
 				stop: func() {
 					if option.getErrorStrategy() == StopOnError {
 						stopped = true
 					}
 				},
+				complete: func() {
+					stopped = true
+				},
 				resetIterable: func(newIterable Iterable) {
 					observe = newIterable.Observe(opts...)
 				},
@@ -360,6 +738,11 @@ func runParallel(ctx context.Context, next chan Item, observe <-chan Item, opera
 				select {
 				case <-ctx.Done():
 					return
+				case <-drain:
+					if !bypassGather {
+						Of(op).SendContext(ctx, gather)
+					}
+					return
 				case item, ok := <-observe:
 					if !ok {
 						if !bypassGather {
@@ -367,11 +750,7 @@ func runParallel(ctx context.Context, next chan Item, observe <-chan Item, opera
 						}
 						return
 					}
-					if item.Error() {
-						op.err(ctx, item, gather, operator)
-					} else {
-						op.next(ctx, item, gather, operator)
-					}
+					process(ctx, op, item, gather, operator, option)
 				}
 			}
 		}()
@@ -383,16 +762,102 @@ func runParallel(ctx context.Context, next chan Item, observe <-chan Item, opera
 	}()
 }
 
+// parallelWorker is an operator processing the items scattered to tasks, see scatterScheduled.
+type parallelWorker struct {
+	op      operator
+	options operatorOptions
+	stopped bool
+}
+
+// scatterScheduled processes each item from a task run by scheduler, with one of the pool operators not
+// processing another item. It returns once all the tasks are run.
+func scatterScheduled(ctx context.Context, scheduler Scheduler, observe <-chan Item, drain <-chan struct{}, gather chan Item, pool int, operatorFactory func() operator, bypassGather bool, option Option, opts ...Option) {
+	workers := make(chan *parallelWorker, pool)
+	all := make([]*parallelWorker, 0, pool)
+	for i := 0; i < pool; i++ {
+		w := &parallelWorker{op: operatorFactory()}
+		w.options = operatorOptions{
+			stopOnError: option.getErrorStrategy() == StopOnError,
+			stop: func() {
+				if option.getErrorStrategy() == StopOnError {
+					w.stopped = true
+				}
+			},
+			complete: func() {
+				w.stopped = true
+			},
+			resetIterable: func(newIterable Iterable) {
+				observe = newIterable.Observe(opts...)
+			},
+		}
+		workers <- w
+		all = append(all, w)
+	}
+
+	var tasks sync.WaitGroup
+	stopped := 0
+	completed := false
+loop:
+	for stopped < pool {
+		var w *parallelWorker
+		select {
+		case <-ctx.Done():
+			break loop
+		case w = <-workers:
+		}
+		if w.stopped {
+			// A stopped operator does not process any other item
+			stopped++
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-drain:
+			completed = true
+			break loop
+		case item, ok := <-observe:
+			if !ok {
+				completed = true
+				break loop
+			}
+			tasks.Add(1)
+			if !scheduler.Schedule(func() {
+				defer tasks.Done()
+				process(ctx, w.op, item, gather, w.options, option)
+				workers <- w
+			}) {
+				tasks.Done()
+				Error(errRejectedTask).SendContext(ctx, gather)
+				break loop
+			}
+		}
+	}
+	tasks.Wait()
+
+	if completed && !bypassGather {
+		for _, w := range all {
+			if !w.stopped {
+				Of(w.op).SendContext(ctx, gather)
+			}
+		}
+	}
+}
+
 func runFirstItem(ctx context.Context, f func(interface{}) int, notif chan Item, observe <-chan Item, next chan Item, operatorFactory func() operator, bypassGather bool, option Option, opts ...Option) {
 	go func() {
 		op := operatorFactory()
-		stopped := false
+		stopped, failed := false, false
 		operator := operatorOptions{
+			stopOnError: option.getErrorStrategy() == StopOnError,
 			stop: func() {
 				if option.getErrorStrategy() == StopOnError {
-					stopped = true
+					stopped, failed = true, true
 				}
 			},
+			complete: func() {
+				stopped = true
+			},
 			resetIterable: func(newIterable Iterable) {
 				observe = newIterable.Observe(opts...)
 			},
@@ -407,16 +872,17 @@ func runFirstItem(ctx context.Context, f func(interface{}) int, notif chan Item,
 				if !ok {
 					break loop
 				}
+				process(ctx, op, i, next, operator, option)
 				if i.Error() {
-					op.err(ctx, i, next, operator)
 					i.SendContext(ctx, notif)
 				} else {
-					op.next(ctx, i, next, operator)
 					Of(f(i.V)).SendContext(ctx, notif)
 				}
 			}
 		}
-		op.end(ctx, next)
+		if !failed {
+			op.end(ctx, next)
+		}
 	}()
 }
 
@@ -463,7 +929,7 @@ func (o *ObservableImpl) serialize(fromCh chan Item, identifier func(interface{}
 							return
 						}
 						if item.Error() {
-							next <- item
+							item.SendContext(ctx, next)
 							return
 						}
 
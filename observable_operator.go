@@ -1,9 +1,11 @@
 package rxgo
 
 import (
+	"container/list"
 	"container/ring"
 	"context"
 	"fmt"
+	"io"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -32,7 +34,7 @@ func (op *allOperator) next(ctx context.Context, item Item, dst chan<- Item, ope
 	if !op.predicate(item.V) {
 		Of(false).SendContext(ctx, dst)
 		op.all = false
-		operatorOptions.stop()
+		operatorOptions.complete()
 	}
 }
 
@@ -50,346 +52,278 @@ func (op *allOperator) gatherNext(ctx context.Context, item Item, dst chan<- Ite
 	if item.V == false {
 		Of(false).SendContext(ctx, dst)
 		op.all = false
-		operatorOptions.stop()
+		operatorOptions.complete()
 	}
 }
 
 // AverageFloat32 calculates the average of numbers emitted by an Observable and emits the average float32.
 func (o *ObservableImpl) AverageFloat32(opts ...Option) Single {
-	return single(o, func() operator {
-		return &averageFloat32Operator{}
-	}, false, false, opts...)
-}
-
-type averageFloat32Operator struct {
-	sum   float32
-	count float32
-}
-
-func (op *averageFloat32Operator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	switch v := item.V.(type) {
-	default:
-		Error(IllegalInputError{error: fmt.Sprintf("expected type: float or int, got: %t", item)}).SendContext(ctx, dst)
-		operatorOptions.stop()
-	case int:
-		op.sum += float32(v)
-		op.count++
-	case float32:
-		op.sum += v
-		op.count++
-	case float64:
-		op.sum += float32(v)
-		op.count++
-	}
-}
-
-func (op *averageFloat32Operator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
-}
-
-func (op *averageFloat32Operator) end(ctx context.Context, dst chan<- Item) {
-	if op.count == 0 {
-		Of(0).SendContext(ctx, dst)
-	} else {
-		Of(op.sum/op.count).SendContext(ctx, dst)
-	}
-}
-
-func (op *averageFloat32Operator) gatherNext(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
-	v := item.V.(*averageFloat32Operator)
-	op.sum += v.sum
-	op.count += v.count
+	return single(o, fold{
+		seed: float32(0),
+		step: func(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
+			switch v := elem.(type) {
+			default:
+				return nil, IllegalInputError{error: fmt.Sprintf("expected type: float or int, got: %t", elem)}
+			case int:
+				return acc.(float32) + float32(v), nil
+			case float32:
+				return acc.(float32) + v, nil
+			case float64:
+				return acc.(float32) + float32(v), nil
+			}
+		},
+		result: averageResult(func(sum interface{}, count int64) interface{} {
+			return sum.(float32) / float32(count)
+		}),
+	}.operatorFactory(), false, false, opts...)
 }
 
 // AverageFloat64 calculates the average of numbers emitted by an Observable and emits the average float64.
 func (o *ObservableImpl) AverageFloat64(opts ...Option) Single {
-	return single(o, func() operator {
-		return &averageFloat64Operator{}
-	}, false, false, opts...)
-}
-
-type averageFloat64Operator struct {
-	sum   float64
-	count float64
-}
-
-func (op *averageFloat64Operator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	switch v := item.V.(type) {
-	default:
-		Error(IllegalInputError{error: fmt.Sprintf("expected type: float or int, got: %t", item)}).SendContext(ctx, dst)
-		operatorOptions.stop()
-	case int:
-		op.sum += float64(v)
-		op.count++
-	case float32:
-		op.sum += float64(v)
-		op.count++
-	case float64:
-		op.sum += v
-		op.count++
-	}
-}
-
-func (op *averageFloat64Operator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
-}
-
-func (op *averageFloat64Operator) end(ctx context.Context, dst chan<- Item) {
-	if op.count == 0 {
-		Of(0).SendContext(ctx, dst)
-	} else {
-		Of(op.sum/op.count).SendContext(ctx, dst)
-	}
-}
-
-func (op *averageFloat64Operator) gatherNext(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
-	v := item.V.(*averageFloat64Operator)
-	op.sum += v.sum
-	op.count += v.count
+	return single(o, fold{
+		seed: float64(0),
+		step: func(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
+			switch v := elem.(type) {
+			default:
+				return nil, IllegalInputError{error: fmt.Sprintf("expected type: float or int, got: %t", elem)}
+			case int:
+				return acc.(float64) + float64(v), nil
+			case float32:
+				return acc.(float64) + float64(v), nil
+			case float64:
+				return acc.(float64) + v, nil
+			}
+		},
+		result: averageResult(func(sum interface{}, count int64) interface{} {
+			return sum.(float64) / float64(count)
+		}),
+	}.operatorFactory(), false, false, opts...)
 }
 
 // AverageInt calculates the average of numbers emitted by an Observable and emits the average int.
 func (o *ObservableImpl) AverageInt(opts ...Option) Single {
-	return single(o, func() operator {
-		return &averageIntOperator{}
-	}, false, false, opts...)
-}
-
-type averageIntOperator struct {
-	sum   int
-	count int
-}
-
-func (op *averageIntOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	switch v := item.V.(type) {
-	default:
-		Error(IllegalInputError{error: fmt.Sprintf("expected type: int, got: %t", item)}).SendContext(ctx, dst)
-		operatorOptions.stop()
-	case int:
-		op.sum += v
-		op.count++
-	}
-}
-
-func (op *averageIntOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
-}
-
-func (op *averageIntOperator) end(ctx context.Context, dst chan<- Item) {
-	if op.count == 0 {
-		Of(0).SendContext(ctx, dst)
-	} else {
-		Of(op.sum/op.count).SendContext(ctx, dst)
-	}
-}
-
-func (op *averageIntOperator) gatherNext(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
-	v := item.V.(*averageIntOperator)
-	op.sum += v.sum
-	op.count += v.count
+	return single(o, fold{
+		seed: int(0),
+		step: func(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
+			switch v := elem.(type) {
+			default:
+				return nil, IllegalInputError{error: fmt.Sprintf("expected type: int, got: %t", elem)}
+			case int:
+				return acc.(int) + v, nil
+			}
+		},
+		result: averageResult(func(sum interface{}, count int64) interface{} {
+			return sum.(int) / int(count)
+		}),
+	}.operatorFactory(), false, false, opts...)
 }
 
-// AverageInt8 calculates the average of numbers emitted by an Observable and emits the≤ average int8.
+// AverageInt8 calculates the average of numbers emitted by an Observable and emits the average int8.
 func (o *ObservableImpl) AverageInt8(opts ...Option) Single {
-	return single(o, func() operator {
-		return &averageInt8Operator{}
-	}, false, false, opts...)
-}
-
-type averageInt8Operator struct {
-	sum   int8
-	count int8
-}
-
-func (op *averageInt8Operator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	switch v := item.V.(type) {
-	default:
-		Error(IllegalInputError{error: fmt.Sprintf("expected type: int8, got: %t", item)}).SendContext(ctx, dst)
-		operatorOptions.stop()
-	case int8:
-		op.sum += v
-		op.count++
-	}
-}
-
-func (op *averageInt8Operator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
-}
-
-func (op *averageInt8Operator) end(ctx context.Context, dst chan<- Item) {
-	if op.count == 0 {
-		Of(0).SendContext(ctx, dst)
-	} else {
-		Of(op.sum/op.count).SendContext(ctx, dst)
-	}
-}
-
-func (op *averageInt8Operator) gatherNext(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
-	v := item.V.(*averageInt8Operator)
-	op.sum += v.sum
-	op.count += v.count
+	return single(o, fold{
+		seed: int8(0),
+		step: func(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
+			switch v := elem.(type) {
+			default:
+				return nil, IllegalInputError{error: fmt.Sprintf("expected type: int8, got: %t", elem)}
+			case int8:
+				return acc.(int8) + v, nil
+			}
+		},
+		result: averageResult(func(sum interface{}, count int64) interface{} {
+			return sum.(int8) / int8(count)
+		}),
+	}.operatorFactory(), false, false, opts...)
 }
 
 // AverageInt16 calculates the average of numbers emitted by an Observable and emits the average int16.
 func (o *ObservableImpl) AverageInt16(opts ...Option) Single {
-	return single(o, func() operator {
-		return &averageInt16Operator{}
-	}, false, false, opts...)
-}
-
-type averageInt16Operator struct {
-	sum   int16
-	count int16
-}
-
-func (op *averageInt16Operator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	switch v := item.V.(type) {
-	default:
-		Error(IllegalInputError{error: fmt.Sprintf("expected type: int16, got: %t", item)}).SendContext(ctx, dst)
-		operatorOptions.stop()
-	case int16:
-		op.sum += v
-		op.count++
-	}
-}
-
-func (op *averageInt16Operator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
-}
-
-func (op *averageInt16Operator) end(ctx context.Context, dst chan<- Item) {
-	if op.count == 0 {
-		Of(0).SendContext(ctx, dst)
-	} else {
-		Of(op.sum/op.count).SendContext(ctx, dst)
-	}
-}
-
-func (op *averageInt16Operator) gatherNext(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
-	v := item.V.(*averageInt16Operator)
-	op.sum += v.sum
-	op.count += v.count
+	return single(o, fold{
+		seed: int16(0),
+		step: func(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
+			switch v := elem.(type) {
+			default:
+				return nil, IllegalInputError{error: fmt.Sprintf("expected type: int16, got: %t", elem)}
+			case int16:
+				return acc.(int16) + v, nil
+			}
+		},
+		result: averageResult(func(sum interface{}, count int64) interface{} {
+			return sum.(int16) / int16(count)
+		}),
+	}.operatorFactory(), false, false, opts...)
 }
 
 // AverageInt32 calculates the average of numbers emitted by an Observable and emits the average int32.
 func (o *ObservableImpl) AverageInt32(opts ...Option) Single {
-	return single(o, func() operator {
-		return &averageInt32Operator{}
-	}, false, false, opts...)
-}
-
-type averageInt32Operator struct {
-	sum   int32
-	count int32
-}
-
-func (op *averageInt32Operator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	switch v := item.V.(type) {
-	default:
-		Error(IllegalInputError{error: fmt.Sprintf("expected type: int32, got: %t", item)}).SendContext(ctx, dst)
-		operatorOptions.stop()
-	case int32:
-		op.sum += v
-		op.count++
-	}
-}
-
-func (op *averageInt32Operator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
-}
-
-func (op *averageInt32Operator) end(ctx context.Context, dst chan<- Item) {
-	if op.count == 0 {
-		Of(0).SendContext(ctx, dst)
-	} else {
-		Of(op.sum/op.count).SendContext(ctx, dst)
-	}
-}
-
-func (op *averageInt32Operator) gatherNext(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
-	v := item.V.(*averageInt32Operator)
-	op.sum += v.sum
-	op.count += v.count
+	return single(o, fold{
+		seed: int32(0),
+		step: func(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
+			switch v := elem.(type) {
+			default:
+				return nil, IllegalInputError{error: fmt.Sprintf("expected type: int32, got: %t", elem)}
+			case int32:
+				return acc.(int32) + v, nil
+			}
+		},
+		result: averageResult(func(sum interface{}, count int64) interface{} {
+			return sum.(int32) / int32(count)
+		}),
+	}.operatorFactory(), false, false, opts...)
 }
 
 // AverageInt64 calculates the average of numbers emitted by an Observable and emits this average int64.
 func (o *ObservableImpl) AverageInt64(opts ...Option) Single {
-	return single(o, func() operator {
-		return &averageInt64Operator{}
-	}, false, false, opts...)
-}
-
-type averageInt64Operator struct {
-	sum   int64
-	count int64
+	return single(o, fold{
+		seed: int64(0),
+		step: func(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
+			switch v := elem.(type) {
+			default:
+				return nil, IllegalInputError{error: fmt.Sprintf("expected type: int64, got: %t", elem)}
+			case int64:
+				return acc.(int64) + v, nil
+			}
+		},
+		result: averageResult(func(sum interface{}, count int64) interface{} {
+			return sum.(int64) / int64(count)
+		}),
+	}.operatorFactory(), false, false, opts...)
+}
+
+// averageResult emits 0 for an empty Observable, otherwise the average computed by div.
+func averageResult(div func(sum interface{}, count int64) interface{}) func(interface{}, int64) (interface{}, bool) {
+	return func(sum interface{}, count int64) (interface{}, bool) {
+		if count == 0 {
+			return 0, true
+		}
+		return div(sum, count), true
+	}
 }
 
-func (op *averageInt64Operator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	switch v := item.V.(type) {
-	default:
-		Error(IllegalInputError{error: fmt.Sprintf("expected type: int64, got: %t", item)}).SendContext(ctx, dst)
-		operatorOptions.stop()
-	case int64:
-		op.sum += v
-		op.count++
+// BackOffRetry implements a backoff retry if a source Observable sends an error, resubscribe to it in the hopes that it will complete without error.
+// Cannot be run in parallel.
+func (o *ObservableImpl) BackOffRetry(backOffCfg backoff.BackOff, opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		retry := func() error {
+			observe := o.Observe(opts...)
+			for {
+				select {
+				case <-ctx.Done():
+					return backoff.Permanent(ctx.Err())
+				case i, ok := <-observe:
+					if !ok {
+						return nil
+					}
+					if i.Error() {
+						return i.E
+					}
+					i.SendContext(ctx, next)
+				}
+			}
+		}
+		if err := backoff.Retry(retry, backoff.WithContext(backOffCfg, ctx)); err != nil && ctx.Err() == nil {
+			Error(err).SendContext(ctx, next)
+		}
 	}
-}
 
-func (op *averageInt64Operator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+	return customObservableOperator(f, opts...)
 }
 
-func (op *averageInt64Operator) end(ctx context.Context, dst chan<- Item) {
-	if op.count == 0 {
-		Of(0).SendContext(ctx, dst)
-	} else {
-		Of(op.sum/op.count).SendContext(ctx, dst)
+// BlockingFirst blocks until the Observable emits its first item, and returns its value or error.
+// An IllegalInputError is returned if the Observable completes without emitting any item, and the context error if
+// ctx is cancelled first. The Observable is disposed once the first item is received.
+func (o *ObservableImpl) BlockingFirst(ctx context.Context, opts ...Option) (interface{}, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	observe := o.Observe(append(opts, WithContext(ctx))...)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case item, ok := <-observe:
+		if !ok {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			return nil, IllegalInputError{error: "no item emitted"}
+		}
+		if item.Error() {
+			return nil, item.E
+		}
+		return item.V, nil
 	}
 }
 
-func (op *averageInt64Operator) gatherNext(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
-	v := item.V.(*averageInt64Operator)
-	op.sum += v.sum
-	op.count += v.count
+// BlockingForEach blocks until the Observable completes, calling f for the value of each item emitted.
+// It returns the first error emitted by the Observable or returned by f, which also disposes the Observable, or
+// the context error if ctx is cancelled first.
+func (o *ObservableImpl) BlockingForEach(ctx context.Context, f func(interface{}) error, opts ...Option) error {
+	return o.blockingForEach(ctx, f, parseOptions(opts...).getValuePool(), opts...)
 }
 
-// BackOffRetry implements a backoff retry if a source Observable sends an error, resubscribe to it in the hopes that it will complete without error.
-// Cannot be run in parallel.
-func (o *ObservableImpl) BackOffRetry(backOffCfg backoff.BackOff, opts ...Option) Observable {
+// blockingForEach is BlockingForEach, the values being put back in pool once processed by f.
+func (o *ObservableImpl) blockingForEach(ctx context.Context, f func(interface{}) error, pool *ValuePool, opts ...Option) error {
 	option := parseOptions(opts...)
-	next := option.buildChannel()
-	ctx := option.buildContext()
-
-	f := func() error {
-		observe := o.Observe(opts...)
-		for {
-			select {
-			case <-ctx.Done():
-				close(next)
-				return nil
-			case i, ok := <-observe:
-				if !ok {
-					return nil
-				}
-				if i.Error() {
-					return i.E
-				}
-				i.SendContext(ctx, next)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	observe := o.Observe(append(opts, WithContext(ctx))...)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case item, ok := <-observe:
+			if !ok {
+				return ctx.Err()
 			}
+			if item.Error() {
+				return item.E
+			}
+			var err error
+			if panicErr := call(option, func() { err = f(item.V) }); panicErr != nil {
+				return panicErr
+			}
+			if err != nil {
+				return err
+			}
+			recycle(pool, item.V)
 		}
 	}
-	go func() {
-		if err := backoff.Retry(f, backOffCfg); err != nil {
-			Error(err).SendContext(ctx, next)
-			close(next)
-			return
-		}
-		close(next)
-	}()
+}
 
-	return &ObservableImpl{
-		iterable: newChannelIterable(next),
+// BlockingLast blocks until the Observable completes, and returns the value of its last item.
+// It returns the first error emitted, an IllegalInputError if the Observable completes without emitting any item,
+// or the context error if ctx is cancelled first.
+func (o *ObservableImpl) BlockingLast(ctx context.Context, opts ...Option) (interface{}, error) {
+	var last interface{}
+	empty := true
+	// The last value is returned: the values are not put back in the pool
+	if err := o.blockingForEach(ctx, func(i interface{}) error {
+		last = i
+		empty = false
+		return nil
+	}, nil, opts...); err != nil {
+		return nil, err
 	}
+	if empty {
+		return nil, IllegalInputError{error: "no item emitted"}
+	}
+	return last, nil
+}
+
+// BlockingSubscribe is the blocking version of Subscribe: it feeds observer until the Observable terminates or
+// ctx is cancelled, and returns the error passed to OnError, or the context error.
+func (o *ObservableImpl) BlockingSubscribe(ctx context.Context, observer Observer, opts ...Option) error {
+	observer = interceptObserver(observer, parseOptions(opts...))
+	err := o.BlockingForEach(ctx, func(i interface{}) error {
+		observer.OnNext(i)
+		return nil
+	}, opts...)
+	switch {
+	case err == nil:
+		observer.OnCompleted()
+	case ctx.Err() == nil:
+		observer.OnError(err)
+	}
+	return err
 }
 
 // BufferWithCount returns an Observable that emits buffers of items it collects
@@ -429,6 +363,10 @@ func (op *bufferWithCountOperator) next(ctx context.Context, item Item, dst chan
 
 func (op *bufferWithCountOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+	// The incomplete buffer is still emitted, end being skipped
+	if operatorOptions.stopOnError {
+		op.end(ctx, dst)
+	}
 }
 
 func (op *bufferWithCountOperator) end(ctx context.Context, dst chan<- Item) {
@@ -440,6 +378,63 @@ func (op *bufferWithCountOperator) end(ctx context.Context, dst chan<- Item) {
 func (op *bufferWithCountOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
 }
 
+// BufferWithCountAndSkip returns an Observable that emits buffers of count items, a new buffer being started every
+// skip items. The buffers overlap if skip is lower than count, and some items are dropped if it is greater.
+// When the source Observable completes, the resulting Observable emits the buffers started and not yet emitted.
+// Cannot be run in parallel.
+func (o *ObservableImpl) BufferWithCountAndSkip(count, skip int, opts ...Option) Observable {
+	if count <= 0 {
+		return Thrown(IllegalInputError{error: "count must be positive"})
+	}
+	if skip <= 0 {
+		return Thrown(IllegalInputError{error: "skip must be positive"})
+	}
+
+	return observable(o, func() operator {
+		return &bufferWithCountAndSkipOperator{
+			count: count,
+			skip:  skip,
+		}
+	}, true, false, opts...)
+}
+
+type bufferWithCountAndSkipOperator struct {
+	count   int
+	skip    int
+	index   int
+	buffers [][]interface{}
+}
+
+func (op *bufferWithCountAndSkipOperator) next(ctx context.Context, item Item, dst chan<- Item, _ operatorOptions) {
+	if op.index%op.skip == 0 {
+		op.buffers = append(op.buffers, make([]interface{}, 0, op.count))
+	}
+	op.index++
+	for i := range op.buffers {
+		op.buffers[i] = append(op.buffers[i], item.V)
+	}
+	if len(op.buffers) > 0 && len(op.buffers[0]) == op.count {
+		Of(op.buffers[0]).SendContext(ctx, dst)
+		op.buffers[0] = nil
+		op.buffers = op.buffers[1:]
+	}
+}
+
+func (op *bufferWithCountAndSkipOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *bufferWithCountAndSkipOperator) end(ctx context.Context, dst chan<- Item) {
+	for _, buffer := range op.buffers {
+		if !Of(buffer).SendContext(ctx, dst) {
+			return
+		}
+	}
+}
+
+func (op *bufferWithCountAndSkipOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
 // BufferWithTime returns an Observable that emits buffers of items it collects from the source
 // Observable. The resulting Observable starts a new buffer periodically, as determined by the
 // timeshift argument. It emits each buffer after a fixed timespan, specified by the timespan argument.
@@ -449,71 +444,12 @@ func (o *ObservableImpl) BufferWithTime(timespan Duration, opts ...Option) Obser
 	if timespan == nil {
 		return Thrown(IllegalInputError{error: "timespan must no be nil"})
 	}
-
-	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
-		observe := o.Observe(opts...)
-		buffer := make([]interface{}, 0)
-		stop := make(chan struct{})
-		mutex := sync.Mutex{}
-
-		checkBuffer := func() {
-			mutex.Lock()
-			if len(buffer) != 0 {
-				if !Of(buffer).SendContext(ctx, next) {
-					mutex.Unlock()
-					return
-				}
-				buffer = make([]interface{}, 0)
-			}
-			mutex.Unlock()
-		}
-
-		go func() {
-			defer close(next)
-			duration := timespan.duration()
-			for {
-				select {
-				case <-stop:
-					checkBuffer()
-					return
-				case <-ctx.Done():
-					return
-				case <-time.After(duration):
-					checkBuffer()
-				}
-			}
-		}()
-
-		for {
-			select {
-			case <-ctx.Done():
-				close(stop)
-				return
-			case item, ok := <-observe:
-				if !ok {
-					close(stop)
-					return
-				}
-				if item.Error() {
-					item.SendContext(ctx, next)
-					if option.getErrorStrategy() == StopOnError {
-						close(stop)
-						return
-					}
-				} else {
-					mutex.Lock()
-					buffer = append(buffer, item.V)
-					mutex.Unlock()
-				}
-			}
-		}
-	}
-
-	return customObservableOperator(f, opts...)
+	return o.bufferWithTime(timespan, 0, opts...)
 }
 
 // BufferWithTimeOrCount returns an Observable that emits buffers of items it collects from the source
-// Observable either from a given count or at a given time interval.
+// Observable either from a given count or at a given time interval, whichever comes first.
+// Each time a buffer is emitted, the time interval is restarted.
 func (o *ObservableImpl) BufferWithTimeOrCount(timespan Duration, count int, opts ...Option) Observable {
 	if timespan == nil {
 		return Thrown(IllegalInputError{error: "timespan must no be nil"})
@@ -521,70 +457,60 @@ func (o *ObservableImpl) BufferWithTimeOrCount(timespan Duration, count int, opt
 	if count <= 0 {
 		return Thrown(IllegalInputError{error: "count must be positive"})
 	}
+	return o.bufferWithTime(timespan, count, opts...)
+}
 
+// bufferWithTime emits the buffer each time the timespan elapses or, if count is positive, as soon as
+// the buffer contains count items.
+func (o *ObservableImpl) bufferWithTime(timespan Duration, count int, opts ...Option) Observable {
 	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
 		observe := o.Observe(opts...)
+		clock := option.getClock()
 		buffer := make([]interface{}, 0)
-		stop := make(chan struct{})
-		send := make(chan struct{})
-		mutex := sync.Mutex{}
+		timer := clock.NewTimer(timespan.duration())
+		defer func() {
+			timer.Stop()
+		}()
 
-		checkBuffer := func() {
-			mutex.Lock()
-			if len(buffer) != 0 {
-				if !Of(buffer).SendContext(ctx, next) {
-					mutex.Unlock()
-					return
-				}
-				buffer = make([]interface{}, 0)
+		flush := func() bool {
+			timer.Stop()
+			timer = clock.NewTimer(timespan.duration())
+			if len(buffer) == 0 {
+				return true
 			}
-			mutex.Unlock()
+			sent := Of(buffer).SendContext(ctx, next)
+			buffer = make([]interface{}, 0)
+			return sent
 		}
 
-		go func() {
-			defer close(next)
-			duration := timespan.duration()
-			for {
-				select {
-				case <-send:
-					checkBuffer()
-				case <-stop:
-					checkBuffer()
-					return
-				case <-ctx.Done():
-					return
-				case <-time.After(duration):
-					checkBuffer()
-				}
-			}
-		}()
-
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			case item, ok := <-observe:
 				if !ok {
-					close(stop)
-					close(send)
+					flush()
 					return
 				}
 				if item.Error() {
-					item.SendContext(ctx, next)
 					if option.getErrorStrategy() == StopOnError {
-						close(stop)
-						close(send)
+						if flush() {
+							item.SendContext(ctx, next)
+						}
 						return
 					}
-				} else {
-					mutex.Lock()
-					buffer = append(buffer, item.V)
-					if len(buffer) == count {
-						mutex.Unlock()
-						send <- struct{}{}
-					} else {
-						mutex.Unlock()
+					if !item.SendContext(ctx, next) {
+						return
 					}
+					continue
+				}
+				buffer = append(buffer, item.V)
+				if count > 0 && len(buffer) == count && !flush() {
+					return
+				}
+			case <-timer.C():
+				if !flush() {
+					return
 				}
 			}
 		}
@@ -593,6 +519,12 @@ func (o *ObservableImpl) BufferWithTimeOrCount(timespan Duration, count int, opt
 	return customObservableOperator(f, opts...)
 }
 
+// ConcatWith emits the items of the Observable, then the items of another Observable once the first one
+// has completed.
+func (o *ObservableImpl) ConcatWith(other Observable, opts ...Option) Observable {
+	return Concat([]Observable{o, other}, opts...)
+}
+
 // Connect instructs a connectable Observable to begin emitting items to its subscribers.
 func (o *ObservableImpl) Connect() Disposable {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -619,7 +551,7 @@ func (op *containsOperator) next(ctx context.Context, item Item, dst chan<- Item
 	if op.equal(item.V) {
 		Of(true).SendContext(ctx, dst)
 		op.contains = true
-		operatorOptions.stop()
+		operatorOptions.complete()
 	}
 }
 
@@ -636,51 +568,44 @@ func (op *containsOperator) end(ctx context.Context, dst chan<- Item) {
 func (op *containsOperator) gatherNext(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	if item.V == true {
 		Of(true).SendContext(ctx, dst)
-		operatorOptions.stop()
+		operatorOptions.complete()
 		op.contains = true
 	}
 }
 
 // Count counts the number of items emitted by the source Observable and emit only this value.
 func (o *ObservableImpl) Count(opts ...Option) Single {
-	return single(o, func() operator {
-		return &countOperator{}
-	}, true, false, opts...)
-}
-
-type countOperator struct {
-	count int64
-}
-
-func (op *countOperator) next(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
-	op.count++
-}
-
-func (op *countOperator) err(_ context.Context, _ Item, _ chan<- Item, operatorOptions operatorOptions) {
-	op.count++
-	operatorOptions.stop()
-}
-
-func (op *countOperator) end(ctx context.Context, dst chan<- Item) {
-	Of(op.count).SendContext(ctx, dst)
-}
-
-func (op *countOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+	return single(o, fold{
+		step: func(_ context.Context, acc interface{}, _ interface{}) (interface{}, error) {
+			return acc, nil
+		},
+		result: func(_ interface{}, count int64) (interface{}, bool) {
+			return count, true
+		},
+	}.operatorFactory(), true, false, opts...)
 }
 
 // Debounce only emits an item from an Observable if a particular timespan has passed without it emitting another item.
+// The pending item, if any, is emitted once the Observable completes.
 func (o *ObservableImpl) Debounce(timespan Duration, opts ...Option) Observable {
 	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
-		defer close(next)
 		observe := o.Observe(opts...)
+		clock := option.getClock()
 		var latest interface{}
+		pending := false
 
 		for {
+			timer := clock.NewTimer(timespan.duration())
 			select {
 			case <-ctx.Done():
+				timer.Stop()
 				return
 			case item, ok := <-observe:
+				timer.Stop()
 				if !ok {
+					if pending {
+						Of(latest).SendContext(ctx, next)
+					}
 					return
 				}
 				if item.Error() {
@@ -692,13 +617,15 @@ func (o *ObservableImpl) Debounce(timespan Duration, opts ...Option) Observable
 					}
 				} else {
 					latest = item.V
+					pending = true
 				}
-			case <-time.After(timespan.duration()):
-				if latest != nil {
+			case <-timer.C():
+				if pending {
 					if !Of(latest).SendContext(ctx, next) {
 						return
 					}
 					latest = nil
+					pending = false
 				}
 			}
 		}
@@ -741,6 +668,117 @@ func (op *defaultIfEmptyOperator) end(ctx context.Context, dst chan<- Item) {
 func (op *defaultIfEmptyOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
 }
 
+// Delay shifts the emissions of an Observable forward in time by the timespan, preserving their relative timing.
+// The errors are delayed as well.
+func (o *ObservableImpl) Delay(timespan Duration, opts ...Option) Observable {
+	type delayedItem struct {
+		item Item
+		due  time.Time
+	}
+
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		sourceCtx, cancelSource := context.WithCancel(ctx)
+		defer cancelSource()
+		observe := o.Observe(append(opts, WithContext(sourceCtx))...)
+		clock := option.getClock()
+		var queue []delayedItem
+
+		for observe != nil || len(queue) != 0 {
+			var timer ClockTimer
+			var fire <-chan time.Time
+			if len(queue) != 0 {
+				timer = clock.NewTimer(queue[0].due.Sub(clock.Now()))
+				fire = timer.C()
+			}
+
+			select {
+			case <-ctx.Done():
+				if timer != nil {
+					timer.Stop()
+				}
+				return
+			case item, ok := <-observe:
+				if timer != nil {
+					timer.Stop()
+				}
+				if !ok {
+					observe = nil
+					continue
+				}
+				queue = append(queue, delayedItem{item: item, due: clock.Now().Add(timespan.duration())})
+				if item.Error() && option.getErrorStrategy() == StopOnError {
+					observe = nil
+					cancelSource()
+				}
+			case <-fire:
+				item := queue[0].item
+				queue = queue[1:]
+				if !item.SendContext(ctx, next) {
+					return
+				}
+			}
+		}
+	}
+
+	return customObservableOperator(f, opts...)
+}
+
+// DelaySubscription postpones the subscription to the source Observable by the timespan.
+func (o *ObservableImpl) DelaySubscription(timespan Duration, opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		timer := option.getClock().NewTimer(timespan.duration())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C():
+		}
+		concatObserve(ctx, o, next, option, opts...)
+	}
+
+	return customObservableOperator(f, opts...)
+}
+
+// Dematerialize converts the Notification items emitted by Materialize back into the events they represent.
+// An ErrorNotification is emitted as an error, a CompletedNotification completes the Observable.
+// An item which is not a Notification is emitted as an IllegalInputError.
+// Cannot be run in parallel.
+func (o *ObservableImpl) Dematerialize(opts ...Option) Observable {
+	return observable(o, func() operator {
+		return &dematerializeOperator{}
+	}, true, false, opts...)
+}
+
+type dematerializeOperator struct{}
+
+func (op *dematerializeOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	notification, ok := item.V.(Notification)
+	if !ok {
+		Error(IllegalInputError{error: fmt.Sprintf("expected type: Notification, got: %T", item.V)}).SendContext(ctx, dst)
+		operatorOptions.stop()
+		return
+	}
+	switch notification.Kind {
+	case NextNotification:
+		Of(notification.Value).SendContext(ctx, dst)
+	case ErrorNotification:
+		Error(notification.Error).SendContext(ctx, dst)
+		operatorOptions.stop()
+	case CompletedNotification:
+		operatorOptions.complete()
+	}
+}
+
+func (op *dematerializeOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *dematerializeOperator) end(_ context.Context, _ chan<- Item) {
+}
+
+func (op *dematerializeOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
 // Distinct suppresses duplicate items in the original Observable and returns
 // a new Observable.
 func (o *ObservableImpl) Distinct(apply Func, opts ...Option) Observable {
@@ -800,32 +838,179 @@ func (o *ObservableImpl) DistinctUntilChanged(apply Func, opts ...Option) Observ
 	}, true, false, opts...)
 }
 
+// DistinctUntilChangedWithComparator suppresses consecutive items in the original Observable
+// considered equal by the comparator (returning 0).
+// Cannot be run in parallel.
+func (o *ObservableImpl) DistinctUntilChangedWithComparator(comparator Comparator, opts ...Option) Observable {
+	return observable(o, func() operator {
+		return &distinctUntilChangedOperator{
+			comparator: comparator,
+		}
+	}, true, false, opts...)
+}
+
 type distinctUntilChangedOperator struct {
-	apply   Func
-	current interface{}
+	apply      Func
+	comparator Comparator
+	current    interface{}
+	started    bool
 }
 
 func (op *distinctUntilChangedOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	key := item.V
+	if op.apply != nil {
+		var err error
+		key, err = op.apply(ctx, item.V)
+		if err != nil {
+			Error(err).SendContext(ctx, dst)
+			operatorOptions.stop()
+			return
+		}
+	}
+	if op.started && op.equal(op.current, key) {
+		return
+	}
+	item.SendContext(ctx, dst)
+	op.current = key
+	op.started = true
+}
+
+func (op *distinctUntilChangedOperator) equal(a, b interface{}) bool {
+	if op.comparator != nil {
+		return op.comparator(a, b) == 0
+	}
+	return a == b
+}
+
+func (op *distinctUntilChangedOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *distinctUntilChangedOperator) end(_ context.Context, _ chan<- Item) {
+}
+
+func (op *distinctUntilChangedOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
+// DistinctWithCapacity suppresses duplicate items in the original Observable, remembering at most
+// capacity keys. Once the capacity is reached, the least recently seen key is forgotten.
+// Cannot be run in parallel.
+func (o *ObservableImpl) DistinctWithCapacity(apply Func, capacity int, opts ...Option) Observable {
+	if capacity <= 0 {
+		return Thrown(IllegalInputError{error: "capacity must be strictly positive"})
+	}
+	return observable(o, func() operator {
+		return &distinctWithCapacityOperator{
+			apply:    apply,
+			capacity: capacity,
+			keys:     list.New(),
+			keyset:   make(map[interface{}]*list.Element),
+		}
+	}, true, false, opts...)
+}
+
+type distinctWithCapacityOperator struct {
+	apply    Func
+	capacity int
+	// keys is ordered from the most to the least recently seen key.
+	keys   *list.List
+	keyset map[interface{}]*list.Element
+}
+
+func (op *distinctWithCapacityOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	key, err := op.apply(ctx, item.V)
 	if err != nil {
 		Error(err).SendContext(ctx, dst)
 		operatorOptions.stop()
 		return
 	}
-	if op.current != key {
-		item.SendContext(ctx, dst)
-		op.current = key
+	if e, ok := op.keyset[key]; ok {
+		op.keys.MoveToFront(e)
+		return
+	}
+	item.SendContext(ctx, dst)
+	op.keyset[key] = op.keys.PushFront(key)
+	if op.keys.Len() > op.capacity {
+		oldest := op.keys.Back()
+		op.keys.Remove(oldest)
+		delete(op.keyset, oldest.Value)
 	}
 }
 
-func (op *distinctUntilChangedOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+func (op *distinctWithCapacityOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
 }
 
-func (op *distinctUntilChangedOperator) end(_ context.Context, _ chan<- Item) {
+func (op *distinctWithCapacityOperator) end(_ context.Context, _ chan<- Item) {
 }
 
-func (op *distinctUntilChangedOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+func (op *distinctWithCapacityOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
+// DoFinally returns an Observable mirroring the source and calling finallyFunc once the source
+// completes, fails or is disposed by the observer. finallyFunc is called after the observer channel is closed.
+func (o *ObservableImpl) DoFinally(finallyFunc func(), opts ...Option) Observable {
+	return o.doOn(lifecycleHooks{finally: finallyFunc}, opts...)
+}
+
+// lifecycleHooks gathers the side effects of the non-terminal Do operators and Tap.
+// A nil hook is ignored.
+type lifecycleHooks struct {
+	next      NextFunc
+	err       ErrFunc
+	completed CompletedFunc
+	subscribe func()
+	dispose   func()
+	finally   func()
+}
+
+func (o *ObservableImpl) doOn(hooks lifecycleHooks, opts ...Option) Observable {
+	call := func(f func()) {
+		if f != nil {
+			f()
+		}
+	}
+
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		defer call(hooks.finally)
+		call(hooks.subscribe)
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		observe := o.Observe(append(opts, WithContext(ctx))...)
+
+		for {
+			select {
+			case <-ctx.Done():
+				call(hooks.dispose)
+				return
+			case item, ok := <-observe:
+				if !ok {
+					if ctx.Err() != nil {
+						call(hooks.dispose)
+					} else {
+						call(hooks.completed)
+					}
+					return
+				}
+				if item.Error() {
+					if hooks.err != nil {
+						hooks.err(item.E)
+					}
+				} else if hooks.next != nil {
+					hooks.next(item.V)
+				}
+				if !item.SendContext(ctx, next) {
+					call(hooks.dispose)
+					return
+				}
+				if item.Error() && option.getErrorStrategy() == StopOnError {
+					return
+				}
+			}
+		}
+	}
+
+	return customObservableOperator(f, opts...)
 }
 
 // DoOnCompleted registers a callback action that will be called once the Observable terminates.
@@ -855,6 +1040,12 @@ func (o *ObservableImpl) DoOnCompleted(completedFunc CompletedFunc, opts ...Opti
 	return dispose
 }
 
+// DoOnDispose returns an Observable mirroring the source and calling disposeFunc if the observer
+// disposes the subscription (by cancelling its context) before the source terminates.
+func (o *ObservableImpl) DoOnDispose(disposeFunc func(), opts ...Option) Observable {
+	return o.doOn(lifecycleHooks{dispose: disposeFunc}, opts...)
+}
+
 // DoOnError registers a callback action that will be called if the Observable terminates abnormally.
 func (o *ObservableImpl) DoOnError(errFunc ErrFunc, opts ...Option) Disposed {
 	dispose := make(chan struct{})
@@ -909,7 +1100,14 @@ func (o *ObservableImpl) DoOnNext(nextFunc NextFunc, opts ...Option) Disposed {
 	return dispose
 }
 
+// DoOnSubscribe returns an Observable mirroring the source and calling subscribeFunc each time
+// it is observed, before the source itself is observed.
+func (o *ObservableImpl) DoOnSubscribe(subscribeFunc func(), opts ...Option) Observable {
+	return o.doOn(lifecycleHooks{subscribe: subscribeFunc}, opts...)
+}
+
 // ElementAt emits only item n emitted by an Observable.
+// If the Observable completes before emitting item n, an IndexOutOfBoundError is emitted.
 // Cannot be run in parallel.
 func (o *ObservableImpl) ElementAt(index uint, opts ...Option) Single {
 	return single(o, func() operator {
@@ -929,7 +1127,7 @@ func (op *elementAtOperator) next(ctx context.Context, item Item, dst chan<- Ite
 	if op.takeCount == int(op.index) {
 		item.SendContext(ctx, dst)
 		op.sent = true
-		operatorOptions.stop()
+		operatorOptions.complete()
 		return
 	}
 	op.takeCount++
@@ -941,7 +1139,7 @@ func (op *elementAtOperator) err(ctx context.Context, item Item, dst chan<- Item
 
 func (op *elementAtOperator) end(ctx context.Context, dst chan<- Item) {
 	if !op.sent {
-		Error(&IllegalInputError{}).SendContext(ctx, dst)
+		Error(IndexOutOfBoundError{error: fmt.Sprintf("index %d, length %d", op.index, op.takeCount)}).SendContext(ctx, dst)
 	}
 }
 
@@ -993,6 +1191,169 @@ func (o *ObservableImpl) Errors(opts ...Option) []error {
 	}
 }
 
+// Expand emits the items of the source Observable and recursively expands each emitted item into
+// an Observable using apply: the items of these Observables are emitted and expanded in turn.
+// At most maxConcurrency expanded Observables are observed at the same time, the others being
+// queued; if maxConcurrency is not positive, the concurrency is unbounded.
+// The resulting Observable completes once the source and all the expanded Observables are complete.
+// With WithScheduler, each expanded Observable is observed from a task run by the given Scheduler instead of a
+// new goroutine, maxConcurrency being ignored: with a TrampolineScheduler, the expansion runs breadth-first on a
+// single goroutine, whatever its depth.
+func (o *ObservableImpl) Expand(apply ItemToObservable, maxConcurrency int, opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		if scheduler := option.getScheduler(); scheduler != nil {
+			o.expandScheduled(ctx, next, apply, scheduler, option, opts...)
+			return
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		results := option.buildChannel()
+		done := make(chan struct{})
+		queue := make([]Item, 0)
+		active := 0
+
+		subscribe := func(item Item) {
+			active++
+			go func() {
+				defer func() {
+					select {
+					case <-ctx.Done():
+					case done <- struct{}{}:
+					}
+				}()
+				observe := apply(item).Observe(opts...)
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case item, ok := <-observe:
+						if !ok {
+							return
+						}
+						if !item.SendContext(ctx, results) {
+							return
+						}
+					}
+				}
+			}()
+		}
+
+		// emit sends the item downstream and schedules its expansion, it returns false if the
+		// resulting Observable has to stop.
+		emit := func(item Item) bool {
+			if item.Error() {
+				item.SendContext(ctx, next)
+				return option.getErrorStrategy() != StopOnError
+			}
+			if !item.SendContext(ctx, next) {
+				return false
+			}
+			if maxConcurrency > 0 && active >= maxConcurrency {
+				queue = append(queue, item)
+			} else {
+				subscribe(item)
+			}
+			return true
+		}
+
+		observe := o.Observe(opts...)
+		for observe != nil || active > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-observe:
+				if !ok {
+					observe = nil
+					continue
+				}
+				if !emit(item) {
+					return
+				}
+			case item := <-results:
+				if !emit(item) {
+					return
+				}
+			case <-done:
+				active--
+				if len(queue) > 0 {
+					item := queue[0]
+					queue[0] = Item{}
+					queue = queue[1:]
+					subscribe(item)
+				}
+			}
+		}
+	}
+
+	return customObservableOperator(f, opts...)
+}
+
+// expandScheduled implements Expand with a Scheduler: expanding an item schedules a task observing its
+// Observable, the items of which are emitted and expanded in turn from this task.
+func (o *ObservableImpl) expandScheduled(ctx context.Context, next chan Item, apply ItemToObservable,
+	scheduler Scheduler, option Option, opts ...Option) {
+	ctx, cancel := context.WithCancel(ctx)
+	wg := sync.WaitGroup{}
+	// The resulting Observable completes once the tasks are run, these returning early if ctx is cancelled
+	defer func() {
+		wg.Wait()
+		cancel()
+	}()
+
+	var emit func(item Item)
+	expand := func(item Item) {
+		defer wg.Done()
+		if ctx.Err() != nil {
+			return
+		}
+		observe := apply(item).Observe(opts...)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+				emit(item)
+			}
+		}
+	}
+	emit = func(item Item) {
+		if item.Error() {
+			item.SendContext(ctx, next)
+			if option.getErrorStrategy() == StopOnError {
+				cancel()
+			}
+			return
+		}
+		if !item.SendContext(ctx, next) {
+			return
+		}
+		wg.Add(1)
+		if !scheduler.Schedule(func() {
+			expand(item)
+		}) {
+			wg.Done()
+			Error(errRejectedTask).SendContext(ctx, next)
+			cancel()
+		}
+	}
+
+	observe := o.Observe(opts...)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case item, ok := <-observe:
+			if !ok {
+				return
+			}
+			emit(item)
+		}
+	}
+}
+
 // Filter emits only those items from an Observable that pass a predicate test.
 func (o *ObservableImpl) Filter(apply Predicate, opts ...Option) Observable {
 	return observable(o, func() operator {
@@ -1032,7 +1393,7 @@ type firstOperator struct{}
 
 func (op *firstOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	item.SendContext(ctx, dst)
-	operatorOptions.stop()
+	operatorOptions.complete()
 }
 
 func (op *firstOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
@@ -1064,7 +1425,7 @@ type firstOrDefaultOperator struct {
 func (op *firstOrDefaultOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	item.SendContext(ctx, dst)
 	op.sent = true
-	operatorOptions.stop()
+	operatorOptions.complete()
 }
 
 func (op *firstOrDefaultOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
@@ -1081,10 +1442,16 @@ func (op *firstOrDefaultOperator) gatherNext(_ context.Context, _ Item, _ chan<-
 }
 
 // FlatMap transforms the items emitted by an Observable into Observables, then flatten the emissions from those into a single Observable.
+// If a pool is specified, it limits the number of inner Observables observed concurrently.
 func (o *ObservableImpl) FlatMap(apply ItemToObservable, opts ...Option) Observable {
+	if parallel, pool := parseOptions(opts...).getPool(); parallel {
+		return o.flatMapParallel(apply, pool, opts...)
+	}
+
 	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
-		defer close(next)
 		observe := o.Observe(opts...)
+		// The observables of the items in flight are not cut once the subscription is drained
+		innerOpts := append(opts[:len(opts):len(opts)], withDrain(nil))
 		for {
 			select {
 			case <-ctx.Done():
@@ -1093,7 +1460,7 @@ func (o *ObservableImpl) FlatMap(apply ItemToObservable, opts ...Option) Observa
 				if !ok {
 					return
 				}
-				observe2 := apply(item).Observe(opts...)
+				observe2 := apply(item).Observe(innerOpts...)
 			loop2:
 				for {
 					select {
@@ -1122,31 +1489,100 @@ func (o *ObservableImpl) FlatMap(apply ItemToObservable, opts ...Option) Observa
 	return customObservableOperator(f, opts...)
 }
 
+func (o *ObservableImpl) flatMapParallel(apply ItemToObservable, pool int, opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		tokens := make(chan struct{}, pool)
+		wg := sync.WaitGroup{}
+
+		inner := func(observe <-chan Item) {
+			defer wg.Done()
+			defer func() {
+				<-tokens
+			}()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-observe:
+					if !ok {
+						return
+					}
+					if item.Error() {
+						item.SendContext(ctx, next)
+						if option.getErrorStrategy() == StopOnError {
+							cancel()
+							return
+						}
+					} else if !item.SendContext(ctx, next) {
+						return
+					}
+				}
+			}
+		}
+
+		observe := o.Observe(opts...)
+		// The observables of the items in flight are not cut once the subscription is drained
+		innerOpts := append(opts[:len(opts):len(opts)], withDrain(nil))
+	loop:
+		for {
+			select {
+			case <-ctx.Done():
+				break loop
+			case item, ok := <-observe:
+				if !ok {
+					break loop
+				}
+				select {
+				case <-ctx.Done():
+					break loop
+				case tokens <- struct{}{}:
+				}
+				wg.Add(1)
+				go inner(apply(item).Observe(innerOpts...))
+			}
+		}
+		wg.Wait()
+	}
+
+	return customObservableOperator(f, opts...)
+}
+
 // ForEach subscribes to the Observable and receives notifications for each element.
 func (o *ObservableImpl) ForEach(nextFunc NextFunc, errFunc ErrFunc, completedFunc CompletedFunc, opts ...Option) Disposed {
 	dispose := make(chan struct{})
+	option := parseOptions(opts...)
+	observer := interceptObserver(funcsObserver{
+		nextFunc:      nextFunc,
+		errFunc:       errFunc,
+		completedFunc: completedFunc,
+	}, option)
 	handler := func(ctx context.Context, src <-chan Item) {
 		defer close(dispose)
 		for {
 			select {
 			case <-ctx.Done():
-				completedFunc()
+				observer.OnCompleted()
 				return
 			case i, ok := <-src:
 				if !ok {
-					completedFunc()
+					observer.OnCompleted()
 					return
 				}
 				if i.Error() {
-					errFunc(i.E)
+					observer.OnError(i.E)
 					break
 				}
-				nextFunc(i.V)
+				if err := call(option, func() { observer.OnNext(i.V) }); err != nil {
+					observer.OnError(err)
+					break
+				}
+				recycle(option.getValuePool(), i.V)
 			}
 		}
 	}
 
-	option := parseOptions(opts...)
 	ctx := option.buildContext()
 	go handler(ctx, o.Observe(opts...))
 	return dispose
@@ -1186,12 +1622,11 @@ func abs(n int64) int64 {
 // The time is extracted using a timeExtractor function.
 func (o *ObservableImpl) Join(joiner Func2, right Observable, timeExtractor func(interface{}) time.Time, window Duration, opts ...Option) Observable {
 	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
-		defer close(next)
 		windowDuration := int64(window.duration())
 		rBuf := make([]Item, 0)
 
-		lObserve := o.Observe()
-		rObserve := right.Observe()
+		lObserve := o.Observe(opts...)
+		rObserve := right.Observe(opts...)
 	lLoop:
 		for {
 			select {
@@ -1320,6 +1755,154 @@ func (o *ObservableImpl) GroupBy(length int, distribution func(Item) int, opts .
 	}
 }
 
+// GroupByDynamic divides an Observable into a dynamic set of GroupedObservables, each one emitting the items sharing
+// the same key computed by keySelector. A GroupedObservable is emitted each time a new key is encountered.
+// The keys must be comparable. Each GroupedObservable has to be observed, otherwise the source is blocked.
+func (o *ObservableImpl) GroupByDynamic(keySelector Func, opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		observe := o.Observe(opts...)
+		groups := make(map[interface{}]chan Item)
+		defer func() {
+			for _, ch := range groups {
+				close(ch)
+			}
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+				if item.Error() {
+					if !item.SendContext(ctx, next) || option.getErrorStrategy() == StopOnError {
+						return
+					}
+					continue
+				}
+				key, err := keySelector(ctx, item.V)
+				if err != nil {
+					if !Error(err).SendContext(ctx, next) || option.getErrorStrategy() == StopOnError {
+						return
+					}
+					continue
+				}
+				ch, exists := groups[key]
+				if !exists {
+					ch = option.buildChannel()
+					groups[key] = ch
+					group := &GroupedObservableImpl{
+						ObservableImpl: &ObservableImpl{iterable: newChannelIterable(ch)},
+						key:            key,
+					}
+					if !Of(group).SendContext(ctx, next) {
+						return
+					}
+				}
+				if !item.SendContext(ctx, ch) {
+					return
+				}
+			}
+		}
+	}
+
+	return customObservableOperator(f, opts...)
+}
+
+// Lift returns an Observable applying a custom operator: for each subscription, the operator is given the
+// downstream Observer and returns the Observer fed by the source.
+// The downstream Observer can be called from any goroutine. Its OnNext blocks until the item is consumed, and the
+// source is disposed as soon as its OnError or OnCompleted is called. The Observable terminates only once the
+// downstream Observer is terminated, even if the source completed.
+// A panic of the Observer returned by the operator is emitted as a PanicError, see WithPanicStrategy.
+func (o *ObservableImpl) Lift(operator ObserverOperator, opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		downstream := &liftObserver{ctx: ctx, next: next, done: make(chan struct{})}
+		// Once f returns, the downstream Observer must not send to next anymore
+		defer downstream.terminate()
+
+		upstream := operator(ctx, downstream)
+		observe := o.Observe(opts...)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-downstream.done:
+				return
+			case item, ok := <-observe:
+				var err error
+				switch {
+				case !ok:
+					err = call(option, upstream.OnCompleted)
+				case item.Error():
+					err = call(option, func() { upstream.OnError(item.E) })
+				default:
+					err = call(option, func() { upstream.OnNext(item.V) })
+				}
+				if err != nil {
+					downstream.OnError(err)
+					return
+				}
+				if !ok || item.Error() {
+					// Wait for the operator to terminate the downstream Observer
+					select {
+					case <-ctx.Done():
+					case <-downstream.done:
+					}
+					return
+				}
+			}
+		}
+	}
+
+	return customObservableOperator(f, opts...)
+}
+
+// liftObserver is the downstream Observer of Lift, forwarding to the Observable channel.
+type liftObserver struct {
+	ctx        context.Context
+	next       chan<- Item
+	mutex      sync.Mutex
+	terminated bool
+	done       chan struct{}
+}
+
+func (l *liftObserver) OnNext(i interface{}) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.terminated {
+		return
+	}
+	Of(i).SendContext(l.ctx, l.next)
+}
+
+func (l *liftObserver) OnError(err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.terminated {
+		return
+	}
+	Error(err).SendContext(l.ctx, l.next)
+	l.terminated = true
+	close(l.done)
+}
+
+func (l *liftObserver) OnCompleted() {
+	l.terminate()
+}
+
+func (l *liftObserver) terminate() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.terminated {
+		return
+	}
+	l.terminated = true
+	close(l.done)
+}
+
 // Last returns a new Observable which emit only last item.
 // Cannot be run in parallel.
 func (o *ObservableImpl) Last(opts ...Option) OptionalSingle {
@@ -1392,12 +1975,95 @@ func (op *lastOrDefaultOperator) gatherNext(_ context.Context, _ Item, _ chan<-
 }
 
 // Map transforms the items emitted by an Observable by applying a function to each item.
+// When run in parallel with WithPreservedOrder, the items are emitted in the order of the source items.
 func (o *ObservableImpl) Map(apply Func, opts ...Option) Observable {
+	option := parseOptions(opts...)
+	if parallel, pool := option.getPool(); parallel && option.isOrderPreserved() {
+		return o.orderedParallelMap(apply, pool, opts...)
+	}
 	return observable(o, func() operator {
 		return &mapOperator{apply: apply}
 	}, false, true, opts...)
 }
 
+// orderedParallelMap applies the function on a pool of goroutines. Each source item is given a result slot,
+// queued in the order of the source; the slots are then drained in this very order.
+// The number of slots in flight is bounded by the pool size, which bounds the reordering memory.
+func (o *ObservableImpl) orderedParallelMap(apply Func, pool int, opts ...Option) Observable {
+	type job struct {
+		item   Item
+		result chan Item
+	}
+
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		observe := o.Observe(append(opts, WithContext(ctx))...)
+		jobs := make(chan job, pool)
+		slots := make(chan chan Item, pool)
+
+		go func() {
+			defer close(jobs)
+			defer close(slots)
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-observe:
+					if !ok {
+						return
+					}
+					j := job{item: item, result: make(chan Item, 1)}
+					select {
+					case <-ctx.Done():
+						return
+					case slots <- j.result:
+					}
+					select {
+					case <-ctx.Done():
+						return
+					case jobs <- j:
+					}
+				}
+			}
+		}()
+
+		for i := 0; i < pool; i++ {
+			go func() {
+				for j := range jobs {
+					if j.item.Error() {
+						j.result <- j.item
+						continue
+					}
+					res, err := apply(ctx, j.item.V)
+					if err != nil {
+						j.result <- Error(err)
+					} else {
+						j.result <- Of(res)
+					}
+				}
+			}()
+		}
+
+		for slot := range slots {
+			var item Item
+			select {
+			case <-ctx.Done():
+				return
+			case item = <-slot:
+			}
+			if !item.SendContext(ctx, next) {
+				return
+			}
+			if item.Error() && option.getErrorStrategy() == StopOnError {
+				return
+			}
+		}
+	}
+
+	return customObservableOperator(f, opts...)
+}
+
 type mapOperator struct {
 	apply Func
 }
@@ -1434,93 +2100,169 @@ func (o *ObservableImpl) Marshal(marshaller Marshaller, opts ...Option) Observab
 	}, opts...)
 }
 
-// Max determines and emits the maximum-valued item emitted by an Observable according to a comparator.
-func (o *ObservableImpl) Max(comparator Comparator, opts ...Option) OptionalSingle {
-	return optionalSingle(o, func() operator {
-		return &maxOperator{
-			comparator: comparator,
-			empty:      true,
-		}
-	}, false, false, opts...)
-}
-
-type maxOperator struct {
-	comparator Comparator
-	empty      bool
-	max        interface{}
-}
-
-func (op *maxOperator) next(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
-	op.empty = false
+// Materialize converts the events of an Observable into Notification items: each item is emitted as a
+// NextNotification, each error as an ErrorNotification and the completion as a CompletedNotification.
+// With the StopOnError strategy, the Observable completes after an ErrorNotification, without CompletedNotification.
+func (o *ObservableImpl) Materialize(opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		observe := o.Observe(append(opts, WithContext(ctx))...)
 
-	if op.max == nil {
-		op.max = item.V
-	} else {
-		if op.comparator(op.max, item.V) < 0 {
-			op.max = item.V
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-observe:
+				if !ok {
+					if ctx.Err() == nil {
+						Of(Notification{Kind: CompletedNotification}).SendContext(ctx, next)
+					}
+					return
+				}
+				notification := Notification{Kind: NextNotification, Value: item.V}
+				if item.Error() {
+					notification = Notification{Kind: ErrorNotification, Error: item.E}
+				}
+				if !Of(notification).SendContext(ctx, next) {
+					return
+				}
+				if item.Error() && option.getErrorStrategy() == StopOnError {
+					return
+				}
+			}
 		}
 	}
-}
 
-func (op *maxOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+	return customObservableOperator(f, opts...)
 }
 
-func (op *maxOperator) end(ctx context.Context, dst chan<- Item) {
-	if !op.empty {
-		Of(op.max).SendContext(ctx, dst)
-	}
+// Max determines and emits the maximum-valued item emitted by an Observable according to a comparator.
+func (o *ObservableImpl) Max(comparator Comparator, opts ...Option) OptionalSingle {
+	return optionalSingle(o, fold{
+		step: func(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
+			if acc == nil || comparator(acc, elem) < 0 {
+				return elem, nil
+			}
+			return acc, nil
+		},
+		result: nonEmptyResult,
+	}.operatorFactory(), false, false, opts...)
 }
 
-func (op *maxOperator) gatherNext(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	op.next(ctx, Of(item.V.(*maxOperator).max), dst, operatorOptions)
+// MergeWith combines the Observable and another one into one by merging their emissions.
+func (o *ObservableImpl) MergeWith(other Observable, opts ...Option) Observable {
+	return Merge([]Observable{o, other}, opts...)
 }
 
 // Min determines and emits the minimum-valued item emitted by an Observable according to a comparator.
 func (o *ObservableImpl) Min(comparator Comparator, opts ...Option) OptionalSingle {
-	return optionalSingle(o, func() operator {
-		return &minOperator{
-			comparator: comparator,
-			empty:      true,
-		}
-	}, false, false, opts...)
-}
-
-type minOperator struct {
-	comparator Comparator
-	empty      bool
-	max        interface{}
+	return optionalSingle(o, fold{
+		step: func(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
+			if acc == nil || comparator(acc, elem) > 0 {
+				return elem, nil
+			}
+			return acc, nil
+		},
+		result: nonEmptyResult,
+	}.operatorFactory(), false, false, opts...)
 }
 
-func (op *minOperator) next(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
-	op.empty = false
-
-	if op.max == nil {
-		op.max = item.V
-	} else {
-		if op.comparator(op.max, item.V) > 0 {
-			op.max = item.V
+// Observe observes an Observable by returning its channel.
+func (o *ObservableImpl) Observe(opts ...Option) <-chan Item {
+	if !o.drainable {
+		if drain := parseOptions(opts...).getDrain(); drain != nil {
+			return observeUntilDrained(o.iterable, drain, opts...)
 		}
 	}
+	return o.iterable.Observe(opts...)
 }
 
-func (op *minOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
-}
+// ObserveOn returns an Observable mirroring the source, and whose items are handed off to the observer from tasks
+// run by the given Scheduler. The items are held by a mailbox, drained by a single task at a time so the order is
+// kept. Its capacity is set with WithMailbox, a single item by default, and the strategy applied once it is full
+// with WithBackPressureStrategy. A task hands off at most a mailboxful of items before yielding the scheduler to
+// the other tasks. If a task is rejected, e.g. once the scheduler is stopped, a RejectedTaskError is emitted.
+func (o *ObservableImpl) ObserveOn(scheduler Scheduler, opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		ctx, cancel := context.WithCancel(ctx)
+		mb := newMailbox(parseMailboxCapacity(opts...), option.getBackPressureStrategy())
+		var mutex sync.Mutex
+		closed := false
+		defer func() {
+			// A task may still be pending: it must not send once next is closed.
+			cancel()
+			mutex.Lock()
+			closed = true
+			mutex.Unlock()
+		}()
+		// The source is unsubscribed as soon as no more item is handed off, e.g. once the mailbox overflows
+		sourceCtx, cancelSource := context.WithCancel(ctx)
+		observe := o.Observe(append(opts, WithContext(sourceCtx))...)
+
+		// reject emits a RejectedTaskError and stops the operator, the mutex being locked.
+		reject := func() {
+			if !closed {
+				Error(errRejectedTask).SendContext(ctx, next)
+			}
+			cancel()
+		}
+		// handOff hands off at most a mailboxful of items. It returns true if the mailbox has to be drained further.
+		handOff := func() bool {
+			mutex.Lock()
+			defer mutex.Unlock()
+			for n := 0; n < mb.capacity; n++ {
+				item, ok := mb.take()
+				if !ok {
+					return false
+				}
+				if closed || !item.SendContext(ctx, next) {
+					return false
+				}
+			}
+			return true
+		}
+		var drain func()
+		drain = func() {
+			// The remaining items are handed off by another task, yielding the scheduler meanwhile
+			if handOff() && !scheduler.Schedule(drain) {
+				mutex.Lock()
+				reject()
+				mutex.Unlock()
+			}
+		}
 
-func (op *minOperator) end(ctx context.Context, dst chan<- Item) {
-	if !op.empty {
-		Of(op.max).SendContext(ctx, dst)
+		func() {
+			defer cancelSource()
+			defer mb.close()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-observe:
+					if !ok {
+						return
+					}
+					schedule, accepted := mb.push(ctx, item)
+					if schedule && !scheduler.Schedule(drain) {
+						mutex.Lock()
+						reject()
+						mutex.Unlock()
+						return
+					}
+					if !accepted || (item.Error() && option.getErrorStrategy() == StopOnError) {
+						return
+					}
+				}
+			}
+		}()
+		select {
+		case <-ctx.Done():
+		case <-mb.drained:
+		}
 	}
-}
-
-func (op *minOperator) gatherNext(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	op.next(ctx, Of(item.V.(*minOperator).max), dst, operatorOptions)
-}
 
-// Observe observes an Observable by returning its channel.
-func (o *ObservableImpl) Observe(opts ...Option) <-chan Item {
-	return o.iterable.Observe(opts...)
+	return customObservableOperator(f, opts...)
 }
 
 // OnErrorResumeNext instructs an Observable to pass control to another Observable rather than invoking
@@ -1600,146 +2342,268 @@ func (op *onErrorReturnItemOperator) end(_ context.Context, _ chan<- Item) {
 func (op *onErrorReturnItemOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
 }
 
-// Reduce applies a function to each item emitted by an Observable, sequentially, and emit the final value.
-func (o *ObservableImpl) Reduce(apply Func2, opts ...Option) OptionalSingle {
-	return optionalSingle(o, func() operator {
-		return &reduceOperator{
-			apply: apply,
-			empty: true,
-		}
-	}, false, false, opts...)
+// Pairwise emits each item of the source Observable together with the previous one, as a PairItem.
+// Nothing is emitted for the first item.
+// Cannot be run in parallel.
+func (o *ObservableImpl) Pairwise(opts ...Option) Observable {
+	return observable(o, func() operator {
+		return &pairwiseOperator{}
+	}, true, false, opts...)
 }
 
-type reduceOperator struct {
-	apply Func2
-	acc   interface{}
-	empty bool
+type pairwiseOperator struct {
+	previous    interface{}
+	hasPrevious bool
 }
 
-func (op *reduceOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	op.empty = false
-	v, err := op.apply(ctx, op.acc, item.V)
-	if err != nil {
-		Error(err).SendContext(ctx, dst)
-		operatorOptions.stop()
-		op.empty = true
-		return
+func (op *pairwiseOperator) next(ctx context.Context, item Item, dst chan<- Item, _ operatorOptions) {
+	if op.hasPrevious {
+		Of(PairItem{Previous: op.previous, Current: item.V}).SendContext(ctx, dst)
 	}
-	op.acc = v
+	op.previous = item.V
+	op.hasPrevious = true
 }
 
-func (op *reduceOperator) err(_ context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	dst <- item
-	operatorOptions.stop()
-	op.empty = true
+func (op *pairwiseOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
 }
 
-func (op *reduceOperator) end(ctx context.Context, dst chan<- Item) {
-	if !op.empty {
-		Of(op.acc).SendContext(ctx, dst)
-	}
+func (op *pairwiseOperator) end(_ context.Context, _ chan<- Item) {
 }
 
-func (op *reduceOperator) gatherNext(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	op.next(ctx, Of(item.V.(*reduceOperator).acc), dst, operatorOptions)
+func (op *pairwiseOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
 }
 
-// Repeat returns an Observable that repeats the sequence of items emitted by the source Observable
-// at most count times, at a particular frequency.
-// Cannot run in parallel.
-func (o *ObservableImpl) Repeat(count int64, frequency Duration, opts ...Option) Observable {
-	if count != Infinite {
-		if count < 0 {
-			return Thrown(IllegalInputError{error: "count must be positive"})
-		}
+// Pipe applies the operators to the Observable, from left to right.
+// Pipe() with no operator returns the Observable itself.
+func (o *ObservableImpl) Pipe(operators ...OperatorFunc) Observable {
+	var observable Observable = o
+	for _, operator := range operators {
+		observable = operator(observable)
 	}
-
-	return observable(o, func() operator {
-		return &repeatOperator{
-			count:     count,
-			frequency: frequency,
-			seq:       make([]Item, 0),
-		}
-	}, true, false, opts...)
+	return observable
 }
 
-type repeatOperator struct {
-	count     int64
-	frequency Duration
-	seq       []Item
+// Publish returns a ConnectableObservable sharing a single subscription to the source Observable
+// among all its observers. The source is observed once Connect is called.
+func (o *ObservableImpl) Publish(opts ...Option) ConnectableObservable {
+	publish := newPublishIterable(o, opts...)
+	return &ConnectableObservableImpl{
+		ObservableImpl: &ObservableImpl{iterable: publish},
+		publish:        publish,
+	}
 }
 
-func (op *repeatOperator) next(ctx context.Context, item Item, dst chan<- Item, _ operatorOptions) {
-	item.SendContext(ctx, dst)
-	op.seq = append(op.seq, item)
+// Reduce applies a function to each item emitted by an Observable, sequentially, and emit the final value.
+func (o *ObservableImpl) Reduce(apply Func2, opts ...Option) OptionalSingle {
+	return optionalSingle(o, fold{
+		step:   apply,
+		result: nonEmptyResult,
+	}.operatorFactory(), false, false, opts...)
 }
 
-func (op *repeatOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
-}
+// Repeat resubscribes to the source Observable count times once it completes, waiting for frequency
+// before each resubscription if it is not nil. The source is repeated indefinitely if count is negative.
+// An error stops the repetition.
+func (o *ObservableImpl) Repeat(count int64, frequency Duration, opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		clock := option.getClock()
+		remaining := count
+		for {
+			observe := o.Observe(opts...)
+		loop:
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-observe:
+					if !ok {
+						break loop
+					}
+					if item.Error() {
+						item.SendContext(ctx, next)
+						if option.getErrorStrategy() == StopOnError {
+							return
+						}
+					} else if !item.SendContext(ctx, next) {
+						return
+					}
+				}
+			}
 
-func (op *repeatOperator) end(ctx context.Context, dst chan<- Item) {
-	for {
-		select {
-		default:
-			break
-		case <-ctx.Done():
-			return
-		}
-		if op.count != Infinite {
-			if op.count == 0 {
-				break
+			if remaining == 0 {
+				return
+			}
+			if remaining > 0 {
+				remaining--
+			}
+			if frequency != nil {
+				timer := clock.NewTimer(frequency.duration())
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C():
+				}
 			}
 		}
-		if op.frequency != nil {
-			time.Sleep(op.frequency.duration())
-		}
-		for _, v := range op.seq {
-			v.SendContext(ctx, dst)
-		}
-		op.count = op.count - 1
 	}
+
+	return customObservableOperator(f, opts...)
 }
 
-func (op *repeatOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+// RepeatWhen resubscribes to the source Observable each time the Observable returned by handler emits an item.
+// handler receives an Observable emitting the number of times the source Observable has completed so far.
+// If the returned Observable completes, the resulting Observable completes; if it emits an error, this error is
+// propagated. A delay between the repetitions can be introduced by delaying the notifier items.
+// An error from the source Observable stops the repetition.
+func (o *ObservableImpl) RepeatWhen(handler func(completions Observable) Observable, opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		// Buffered as handler may return the completions Observable itself
+		completions := make(chan Item, 1)
+		defer close(completions)
+		opts = append(opts, WithContext(ctx))
+		notifier := handler(FromChannel(completions)).Observe(opts...)
+
+		for iteration := 1; ; iteration++ {
+			observe := o.Observe(opts...)
+		loop:
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-observe:
+					if !ok {
+						break loop
+					}
+					if item.Error() {
+						item.SendContext(ctx, next)
+						return
+					}
+					if !item.SendContext(ctx, next) {
+						return
+					}
+				}
+			}
+
+			// The completion is forwarded to the handler while waiting for the repeat signal
+			pending := completions
+		wait:
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case pending <- Of(iteration):
+					pending = nil
+				case item, ok := <-notifier:
+					if !ok {
+						return
+					}
+					if item.Error() {
+						item.SendContext(ctx, next)
+						return
+					}
+					break wait
+				}
+			}
+		}
+	}
+
+	return customObservableOperator(f, opts...)
 }
 
 // Retry retries if a source Observable sends an error, resubscribe to it in the hopes that it will complete without error.
 // Cannot be run in parallel.
 func (o *ObservableImpl) Retry(count int, shouldRetry func(error) bool, opts ...Option) Observable {
-	option := parseOptions(opts...)
-	next := option.buildChannel()
-	ctx := option.buildContext()
-
-	go func() {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		remaining := count
 		observe := o.Observe(opts...)
-	loop:
 		for {
 			select {
 			case <-ctx.Done():
-				break loop
+				return
 			case i, ok := <-observe:
 				if !ok {
-					break loop
+					return
 				}
 				if i.Error() {
-					count--
-					if count < 0 || !shouldRetry(i.E) {
+					remaining--
+					if remaining < 0 || !shouldRetry(i.E) {
 						i.SendContext(ctx, next)
-						break loop
+						return
 					}
 					observe = o.Observe(opts...)
-				} else {
-					i.SendContext(ctx, next)
+				} else if !i.SendContext(ctx, next) {
+					return
 				}
 			}
 		}
-		close(next)
-	}()
+	}
 
-	return &ObservableImpl{
-		iterable: newChannelIterable(next),
+	return customObservableOperator(f, opts...)
+}
+
+// RetryWhen resubscribes to the source Observable each time the Observable returned by handler emits an item.
+// handler receives an Observable emitting the errors sent by the source Observable. If the returned Observable
+// completes, the resulting Observable completes; if it emits an error, this error is propagated.
+func (o *ObservableImpl) RetryWhen(handler func(errors Observable) Observable, opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		// Buffered as handler may return the errors Observable itself
+		errs := make(chan Item, 1)
+		defer close(errs)
+		opts = append(opts, WithContext(ctx))
+		notifier := handler(FromChannel(errs)).Observe(opts...)
+
+		for {
+			var err error
+			observe := o.Observe(opts...)
+		loop:
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-observe:
+					if !ok {
+						return
+					}
+					if item.Error() {
+						err = item.E
+						break loop
+					}
+					if !item.SendContext(ctx, next) {
+						return
+					}
+				}
+			}
+
+			// The error is forwarded to the handler while waiting for the retry signal
+			pending := errs
+		wait:
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case pending <- Of(err):
+					pending = nil
+				case item, ok := <-notifier:
+					if !ok {
+						return
+					}
+					if item.Error() {
+						item.SendContext(ctx, next)
+						return
+					}
+					break wait
+				}
+			}
+		}
 	}
+
+	return customObservableOperator(f, opts...)
 }
 
 // Run creates an Observer without consuming the emitted items.
@@ -1769,74 +2633,76 @@ func (o *ObservableImpl) Run(opts ...Option) Disposed {
 // Sample returns an Observable that emits the most recent items emitted by the source
 // Iterable whenever the input Iterable emits an item.
 func (o *ObservableImpl) Sample(iterable Iterable, opts ...Option) Observable {
-	option := parseOptions(opts...)
-	next := option.buildChannel()
-	ctx := option.buildContext()
-	itCh := make(chan Item)
-	obsCh := make(chan Item)
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		itCh := option.buildChannel()
+		obsCh := option.buildChannel()
 
-	go func() {
-		defer close(obsCh)
-		observe := o.Observe(opts...)
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case i, ok := <-observe:
-				if !ok {
+		go func() {
+			defer close(obsCh)
+			observe := o.Observe(opts...)
+			for {
+				select {
+				case <-ctx.Done():
 					return
+				case i, ok := <-observe:
+					if !ok {
+						return
+					}
+					i.SendContext(ctx, obsCh)
 				}
-				i.SendContext(ctx, obsCh)
 			}
-		}
-	}()
+		}()
 
-	go func() {
-		defer close(itCh)
-		observe := iterable.Observe(opts...)
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case i, ok := <-observe:
-				if !ok {
+		go func() {
+			defer close(itCh)
+			observe := iterable.Observe(opts...)
+			for {
+				select {
+				case <-ctx.Done():
 					return
+				case i, ok := <-observe:
+					if !ok {
+						return
+					}
+					i.SendContext(ctx, itCh)
 				}
-				i.SendContext(ctx, itCh)
 			}
-		}
-	}()
+		}()
 
-	go func() {
-		defer close(next)
 		var lastEmittedItem Item
 		isItemWaitingToBeEmitted := false
 
 		for {
 			select {
+			case <-ctx.Done():
+				return
 			case _, ok := <-itCh:
-				if ok {
-					if isItemWaitingToBeEmitted {
-						next <- lastEmittedItem
-						isItemWaitingToBeEmitted = false
-					}
-				} else {
+				if !ok {
 					return
 				}
+				if isItemWaitingToBeEmitted {
+					if !lastEmittedItem.SendContext(ctx, next) {
+						return
+					}
+					isItemWaitingToBeEmitted = false
+				}
 			case item, ok := <-obsCh:
-				if ok {
-					lastEmittedItem = item
-					isItemWaitingToBeEmitted = true
-				} else {
+				if !ok {
 					return
 				}
+				lastEmittedItem = item
+				isItemWaitingToBeEmitted = true
 			}
 		}
-	}()
-
-	return &ObservableImpl{
-		iterable: newChannelIterable(next),
 	}
+
+	return customObservableOperator(f, opts...)
+}
+
+// SampleWithTime returns an Observable that emits the most recent item emitted by the source Observable
+// within each periodic time interval.
+func (o *ObservableImpl) SampleWithTime(timespan Duration, opts ...Option) Observable {
+	return o.throttle(timespan, false, opts...)
 }
 
 // Scan apply a Func2 to each item emitted by an Observable, sequentially, and emit each successive value.
@@ -1849,6 +2715,18 @@ func (o *ObservableImpl) Scan(apply Func2, opts ...Option) Observable {
 	}, true, false, opts...)
 }
 
+// ScanWithSeed apply a Func2 to each item emitted by an Observable, sequentially, and emit each successive value.
+// The first call to apply receives seed as the accumulator.
+// Cannot be run in parallel.
+func (o *ObservableImpl) ScanWithSeed(apply Func2, seed interface{}, opts ...Option) Observable {
+	return observable(o, func() operator {
+		return &scanOperator{
+			apply:   apply,
+			current: seed,
+		}
+	}, true, false, opts...)
+}
+
 type scanOperator struct {
 	apply   Func2
 	current interface{}
@@ -1903,11 +2781,9 @@ func (o *ObservableImpl) Send(output chan<- Item, opts ...Option) {
 				if !ok {
 					break loop
 				}
-				if i.Error() {
-					output <- i
+				if !i.SendContext(ctx, output) || i.Error() {
 					break loop
 				}
-				i.SendContext(ctx, output)
 			}
 		}
 		close(output)
@@ -1916,46 +2792,46 @@ func (o *ObservableImpl) Send(output chan<- Item, opts ...Option) {
 
 // SequenceEqual emits true if an Observable and the input Observable emit the same items,
 // in the same order, with the same termination state. Otherwise, it emits false.
+// If either of them emits an error, the error is emitted instead.
 func (o *ObservableImpl) SequenceEqual(iterable Iterable, opts ...Option) Single {
-	option := parseOptions(opts...)
-	next := option.buildChannel()
-	ctx := option.buildContext()
-	itCh := make(chan Item)
-	obsCh := make(chan Item)
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		itCh := option.buildChannel()
+		obsCh := option.buildChannel()
 
-	go func() {
-		defer close(obsCh)
-		observe := o.Observe(opts...)
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case i, ok := <-observe:
-				if !ok {
+		go func() {
+			defer close(obsCh)
+			observe := o.Observe(append(opts[:len(opts):len(opts)], WithContext(ctx))...)
+			for {
+				select {
+				case <-ctx.Done():
 					return
+				case i, ok := <-observe:
+					if !ok {
+						return
+					}
+					i.SendContext(ctx, obsCh)
 				}
-				i.SendContext(ctx, obsCh)
 			}
-		}
-	}()
+		}()
 
-	go func() {
-		defer close(itCh)
-		observe := iterable.Observe(opts...)
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case i, ok := <-observe:
-				if !ok {
+		go func() {
+			defer close(itCh)
+			observe := iterable.Observe(append(opts[:len(opts):len(opts)], WithContext(ctx))...)
+			for {
+				select {
+				case <-ctx.Done():
 					return
+				case i, ok := <-observe:
+					if !ok {
+						return
+					}
+					i.SendContext(ctx, itCh)
 				}
-				i.SendContext(ctx, itCh)
 			}
-		}
-	}()
+		}()
 
-	go func() {
 		var mainSequence []interface{}
 		var obsSequence []interface{}
 		areCorrect := true
@@ -1965,19 +2841,31 @@ func (o *ObservableImpl) SequenceEqual(iterable Iterable, opts ...Option) Single
 	mainLoop:
 		for {
 			select {
+			case <-ctx.Done():
+				return
 			case item, ok := <-itCh:
+				if ok && item.Error() {
+					item.SendContext(ctx, next)
+					return
+				}
 				if ok {
-					mainSequence = append(mainSequence, item)
+					mainSequence = append(mainSequence, item.V)
 					areCorrect, mainSequence, obsSequence = popAndCompareFirstItems(mainSequence, obsSequence)
 				} else {
 					isMainChannelClosed = true
+					itCh = nil
 				}
 			case item, ok := <-obsCh:
+				if ok && item.Error() {
+					item.SendContext(ctx, next)
+					return
+				}
 				if ok {
-					obsSequence = append(obsSequence, item)
+					obsSequence = append(obsSequence, item.V)
 					areCorrect, mainSequence, obsSequence = popAndCompareFirstItems(mainSequence, obsSequence)
 				} else {
 					isObsChannelClosed = true
+					obsCh = nil
 				}
 			}
 
@@ -1987,67 +2875,60 @@ func (o *ObservableImpl) SequenceEqual(iterable Iterable, opts ...Option) Single
 		}
 
 		Of(areCorrect && len(mainSequence) == 0 && len(obsSequence) == 0).SendContext(ctx, next)
-		close(next)
-	}()
+	}
 
 	return &SingleImpl{
-		iterable: newChannelIterable(next),
+		iterable: customObservableOperator(f, opts...),
 	}
 }
 
 // Serialize forces an Observable to make serialized calls and to be well-behaved.
 func (o *ObservableImpl) Serialize(from int, identifier func(interface{}) int, opts ...Option) Observable {
-	option := parseOptions(opts...)
-	next := option.buildChannel()
-
-	ctx := option.buildContext()
-	mutex := sync.Mutex{}
-	minHeap := binaryheap.NewWith(func(a, b interface{}) int {
-		return a.(int) - b.(int)
-	})
-	minHeap.Push(from)
-	counter := int64(from)
-	status := make(map[int]interface{})
-	notif := make(chan struct{})
-
-	// Scatter
-	go func() {
-		defer close(notif)
-		src := o.Observe(opts...)
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		mutex := sync.Mutex{}
+		minHeap := binaryheap.NewWith(func(a, b interface{}) int {
+			return a.(int) - b.(int)
+		})
+		minHeap.Push(from)
+		counter := int64(from)
+		status := make(map[int]interface{})
+		notif := make(chan struct{})
 
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case item, ok := <-src:
-				if !ok {
-					return
-				}
-				if item.Error() {
-					next <- item
-					return
-				}
+		// Scatter
+		go func() {
+			defer close(notif)
+			src := o.Observe(opts...)
 
-				id := identifier(item.V)
-				mutex.Lock()
-				if id != from {
-					minHeap.Push(id)
-				}
-				status[id] = item.V
-				mutex.Unlock()
+			for {
 				select {
 				case <-ctx.Done():
 					return
-				case notif <- struct{}{}:
+				case item, ok := <-src:
+					if !ok {
+						return
+					}
+					if item.Error() {
+						item.SendContext(ctx, next)
+						return
+					}
+
+					id := identifier(item.V)
+					mutex.Lock()
+					if id != from {
+						minHeap.Push(id)
+					}
+					status[id] = item.V
+					mutex.Unlock()
+					select {
+					case <-ctx.Done():
+						return
+					case notif <- struct{}{}:
+					}
 				}
 			}
-		}
-	}()
-
-	// Gather
-	go func() {
-		defer close(next)
+		}()
 
+		// Gather
 		for {
 			select {
 			case <-ctx.Done():
@@ -2077,11 +2958,15 @@ func (o *ObservableImpl) Serialize(from int, identifier func(interface{}) int, o
 				mutex.Unlock()
 			}
 		}
-	}()
-
-	return &ObservableImpl{
-		iterable: newChannelIterable(next),
 	}
+
+	return customObservableOperator(f, opts...)
+}
+
+// Share returns an Observable sharing a single subscription to the source Observable among all its observers.
+// It is a shorthand for Publish(opts...).RefCount().
+func (o *ObservableImpl) Share(opts ...Option) Observable {
+	return o.Publish(opts...).RefCount()
 }
 
 // Skip suppresses the first n items in the original Observable and
@@ -2123,24 +3008,35 @@ func (op *skipOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ o
 // Cannot be run in parallel.
 func (o *ObservableImpl) SkipLast(nth uint, opts ...Option) Observable {
 	return observable(o, func() operator {
-		return &skipLastOperator{
+		op := &skipLastOperator{
 			nth: nth,
 		}
+		if nth > 0 {
+			op.r = ring.New(int(nth))
+		}
+		return op
 	}, true, false, opts...)
 }
 
 type skipLastOperator struct {
-	nth       uint
-	skipCount int
+	nth uint
+	// r holds the last nth items; once full, it points to the oldest one.
+	r     *ring.Ring
+	count uint
 }
 
-func (op *skipLastOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	if op.skipCount >= int(op.nth) {
-		operatorOptions.stop()
+func (op *skipLastOperator) next(ctx context.Context, item Item, dst chan<- Item, _ operatorOptions) {
+	if op.nth == 0 {
+		item.SendContext(ctx, dst)
 		return
 	}
-	op.skipCount++
-	item.SendContext(ctx, dst)
+	if op.count == op.nth {
+		Of(op.r.Value).SendContext(ctx, dst)
+	} else {
+		op.count++
+	}
+	op.r.Value = item.V
+	op.r = op.r.Next()
 }
 
 func (op *skipLastOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
@@ -2153,6 +3049,54 @@ func (op *skipLastOperator) end(_ context.Context, _ chan<- Item) {
 func (op *skipLastOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
 }
 
+// SkipUntil discards the items emitted by an Observable until the notifier Observable emits an item.
+// If the notifier completes without emitting any item, every item is discarded.
+// Cannot be run in parallel.
+func (o *ObservableImpl) SkipUntil(notifier Observable, opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		observe := o.Observe(append(opts, WithContext(ctx))...)
+		notifierCtx, cancelNotifier := context.WithCancel(ctx)
+		defer cancelNotifier()
+		notify := notifier.Observe(WithContext(notifierCtx))
+		skip := true
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-notify:
+				if ok && item.Error() {
+					item.SendContext(ctx, next)
+					return
+				}
+				if ok {
+					skip = false
+				}
+				// The notifier is not needed anymore
+				cancelNotifier()
+				notify = nil
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+				if item.Error() {
+					if !item.SendContext(ctx, next) || option.getErrorStrategy() == StopOnError {
+						return
+					}
+					continue
+				}
+				if !skip && !item.SendContext(ctx, next) {
+					return
+				}
+			}
+		}
+	}
+
+	return customObservableOperator(f, opts...)
+}
+
 // SkipWhile discard items emitted by an Observable until a specified condition becomes false.
 // Cannot be run in parallel.
 func (o *ObservableImpl) SkipWhile(apply Predicate, opts ...Option) Observable {
@@ -2180,141 +3124,321 @@ func (op *skipWhileOperator) next(ctx context.Context, item Item, dst chan<- Ite
 	}
 }
 
-func (op *skipWhileOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
-	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+func (op *skipWhileOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *skipWhileOperator) end(_ context.Context, _ chan<- Item) {
+}
+
+func (op *skipWhileOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
+// StartWith emits a specified Iterable before beginning to emit the items from the source Observable.
+func (o *ObservableImpl) StartWith(iterable Iterable, opts ...Option) Observable {
+	return Concat([]Observable{&ObservableImpl{iterable: iterable}, o}, opts...)
+}
+
+// StartWithItems emits the specified items before beginning to emit the items from the source Observable.
+func (o *ObservableImpl) StartWithItems(items []interface{}, opts ...Option) Observable {
+	return o.StartWith(newJustIterable(items...)(), opts...)
+}
+
+// StartWithObservable emits the items of another Observable, until it completes, before beginning to emit
+// the items from the source Observable.
+func (o *ObservableImpl) StartWithObservable(other Observable, opts ...Option) Observable {
+	return o.StartWith(other, opts...)
+}
+
+// Subscribe feeds an Observer with the items of the Observable, following the Rx grammar: the Observer methods are
+// called sequentially, OnError or OnCompleted is called at most once, and nothing is called after it.
+// An error terminates the subscription whatever the error strategy. Nothing is called once the context is cancelled.
+// It returns a channel closed once the subscription terminates.
+func (o *ObservableImpl) Subscribe(observer Observer, opts ...Option) Disposed {
+	return o.subscribe(observer, nil, opts...).done
+}
+
+// SubscribeWithContext subscribes an Observer like Subscribe, the subscription being bound to ctx: once ctx is
+// cancelled, the Observer is not called anymore and the whole upstream chain is torn down, the operators and
+// producers stopping their goroutines and closing their channels.
+func (o *ObservableImpl) SubscribeWithContext(ctx context.Context, observer Observer, opts ...Option) Disposed {
+	return o.Subscribe(observer, append(opts[:len(opts):len(opts)], WithContext(ctx))...)
+}
+
+// SubscribeAwait subscribes an Observer like Subscribe, and returns a handle to wait for, cancel or drain the
+// subscription, and to get the error terminating it.
+func (o *ObservableImpl) SubscribeAwait(observer Observer, opts ...Option) *Subscription {
+	drain := make(chan struct{})
+	return o.subscribe(observer, drain, append(opts[:len(opts):len(opts)], withDrain(drain))...)
+}
+
+func (o *ObservableImpl) subscribe(observer Observer, drain chan struct{}, opts ...Option) *Subscription {
+	dispose := make(chan struct{})
+	option := parseOptions(opts...)
+	observer = interceptObserver(observer, option)
+	ctx, cancel := context.WithCancel(option.buildContext())
+	observe := o.Observe(append(opts, WithContext(ctx))...)
+	sub := &Subscription{
+		done:   dispose,
+		cancel: cancel,
+		drain:  drain,
+	}
+	go func() {
+		defer close(dispose)
+		defer cancel()
+		for {
+			select {
+			case <-ctx.Done():
+				sub.err = ctx.Err()
+				return
+			case item, ok := <-observe:
+				if !ok {
+					// A source may close its channel once the context is cancelled, or complete once drained
+					if sub.err = ctx.Err(); sub.err == nil {
+						observer.OnCompleted()
+					}
+					return
+				}
+				if item.Error() {
+					sub.err = item.E
+					observer.OnError(item.E)
+					return
+				}
+				if err := call(option, func() { observer.OnNext(item.V) }); err != nil {
+					sub.err = err
+					observer.OnError(err)
+					return
+				}
+				recycle(option.getValuePool(), item.V)
+			}
+		}
+	}()
+	return sub
+}
+
+// SubscribeOn returns an Observable whose source is subscribed to from a task run by the given Scheduler: the
+// task returns once the source is observed, its items being forwarded by the operator, so that it does not hold
+// a goroutine of the scheduler while the source is running. If the task is rejected, e.g. once the scheduler is
+// stopped, a RejectedTaskError is emitted.
+func (o *ObservableImpl) SubscribeOn(scheduler Scheduler, opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		subscribed := make(chan (<-chan Item), 1)
+		observeOpts := append(opts, WithContext(ctx))
+		if !scheduler.Schedule(func() {
+			subscribed <- o.Observe(observeOpts...)
+		}) {
+			Error(errRejectedTask).SendContext(ctx, next)
+			return
+		}
+
+		var observe <-chan Item
+		select {
+		case <-ctx.Done():
+			return
+		case observe = <-subscribed:
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+				if !item.SendContext(ctx, next) {
+					return
+				}
+				if item.Error() && option.getErrorStrategy() == StopOnError {
+					return
+				}
+			}
+		}
+	}
+
+	return customObservableOperator(f, opts...)
+}
+
+// SumFloat32 calculates the sum of float32 emitted by an Observable and emits a float32.
+func (o *ObservableImpl) SumFloat32(opts ...Option) OptionalSingle {
+	return optionalSingle(o, fold{
+		seed: float32(0),
+		step: func(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
+			switch v := elem.(type) {
+			default:
+				return nil, IllegalInputError{error: fmt.Sprintf("expected type: (float32|int|int8|int16|int32|int64), got: %t", elem)}
+			case int:
+				return acc.(float32) + float32(v), nil
+			case int8:
+				return acc.(float32) + float32(v), nil
+			case int16:
+				return acc.(float32) + float32(v), nil
+			case int32:
+				return acc.(float32) + float32(v), nil
+			case int64:
+				return acc.(float32) + float32(v), nil
+			case float32:
+				return acc.(float32) + v, nil
+			}
+		},
+		result: nonEmptyResult,
+	}.operatorFactory(), false, false, opts...)
 }
 
-func (op *skipWhileOperator) end(_ context.Context, _ chan<- Item) {
+// SumFloat64 calculates the sum of float64 emitted by an Observable and emits a float64.
+func (o *ObservableImpl) SumFloat64(opts ...Option) OptionalSingle {
+	return optionalSingle(o, fold{
+		seed: float64(0),
+		step: func(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
+			switch v := elem.(type) {
+			default:
+				return nil, IllegalInputError{error: fmt.Sprintf("expected type: (float32|float64|int|int8|int16|int32|int64), got: %t", elem)}
+			case int:
+				return acc.(float64) + float64(v), nil
+			case int8:
+				return acc.(float64) + float64(v), nil
+			case int16:
+				return acc.(float64) + float64(v), nil
+			case int32:
+				return acc.(float64) + float64(v), nil
+			case int64:
+				return acc.(float64) + float64(v), nil
+			case float32:
+				return acc.(float64) + float64(v), nil
+			case float64:
+				return acc.(float64) + v, nil
+			}
+		},
+		result: nonEmptyResult,
+	}.operatorFactory(), false, false, opts...)
 }
 
-func (op *skipWhileOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+// SumInt64 calculates the sum of integers emitted by an Observable and emits an int64.
+func (o *ObservableImpl) SumInt64(opts ...Option) OptionalSingle {
+	return optionalSingle(o, fold{
+		seed: int64(0),
+		step: func(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
+			switch v := elem.(type) {
+			default:
+				return nil, IllegalInputError{error: fmt.Sprintf("expected type: (int|int8|int16|int32|int64), got: %t", elem)}
+			case int:
+				return acc.(int64) + int64(v), nil
+			case int8:
+				return acc.(int64) + int64(v), nil
+			case int16:
+				return acc.(int64) + int64(v), nil
+			case int32:
+				return acc.(int64) + int64(v), nil
+			case int64:
+				return acc.(int64) + v, nil
+			}
+		},
+		result: nonEmptyResult,
+	}.operatorFactory(), false, false, opts...)
 }
 
-// StartWith emits a specified Iterable before beginning to emit the items from the source Observable.
-func (o *ObservableImpl) StartWith(iterable Iterable, opts ...Option) Observable {
-	option := parseOptions(opts...)
-	next := option.buildChannel()
-	ctx := option.buildContext()
+// SwitchIfEmpty mirrors the source Observable, or the other Observable if the source completes without emitting
+// any item. The other Observable is only observed in that case.
+func (o *ObservableImpl) SwitchIfEmpty(other Observable, opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		sourceCtx, cancelSource := context.WithCancel(ctx)
+		defer cancelSource()
+		observe := o.Observe(append(opts, WithContext(sourceCtx))...)
+		empty := true
 
-	go func() {
-		defer close(next)
-		observe := iterable.Observe(opts...)
-	loop1:
+	loop:
 		for {
 			select {
 			case <-ctx.Done():
-				break loop1
-			case i, ok := <-observe:
+				return
+			case item, ok := <-observe:
 				if !ok {
-					break loop1
+					break loop
 				}
-				if i.Error() {
-					next <- i
+				empty = false
+				if !item.SendContext(ctx, next) {
 					return
 				}
-				i.SendContext(ctx, next)
-			}
-		}
-		observe = o.Observe(opts...)
-	loop2:
-		for {
-			select {
-			case <-ctx.Done():
-				break loop2
-			case i, ok := <-observe:
-				if !ok {
-					break loop2
-				}
-				if i.Error() {
-					i.SendContext(ctx, next)
+				if item.Error() && option.getErrorStrategy() == StopOnError {
 					return
 				}
-				i.SendContext(ctx, next)
 			}
 		}
-	}()
 
-	return &ObservableImpl{
-		iterable: newChannelIterable(next),
+		if empty {
+			concatObserve(ctx, other, next, option, opts...)
+		}
 	}
-}
 
-// SumFloat32 calculates the average of float32 emitted by an Observable and emits a float32.
-func (o *ObservableImpl) SumFloat32(opts ...Option) OptionalSingle {
-	return o.Reduce(func(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
-		if acc == nil {
-			acc = float32(0)
-		}
-		sum := acc.(float32)
-		switch i := elem.(type) {
-		default:
-			return nil, IllegalInputError{error: fmt.Sprintf("expected type: (float32|int|int8|int16|int32|int64), got: %t", elem)}
-		case int:
-			return sum + float32(i), nil
-		case int8:
-			return sum + float32(i), nil
-		case int16:
-			return sum + float32(i), nil
-		case int32:
-			return sum + float32(i), nil
-		case int64:
-			return sum + float32(i), nil
-		case float32:
-			return sum + i, nil
-		}
-	}, opts...)
+	return customObservableOperator(f, opts...)
 }
 
-// SumFloat64 calculates the average of float64 emitted by an Observable and emits a float64.
-func (o *ObservableImpl) SumFloat64(opts ...Option) OptionalSingle {
-	return o.Reduce(func(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
-		if acc == nil {
-			acc = float64(0)
-		}
-		sum := acc.(float64)
-		switch i := elem.(type) {
-		default:
-			return nil, IllegalInputError{error: fmt.Sprintf("expected type: (float32|float64|int|int8|int16|int32|int64), got: %t", elem)}
-		case int:
-			return sum + float64(i), nil
-		case int8:
-			return sum + float64(i), nil
-		case int16:
-			return sum + float64(i), nil
-		case int32:
-			return sum + float64(i), nil
-		case int64:
-			return sum + float64(i), nil
-		case float32:
-			return sum + float64(i), nil
-		case float64:
-			return sum + i, nil
+// SwitchMap transforms each item into an Observable and mirrors the items emitted by the most recent one.
+// Each time a new item is emitted by the source Observable, the previous inner Observable is unsubscribed.
+func (o *ObservableImpl) SwitchMap(apply ItemToObservable, opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		observe := o.Observe(opts...)
+		// The observables of the items in flight are not cut once the subscription is drained
+		innerOpts := append(opts[:len(opts):len(opts)], withDrain(nil))
+		mutex := sync.Mutex{}
+		wg := sync.WaitGroup{}
+		current := NewSerialDisposable()
+
+		inner := func(ctx context.Context, observe <-chan Item) {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-observe:
+					if !ok {
+						return
+					}
+					mutex.Lock()
+					// The inner Observable may have been switched while waiting for the lock
+					if ctx.Err() != nil {
+						mutex.Unlock()
+						return
+					}
+					sent := item.SendContext(ctx, next)
+					stop := sent && item.Error() && option.getErrorStrategy() == StopOnError
+					if stop {
+						cancel()
+					}
+					mutex.Unlock()
+					if !sent || stop {
+						return
+					}
+				}
+			}
 		}
-	}, opts...)
-}
 
-// SumInt64 calculates the average of integers emitted by an Observable and emits an int64.
-func (o *ObservableImpl) SumInt64(opts ...Option) OptionalSingle {
-	return o.Reduce(func(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
-		if acc == nil {
-			acc = int64(0)
-		}
-		sum := acc.(int64)
-		switch i := elem.(type) {
-		default:
-			return nil, IllegalInputError{error: fmt.Sprintf("expected type: (int|int8|int16|int32|int64), got: %t", elem)}
-		case int:
-			return sum + int64(i), nil
-		case int8:
-			return sum + int64(i), nil
-		case int16:
-			return sum + int64(i), nil
-		case int32:
-			return sum + int64(i), nil
-		case int64:
-			return sum + i, nil
+	loop:
+		for {
+			select {
+			case <-ctx.Done():
+				break loop
+			case item, ok := <-observe:
+				if !ok {
+					break loop
+				}
+				innerCtx, innerCancel := context.WithCancel(ctx)
+				current.Set(Disposable(innerCancel))
+				// Wait for a pending send of the previous inner Observable
+				mutex.Lock()
+				mutex.Unlock()
+				wg.Add(1)
+				go inner(innerCtx, apply(item).Observe(append(innerOpts, WithContext(innerCtx))...))
+			}
 		}
-	}, opts...)
+		wg.Wait()
+		current.Dispose()
+	}
+
+	return customObservableOperator(f, opts...)
 }
 
 // Take emits only the first n items emitted by an Observable.
@@ -2332,11 +3456,14 @@ type takeOperator struct {
 	takeCount int
 }
 
-func (op *takeOperator) next(ctx context.Context, item Item, dst chan<- Item, _ operatorOptions) {
+func (op *takeOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	if op.takeCount < int(op.nth) {
 		op.takeCount++
 		item.SendContext(ctx, dst)
 	}
+	if op.takeCount == int(op.nth) {
+		operatorOptions.complete()
+	}
 }
 
 func (op *takeOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
@@ -2414,7 +3541,7 @@ type takeUntilOperator struct {
 func (op *takeUntilOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	item.SendContext(ctx, dst)
 	if op.apply(item.V) {
-		operatorOptions.stop()
+		operatorOptions.complete()
 		return
 	}
 }
@@ -2429,6 +3556,42 @@ func (op *takeUntilOperator) end(_ context.Context, _ chan<- Item) {
 func (op *takeUntilOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
 }
 
+// TakeUntilObservable returns an Observable that emits the items emitted by the source Observable
+// until the notifier Observable emits an item or terminates.
+// Cannot be run in parallel.
+func (o *ObservableImpl) TakeUntilObservable(notifier Observable, opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		observe := o.Observe(append(opts, WithContext(ctx))...)
+		notify := notifier.Observe(WithContext(ctx))
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-notify:
+				if ok && item.Error() {
+					item.SendContext(ctx, next)
+				}
+				return
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+				if !item.SendContext(ctx, next) {
+					return
+				}
+				if item.Error() && option.getErrorStrategy() == StopOnError {
+					return
+				}
+			}
+		}
+	}
+
+	return customObservableOperator(f, opts...)
+}
+
 // TakeWhile returns an Observable that emits items emitted by the source ObservableSource so long as each
 // item satisfied a specified condition, and then completes as soon as this condition is not satisfied.
 // Cannot be run in parallel.
@@ -2446,7 +3609,7 @@ type takeWhileOperator struct {
 
 func (op *takeWhileOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	if !op.apply(item.V) {
-		operatorOptions.stop()
+		operatorOptions.complete()
 		return
 	}
 	item.SendContext(ctx, dst)
@@ -2462,12 +3625,112 @@ func (op *takeWhileOperator) end(_ context.Context, _ chan<- Item) {
 func (op *takeWhileOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
 }
 
+// Tap returns an Observable mirroring the source and calling the given functions as a side effect,
+// without consuming the Observable (unlike ForEach). Any of the functions can be nil.
+func (o *ObservableImpl) Tap(nextFunc NextFunc, errFunc ErrFunc, completedFunc CompletedFunc, opts ...Option) Observable {
+	return o.doOn(lifecycleHooks{
+		next:      nextFunc,
+		err:       errFunc,
+		completed: completedFunc,
+	}, opts...)
+}
+
+// ThrottleFirst returns an Observable that emits the first item emitted by the source Observable, then
+// ignores the subsequent items until the timespan has elapsed.
+func (o *ObservableImpl) ThrottleFirst(timespan Duration, opts ...Option) Observable {
+	return o.throttle(timespan, true, opts...)
+}
+
+// ThrottleLast returns an Observable that emits the most recent item emitted by the source Observable
+// within each periodic time interval. It is an alias of SampleWithTime.
+func (o *ObservableImpl) ThrottleLast(timespan Duration, opts ...Option) Observable {
+	return o.throttle(timespan, false, opts...)
+}
+
+// throttle emits at most one item per time window. If first is set, a window is opened by the first item
+// received and this item is emitted. Otherwise, the windows are periodic and the most recent item received
+// within a window is emitted when the window closes.
+func (o *ObservableImpl) throttle(timespan Duration, first bool, opts ...Option) Observable {
+	if timespan == nil {
+		return Thrown(IllegalInputError{error: "timespan must no be nil"})
+	}
+
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		observe := o.Observe(opts...)
+		clock := option.getClock()
+		var timer ClockTimer
+		var window <-chan time.Time
+		var latest interface{}
+		pending := false
+
+		openWindow := func() {
+			timer = clock.NewTimer(timespan.duration())
+			window = timer.C()
+		}
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+		if !first {
+			openWindow()
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+				if item.Error() {
+					if !item.SendContext(ctx, next) {
+						return
+					}
+					if option.getErrorStrategy() == StopOnError {
+						return
+					}
+					continue
+				}
+				if !first {
+					latest = item.V
+					pending = true
+				} else if window == nil {
+					if !item.SendContext(ctx, next) {
+						return
+					}
+					openWindow()
+				}
+			case <-window:
+				if first {
+					window = nil
+					continue
+				}
+				if pending {
+					if !Of(latest).SendContext(ctx, next) {
+						return
+					}
+					latest = nil
+					pending = false
+				}
+				openWindow()
+			}
+		}
+	}
+
+	return customObservableOperator(f, opts...)
+}
+
 // TimeInterval converts an Observable that emits items into one that emits indications of the amount of time elapsed between those emissions.
+// The first duration is measured from the subscription. The time is read from the clock set with WithClock.
 func (o *ObservableImpl) TimeInterval(opts ...Option) Observable {
 	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
-		defer close(next)
-		observe := o.Observe(opts...)
-		latest := time.Now().UTC()
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		observe := o.Observe(append(opts, WithContext(ctx))...)
+		clock := option.getClock()
+		latest := clock.Now().UTC()
 
 		for {
 			select {
@@ -2485,7 +3748,7 @@ func (o *ObservableImpl) TimeInterval(opts ...Option) Observable {
 						return
 					}
 				} else {
-					now := time.Now().UTC()
+					now := clock.Now().UTC()
 					if !Of(now.Sub(latest)).SendContext(ctx, next) {
 						return
 					}
@@ -2498,19 +3761,106 @@ func (o *ObservableImpl) TimeInterval(opts ...Option) Observable {
 	return customObservableOperator(f, opts...)
 }
 
+// Timeout mirrors the source Observable, but emits a TimeoutError if no item is emitted within the timespan,
+// starting from the subscription and then from each item. With WithTimeoutStrategy(TimeoutFirstItem), only
+// the first item has to be emitted within the timespan.
+func (o *ObservableImpl) Timeout(timespan Duration, opts ...Option) Observable {
+	return o.timeout(timespan, time.Time{}, nil, opts...)
+}
+
+// TimeoutWith mirrors the source Observable, but switches to the fallback Observable if no item is emitted
+// within the timespan, starting from the subscription and then from each item. With
+// WithTimeoutStrategy(TimeoutFirstItem), only the first item has to be emitted within the timespan.
+func (o *ObservableImpl) TimeoutWith(timespan Duration, fallback Observable, opts ...Option) Observable {
+	return o.timeout(timespan, time.Time{}, fallback, opts...)
+}
+
+// TimeoutAt mirrors the source Observable, but emits a TimeoutError if it has not terminated by the deadline,
+// read from the clock set with WithClock.
+func (o *ObservableImpl) TimeoutAt(deadline time.Time, opts ...Option) Observable {
+	return o.timeout(nil, deadline, nil, opts...)
+}
+
+// timeout unsubscribes from the source Observable once the deadline passes if not zero, or otherwise once the
+// timespan elapses according to the timeout strategy, then emits a TimeoutError or, if not nil, the items of
+// the fallback Observable.
+func (o *ObservableImpl) timeout(timespan Duration, deadline time.Time, fallback Observable, opts ...Option) Observable {
+	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		sourceCtx, cancelSource := context.WithCancel(ctx)
+		defer cancelSource()
+		observe := o.Observe(append(opts, WithContext(sourceCtx))...)
+		clock := option.getClock()
+		strategy := parseTimeoutStrategy(opts...)
+
+		var timer ClockTimer
+		if deadline.IsZero() {
+			timer = clock.NewTimer(timespan.duration())
+		} else {
+			timer = clock.NewTimer(deadline.Sub(clock.Now()))
+		}
+		defer func() {
+			timer.Stop()
+		}()
+		expired := timer.C()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-expired:
+				cancelSource()
+				if fallback != nil {
+					concatObserve(ctx, fallback, next, option, opts...)
+					return
+				}
+				if deadline.IsZero() {
+					Error(TimeoutError{error: fmt.Sprintf("no item emitted within %v", timespan.duration())}).SendContext(ctx, next)
+				} else {
+					Error(TimeoutError{error: fmt.Sprintf("not terminated by %v", deadline)}).SendContext(ctx, next)
+				}
+				return
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+				// The timespan is not measured while the item is sent
+				if deadline.IsZero() {
+					timer.Stop()
+					expired = nil
+				}
+				if !item.SendContext(ctx, next) {
+					return
+				}
+				if item.Error() && option.getErrorStrategy() == StopOnError {
+					return
+				}
+				if deadline.IsZero() && strategy == TimeoutEachItem {
+					timer = clock.NewTimer(timespan.duration())
+					expired = timer.C()
+				}
+			}
+		}
+	}
+
+	return customObservableOperator(f, opts...)
+}
+
 // Timestamp attaches a timestamp to each item emitted by an Observable indicating when it was emitted.
+// The time is read from the clock set with WithClock.
 func (o *ObservableImpl) Timestamp(opts ...Option) Observable {
+	clock := parseOptions(opts...).getClock()
 	return observable(o, func() operator {
-		return &timestampOperator{}
+		return &timestampOperator{clock: clock}
 	}, true, false, opts...)
 }
 
 type timestampOperator struct {
+	clock Clock
 }
 
 func (op *timestampOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	Of(TimestampItem{
-		Timestamp: time.Now().UTC(),
+		Timestamp: op.clock.Now().UTC(),
 		V:         item.V,
 	}).SendContext(ctx, dst)
 }
@@ -2525,6 +3875,78 @@ func (op *timestampOperator) end(_ context.Context, _ chan<- Item) {
 func (op *timestampOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
 }
 
+// ToChannel returns a channel receiving the values of the items emitted by an Observable.
+// The channel capacity is set with WithBufferedChannel. On an error, the channel is closed,
+// unless WithErrorValues is passed: the error is then sent as a value, and the channel is closed
+// only under the StopOnError strategy.
+func (o *ObservableImpl) ToChannel(opts ...Option) <-chan interface{} {
+	option := parseOptions(opts...)
+	ctx, cancel := context.WithCancel(option.buildContext())
+	ch := make(chan interface{}, option.getBufferedChannelCapacity())
+	observe := o.Observe(append(opts, WithContext(ctx))...)
+
+	go func() {
+		defer close(ch)
+		defer cancel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+				value := item.V
+				if item.Error() {
+					if !option.isErrorValues() {
+						return
+					}
+					value = item.E
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case ch <- value:
+				}
+				if item.Error() && option.getErrorStrategy() == StopOnError {
+					return
+				}
+			}
+		}
+	}()
+	return ch
+}
+
+// ToList returns a Single emitting the slice of all the items emitted by the Observable.
+// Unlike ToSlice, it is not blocking.
+// Cannot be run in parallel.
+func (o *ObservableImpl) ToList(opts ...Option) Single {
+	return single(o, func() operator {
+		return &toListOperator{
+			s: make([]interface{}, 0),
+		}
+	}, true, false, opts...)
+}
+
+type toListOperator struct {
+	s []interface{}
+}
+
+func (op *toListOperator) next(_ context.Context, item Item, _ chan<- Item, _ operatorOptions) {
+	op.s = append(op.s, item.V)
+}
+
+func (op *toListOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *toListOperator) end(ctx context.Context, dst chan<- Item) {
+	Of(op.s).SendContext(ctx, dst)
+}
+
+func (op *toListOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
 // ToMap convert the sequence of items emitted by an Observable
 // into a map keyed by a specified key function.
 // Cannot be run in parallel.
@@ -2611,6 +4033,144 @@ func (op *toMapWithValueSelector) end(ctx context.Context, dst chan<- Item) {
 func (op *toMapWithValueSelector) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
 }
 
+// ToMultimap converts the sequence of items emitted by an Observable into a map keyed by a specified key
+// function, each key being associated to the slice of the items sharing it.
+// Cannot be run in parallel.
+func (o *ObservableImpl) ToMultimap(keySelector Func, opts ...Option) Single {
+	return o.ToMultimapWithValueSelector(keySelector, func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	}, opts...)
+}
+
+// ToMultimapWithValueSelector converts the sequence of items emitted by an Observable into a map keyed by a
+// specified key function, each key being associated to the slice of the values computed by another value function.
+// Cannot be run in parallel.
+func (o *ObservableImpl) ToMultimapWithValueSelector(keySelector, valueSelector Func, opts ...Option) Single {
+	return single(o, func() operator {
+		return &toMultimapOperator{
+			keySelector:   keySelector,
+			valueSelector: valueSelector,
+			m:             make(map[interface{}][]interface{}),
+		}
+	}, true, false, opts...)
+}
+
+type toMultimapOperator struct {
+	keySelector, valueSelector Func
+	m                          map[interface{}][]interface{}
+}
+
+func (op *toMultimapOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	k, err := op.keySelector(ctx, item.V)
+	if err != nil {
+		op.err(ctx, Error(err), dst, operatorOptions)
+		return
+	}
+
+	v, err := op.valueSelector(ctx, item.V)
+	if err != nil {
+		op.err(ctx, Error(err), dst, operatorOptions)
+		return
+	}
+
+	op.m[k] = append(op.m[k], v)
+}
+
+func (op *toMultimapOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+func (op *toMultimapOperator) end(ctx context.Context, dst chan<- Item) {
+	Of(op.m).SendContext(ctx, dst)
+}
+
+func (op *toMultimapOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
+// ToCompletable converts an Observable into a Completable, ignoring all the items emitted.
+// The Completable terminates with the first error emitted by the Observable, if any.
+func (o *ObservableImpl) ToCompletable(opts ...Option) Completable {
+	return &CompletableImpl{iterable: o.IgnoreElements(append(opts, WithErrorStrategy(StopOnError))...)}
+}
+
+// ToOptionalSingle converts an Observable emitting zero or one item into an OptionalSingle.
+// If the Observable emits more than one item, the OptionalSingle terminates with an IllegalInputError.
+func (o *ObservableImpl) ToOptionalSingle(opts ...Option) OptionalSingle {
+	return optionalSingle(o, func() operator {
+		return &toSingleOperator{optional: true}
+	}, true, false, opts...)
+}
+
+// ToSeq returns a range-over-func iterator over the values of the items emitted by an Observable, e.g. to be
+// used as an iter.Seq[any] since Go 1.23. The Observable is observed each time the iterator is ranged over, until
+// ctx is cancelled or the loop is exited. As with ToChannel, the iteration stops on an error, unless
+// WithErrorValues is passed: the error is then yielded as a value.
+func (o *ObservableImpl) ToSeq(ctx context.Context, opts ...Option) func(yield func(interface{}) bool) {
+	return func(yield func(interface{}) bool) {
+		option := parseOptions(opts...)
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		for item := range o.Observe(append(opts, WithContext(ctx))...) {
+			value := item.V
+			if item.Error() {
+				if !option.isErrorValues() {
+					return
+				}
+				value = item.E
+			}
+			if !yield(value) {
+				return
+			}
+			if item.Error() && option.getErrorStrategy() == StopOnError {
+				return
+			}
+		}
+	}
+}
+
+// ToSingle converts an Observable emitting exactly one item into a Single.
+// If the Observable emits no item or more than one item, the Single terminates with an IllegalInputError.
+func (o *ObservableImpl) ToSingle(opts ...Option) Single {
+	return single(o, func() operator {
+		return &toSingleOperator{}
+	}, true, false, opts...)
+}
+
+type toSingleOperator struct {
+	optional bool
+	item     *Item
+	failed   bool
+}
+
+func (op *toSingleOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	if op.item != nil {
+		op.failed = true
+		Error(IllegalInputError{error: "more than one item emitted"}).SendContext(ctx, dst)
+		operatorOptions.complete()
+		return
+	}
+	op.item = &item
+}
+
+func (op *toSingleOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	op.failed = true
+	item.SendContext(ctx, dst)
+	operatorOptions.complete()
+}
+
+func (op *toSingleOperator) end(ctx context.Context, dst chan<- Item) {
+	switch {
+	case op.failed:
+	case op.item != nil:
+		op.item.SendContext(ctx, dst)
+	case !op.optional:
+		Error(IllegalInputError{error: "no item emitted"}).SendContext(ctx, dst)
+	}
+}
+
+func (op *toSingleOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
 // ToSlice collects all items from an Observable and emit them in a slice and an optional error.
 // Cannot be run in parallel.
 func (o *ObservableImpl) ToSlice(initialCapacity int, opts ...Option) ([]interface{}, error) {
@@ -2698,16 +4258,19 @@ func (op *windowWithCountOperator) post(ctx context.Context, dst chan<- Item) {
 
 func (op *windowWithCountOperator) next(ctx context.Context, item Item, dst chan<- Item, _ operatorOptions) {
 	op.pre(ctx, dst)
-	op.currentChannel <- item
+	item.SendContext(ctx, op.currentChannel)
 	op.iCount++
 	op.post(ctx, dst)
 }
 
 func (op *windowWithCountOperator) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	op.pre(ctx, dst)
-	op.currentChannel <- item
+	item.SendContext(ctx, op.currentChannel)
 	op.iCount++
 	op.post(ctx, dst)
+	if operatorOptions.stopOnError {
+		op.end(ctx, dst)
+	}
 	operatorOptions.stop()
 }
 
@@ -2726,79 +4289,75 @@ func (o *ObservableImpl) WindowWithTime(timespan Duration, opts ...Option) Obser
 	if timespan == nil {
 		return Thrown(IllegalInputError{error: "timespan must no be nil"})
 	}
+	return o.windowWithTime(timespan, 0, opts...)
+}
+
+// WindowWithTimeOrCount periodically subdivides items from an Observable into Observables based on timed windows or a specific size
+// and emit them rather than emitting the items one at a time.
+// Each time a window is closed, the time interval is restarted.
+func (o *ObservableImpl) WindowWithTimeOrCount(timespan Duration, count int, opts ...Option) Observable {
+	if timespan == nil {
+		return Thrown(IllegalInputError{error: "timespan must no be nil"})
+	}
+	if count < 0 {
+		return Thrown(IllegalInputError{error: "count must be positive or nil"})
+	}
+	return o.windowWithTime(timespan, count, opts...)
+}
 
+// windowWithTime closes the current window each time the timespan elapses or, if count is positive, as soon
+// as the window contains count items. Empty windows are not closed.
+func (o *ObservableImpl) windowWithTime(timespan Duration, count int, opts ...Option) Observable {
 	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
 		observe := o.Observe(opts...)
+		clock := option.getClock()
 		ch := option.buildChannel()
-		done := make(chan struct{})
-		empty := true
-		mutex := sync.Mutex{}
+		defer func() {
+			close(ch)
+		}()
 		if !Of(FromChannel(ch)).SendContext(ctx, next) {
 			return
 		}
+		iCount := 0
+		timer := clock.NewTimer(timespan.duration())
+		defer func() {
+			timer.Stop()
+		}()
 
-		go func() {
-			defer func() {
-				mutex.Lock()
-				close(ch)
-				mutex.Unlock()
-			}()
-			defer close(next)
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-done:
-					return
-				case <-time.After(timespan.duration()):
-					mutex.Lock()
-					if empty {
-						mutex.Unlock()
-						continue
-					}
-					close(ch)
-					empty = true
-					ch = option.buildChannel()
-					if !Of(FromChannel(ch)).SendContext(ctx, next) {
-						close(done)
-						return
-					}
-					mutex.Unlock()
-				}
+		rotate := func() bool {
+			timer.Stop()
+			timer = clock.NewTimer(timespan.duration())
+			if iCount == 0 {
+				return true
 			}
-		}()
+			close(ch)
+			iCount = 0
+			ch = option.buildChannel()
+			return Of(FromChannel(ch)).SendContext(ctx, next)
+		}
 
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case <-done:
-				return
 			case item, ok := <-observe:
 				if !ok {
-					close(done)
 					return
 				}
-				if item.Error() {
-					mutex.Lock()
-					if !item.SendContext(ctx, ch) {
-						mutex.Unlock()
-						close(done)
-						return
-					}
-					mutex.Unlock()
-					if option.getErrorStrategy() == StopOnError {
-						close(done)
-						return
-					}
-				}
-				mutex.Lock()
 				if !item.SendContext(ctx, ch) {
-					mutex.Unlock()
 					return
 				}
-				empty = false
-				mutex.Unlock()
+				if item.Error() && option.getErrorStrategy() == StopOnError {
+					return
+				}
+				iCount++
+				if count > 0 && iCount == count && !rotate() {
+					return
+				}
+			case <-timer.C():
+				if !rotate() {
+					return
+				}
 			}
 		}
 	}
@@ -2806,98 +4365,88 @@ func (o *ObservableImpl) WindowWithTime(timespan Duration, opts ...Option) Obser
 	return customObservableOperator(f, opts...)
 }
 
-// WindowWithTimeOrCount periodically subdivides items from an Observable into Observables based on timed windows or a specific size
-// and emit them rather than emitting the items one at a time.
-func (o *ObservableImpl) WindowWithTimeOrCount(timespan Duration, count int, opts ...Option) Observable {
-	if timespan == nil {
-		return Thrown(IllegalInputError{error: "timespan must no be nil"})
-	}
-	if count < 0 {
-		return Thrown(IllegalInputError{error: "count must be positive or nil"})
-	}
-
+// WithLatestFrom combines each item emitted by the source Observable with the latest item emitted by the other
+// Observable. The items emitted before the other Observable emits anything are dropped; its completion does not
+// complete the resulting Observable, but its errors are emitted.
+func (o *ObservableImpl) WithLatestFrom(other Observable, combiner Func2, opts ...Option) Observable {
 	f := func(ctx context.Context, next chan Item, option Option, opts ...Option) {
-		observe := o.Observe(opts...)
-		ch := option.buildChannel()
-		done := make(chan struct{})
+		wg := sync.WaitGroup{}
+		defer wg.Wait()
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
 		mutex := sync.Mutex{}
-		iCount := 0
-		if !Of(FromChannel(ch)).SendContext(ctx, next) {
-			return
-		}
+		var latest interface{}
+		hasLatest := false
+		stopOnError := option.getErrorStrategy() == StopOnError
 
+		wg.Add(1)
 		go func() {
-			defer func() {
-				mutex.Lock()
-				close(ch)
-				mutex.Unlock()
-			}()
-			defer close(next)
+			defer wg.Done()
+			observe := other.Observe(append(opts[:len(opts):len(opts)], WithContext(ctx))...)
 			for {
 				select {
 				case <-ctx.Done():
 					return
-				case <-done:
-					return
-				case <-time.After(timespan.duration()):
-					mutex.Lock()
-					if iCount == 0 {
-						mutex.Unlock()
-						continue
-					}
-					close(ch)
-					iCount = 0
-					ch = option.buildChannel()
-					if !Of(FromChannel(ch)).SendContext(ctx, next) {
-						close(done)
+				case item, ok := <-observe:
+					if !ok {
 						return
 					}
+					mutex.Lock()
+					if item.Error() {
+						item.SendContext(ctx, next)
+						if stopOnError {
+							cancel()
+						}
+					} else {
+						latest = item.V
+						hasLatest = true
+					}
 					mutex.Unlock()
 				}
 			}
 		}()
 
+		observe := o.Observe(append(opts[:len(opts):len(opts)], WithContext(ctx))...)
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case <-done:
-				return
 			case item, ok := <-observe:
 				if !ok {
-					close(done)
+					return
+				}
+				mutex.Lock()
+				// The other Observable may have failed while waiting for the lock
+				if ctx.Err() != nil {
+					mutex.Unlock()
 					return
 				}
 				if item.Error() {
-					mutex.Lock()
-					if !item.SendContext(ctx, ch) {
-						mutex.Unlock()
-						close(done)
-						return
-					}
+					item.SendContext(ctx, next)
 					mutex.Unlock()
-					if option.getErrorStrategy() == StopOnError {
-						close(done)
+					if stopOnError {
 						return
 					}
+					continue
 				}
-				mutex.Lock()
-				if !item.SendContext(ctx, ch) {
+				if !hasLatest {
 					mutex.Unlock()
-					return
+					continue
 				}
-				iCount++
-				if iCount == count {
-					close(ch)
-					iCount = 0
-					ch = option.buildChannel()
-					if !Of(FromChannel(ch)).SendContext(ctx, next) {
-						mutex.Unlock()
-						close(done)
+				v, err := combiner(ctx, item.V, latest)
+				if err != nil {
+					Error(err).SendContext(ctx, next)
+					mutex.Unlock()
+					if stopOnError {
 						return
 					}
+					continue
 				}
+				sent := Of(v).SendContext(ctx, next)
 				mutex.Unlock()
+				if !sent {
+					return
+				}
 			}
 		}
 	}
@@ -2905,56 +4454,62 @@ func (o *ObservableImpl) WindowWithTimeOrCount(timespan Duration, count int, opt
 	return customObservableOperator(f, opts...)
 }
 
-// ZipFromIterable merges the emissions of an Iterable via a specified function
-// and emit single items for each combination based on the results of this function.
-func (o *ObservableImpl) ZipFromIterable(iterable Iterable, zipper Func2, opts ...Option) Observable {
-	option := parseOptions(opts...)
-	next := option.buildChannel()
-	ctx := option.buildContext()
+// WriteTo writes the items of an Observable to w, each item being marshalled first. It blocks until the
+// Observable completes and returns the number of items written, along with the first error: emitted by the
+// Observable or returned by the marshaller or w. A marshalling or writing error stops the observation.
+// Cannot be run in parallel.
+func (o *ObservableImpl) WriteTo(w io.Writer, marshaller Marshaller, opts ...Option) (int, error) {
+	op := &writeToOperator{
+		w:          w,
+		marshaller: marshaller,
+	}
+	<-observable(o, func() operator {
+		return op
+	}, true, false, opts...).Run()
+	return op.count, op.firstErr
+}
 
-	go func() {
-		defer close(next)
-		it1 := o.Observe(opts...)
-		it2 := iterable.Observe(opts...)
-	loop:
-		for {
-			select {
-			case <-ctx.Done():
-				break loop
-			case i1, ok := <-it1:
-				if !ok {
-					break loop
-				}
-				if i1.Error() {
-					i1.SendContext(ctx, next)
-					return
-				}
-				for {
-					select {
-					case <-ctx.Done():
-						break loop
-					case i2, ok := <-it2:
-						if !ok {
-							break loop
-						}
-						if i2.Error() {
-							i2.SendContext(ctx, next)
-							return
-						}
-						v, err := zipper(ctx, i1.V, i2.V)
-						if err != nil {
-							Error(err).SendContext(ctx, next)
-							return
-						}
-						Of(v).SendContext(ctx, next)
-						continue loop
-					}
-				}
-			}
-		}
-	}()
+type writeToOperator struct {
+	w          io.Writer
+	marshaller Marshaller
+	count      int
+	firstErr   error
+}
 
-	return &ObservableImpl{
-		iterable: newChannelIterable(next),
+func (op *writeToOperator) next(_ context.Context, item Item, _ chan<- Item, operatorOptions operatorOptions) {
+	b, err := op.marshaller(item.V)
+	if err == nil {
+		_, err = op.w.Write(b)
+	}
+	if err != nil {
+		op.setErr(err)
+		operatorOptions.complete()
+		return
+	}
+	op.count++
+}
+
+func (op *writeToOperator) err(_ context.Context, item Item, _ chan<- Item, operatorOptions operatorOptions) {
+	op.setErr(item.E)
+	operatorOptions.stop()
+}
+
+func (op *writeToOperator) setErr(err error) {
+	if op.firstErr == nil {
+		op.firstErr = err
 	}
 }
+
+func (op *writeToOperator) end(_ context.Context, _ chan<- Item) {
+}
+
+func (op *writeToOperator) gatherNext(_ context.Context, _ Item, _ chan<- Item, _ operatorOptions) {
+}
+
+// ZipFromIterable merges the emissions of an Iterable via a specified function
+// and emit single items for each combination based on the results of this function.
+func (o *ObservableImpl) ZipFromIterable(iterable Iterable, zipper Func2, opts ...Option) Observable {
+	return zip([]Iterable{o, iterable}, func(ctx context.Context, values []interface{}) (interface{}, error) {
+		return zipper(ctx, values[0], values[1])
+	}, opts...)
+}
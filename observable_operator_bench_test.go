@@ -128,3 +128,96 @@ func Benchmark_Map_Parallel(b *testing.B) {
 		<-obs.Run()
 	}
 }
+
+func Benchmark_Map_Fused(b *testing.B) {
+	benchmarkMapChain(b)
+}
+
+func Benchmark_Map_Unfused(b *testing.B) {
+	// An operator with its own channel capacity is not fused
+	benchmarkMapChain(b, WithUnbufferedChannel())
+}
+
+func benchmarkMapChain(b *testing.B, opts ...Option) {
+	identity := func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	}
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		obs := Range(0, benchNumberOfElementsSmall, WithBufferedChannel(benchChannelCap)).
+			Map(identity, opts...).
+			Filter(func(interface{}) bool {
+				return true
+			}, opts...).
+			Map(identity, opts...).
+			Map(identity, opts...)
+		b.StartTimer()
+		<-obs.Run()
+	}
+}
+
+type benchRecord struct {
+	id      int
+	payload [64]byte
+}
+
+func Benchmark_Map_Boxed(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		obs := Range(0, benchNumberOfElementsSmall, WithBufferedChannel(benchChannelCap)).
+			Map(func(_ context.Context, i interface{}) (interface{}, error) {
+				// A value is allocated per item
+				return benchRecord{id: i.(int)}, nil
+			})
+		b.StartTimer()
+		<-obs.ForEach(func(interface{}) {}, func(error) {}, func() {})
+	}
+}
+
+func Benchmark_Map_Pooled(b *testing.B) {
+	b.ReportAllocs()
+	pool := NewValuePool(func() interface{} {
+		return &benchRecord{}
+	}, func(v interface{}) bool {
+		_, ok := v.(*benchRecord)
+		return ok
+	})
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		obs := Range(0, benchNumberOfElementsSmall, WithBufferedChannel(benchChannelCap)).
+			Map(func(_ context.Context, i interface{}) (interface{}, error) {
+				record := pool.Get().(*benchRecord)
+				record.id = i.(int)
+				return record, nil
+			})
+		b.StartTimer()
+		<-obs.ForEach(func(interface{}) {}, func(error) {}, func() {}, WithValuePool(pool))
+	}
+}
+
+func Benchmark_Map_Batched(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		obs := Range(0, benchNumberOfElementsSmall).
+			Map(func(_ context.Context, i interface{}) (interface{}, error) {
+				return i, nil
+			}, WithBatchSize(100))
+		b.StartTimer()
+		<-obs.Run()
+	}
+}
+
+func Benchmark_Map_Unbatched(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		obs := Range(0, benchNumberOfElementsSmall).
+			Map(func(_ context.Context, i interface{}) (interface{}, error) {
+				return i, nil
+			})
+		b.StartTimer()
+		<-obs.Run()
+	}
+}
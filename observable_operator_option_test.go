@@ -52,6 +52,23 @@ func Test_Observable_Option_ComposedCapacity(t *testing.T) {
 	assert.Equal(t, 12, cap(obs2.Observe()))
 }
 
+func Test_Observable_Option_OperatorCapacity(t *testing.T) {
+	obs := Just(1)().Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	}, WithBufferedChannel(3))
+	assert.Equal(t, 3, cap(obs.Observe(WithBufferedChannel(7))))
+	assert.Equal(t, 7, cap(Just(1)().Observe(WithBufferedChannel(7))))
+}
+
+func Test_Observable_Option_UnbufferedChannel(t *testing.T) {
+	obs := Just(1)().Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	}, WithUnbufferedChannel())
+	ch := obs.Observe(WithBufferedChannel(7))
+	assert.Equal(t, 0, cap(ch))
+	assert.Equal(t, 1, (<-ch).V)
+}
+
 func Test_Observable_Option_ContextPropagation(t *testing.T) {
 	expectedCtx := context.Background()
 	var gotCtx context.Context
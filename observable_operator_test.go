@@ -1,10 +1,12 @@
 package rxgo
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -31,6 +33,16 @@ func Test_Observable_All_False(t *testing.T) {
 		HasItem(false), HasNoError())
 }
 
+func Test_Observable_All_Error(t *testing.T) {
+	Assert(context.Background(), t, testObservable(1, errFoo, 3).All(predicateAllInt),
+		IsEmpty(), HasError(errFoo))
+}
+
+func Test_Observable_All_ContinueOnError(t *testing.T) {
+	Assert(context.Background(), t, testObservable(1, errFoo, "x", 3).All(predicateAllInt, WithErrorStrategy(ContinueOnError)),
+		HasItems(false), HasError(errFoo))
+}
+
 func Test_Observable_All_Parallel_True(t *testing.T) {
 	Assert(context.Background(), t, Range(1, 10000).All(predicateAllInt, WithCPUPool()),
 		HasItem(true), HasNoError())
@@ -136,6 +148,14 @@ func Test_Observable_AverageInt64(t *testing.T) {
 	Assert(context.Background(), t, testObservable(1.1, 2.2, 3.3).AverageInt64(), HasAnError())
 }
 
+func Test_Observable_AverageInt_Error(t *testing.T) {
+	Assert(context.Background(), t, testObservable(1, errFoo, 3).AverageInt(), IsEmpty(), HasError(errFoo))
+}
+
+func Test_Observable_AverageInt_Parallel(t *testing.T) {
+	Assert(context.Background(), t, Range(1, 999).AverageInt(WithCPUPool()), HasItem(500))
+}
+
 func Test_Observable_BackOffRetry(t *testing.T) {
 	i := 0
 	backOffCfg := backoff.NewExponentialBackOff()
@@ -164,6 +184,119 @@ func Test_Observable_BackOffRetry_Error(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(1, 2, 1, 2, 1, 2, 1, 2), HasError(errFoo))
 }
 
+func Test_Observable_BlockingFirst(t *testing.T) {
+	v, err := testObservable(1, 2, 3).BlockingFirst(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	_, err = testObservable(errFoo, 2).BlockingFirst(context.Background())
+	assert.Equal(t, errFoo, err)
+
+	_, err = Empty().BlockingFirst(context.Background())
+	assert.Equal(t, IllegalInputError{error: "no item emitted"}, err)
+}
+
+func Test_Observable_BlockingFirst_Disposed(t *testing.T) {
+	stopped := make(chan struct{})
+	obs := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		defer close(stopped)
+		for i := 0; Of(i).SendContext(ctx, next); i++ {
+		}
+	}})
+	v, err := obs.BlockingFirst(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, v)
+	<-stopped
+}
+
+func Test_Observable_BlockingFirst_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := Never().BlockingFirst(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func Test_Observable_BlockingForEach(t *testing.T) {
+	var got []interface{}
+	err := testObservable(1, 2, 3).BlockingForEach(context.Background(), func(i interface{}) error {
+		got = append(got, i)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2, 3}, got)
+}
+
+func Test_Observable_BlockingForEach_Error(t *testing.T) {
+	var got []interface{}
+	err := testObservable(1, errFoo, 3).BlockingForEach(context.Background(), func(i interface{}) error {
+		got = append(got, i)
+		return nil
+	})
+	assert.Equal(t, errFoo, err)
+	assert.Equal(t, []interface{}{1}, got)
+
+	got = nil
+	err = testObservable(1, 2, 3).BlockingForEach(context.Background(), func(i interface{}) error {
+		got = append(got, i)
+		if i == 2 {
+			return errBar
+		}
+		return nil
+	})
+	assert.Equal(t, errBar, err)
+	assert.Equal(t, []interface{}{1, 2}, got)
+}
+
+func Test_Observable_BlockingForEach_Panic(t *testing.T) {
+	err := testObservable(1).BlockingForEach(context.Background(), func(i interface{}) error {
+		panic("foo")
+	}, WithPanicStrategy(RecoverPanic))
+	assert.Equal(t, "foo", err.(PanicError).Value)
+}
+
+func Test_Observable_BlockingForEach_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := Never().BlockingForEach(ctx, func(interface{}) error {
+		return nil
+	})
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func Test_Observable_BlockingLast(t *testing.T) {
+	v, err := testObservable(1, 2, 3).BlockingLast(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+
+	_, err = testObservable(1, errFoo, 3).BlockingLast(context.Background())
+	assert.Equal(t, errFoo, err)
+
+	_, err = Empty().BlockingLast(context.Background())
+	assert.Equal(t, IllegalInputError{error: "no item emitted"}, err)
+}
+
+func Test_Observable_BlockingSubscribe(t *testing.T) {
+	var got []interface{}
+	completed := false
+	err := testObservable(1, 2).BlockingSubscribe(context.Background(), funcObserver{
+		next:      func(i interface{}) { got = append(got, i) },
+		err:       func(error) { assert.FailNow(t, "unexpected error") },
+		completed: func() { completed = true },
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2}, got)
+	assert.True(t, completed)
+
+	var observed error
+	err = testObservable(1, errFoo).BlockingSubscribe(context.Background(), funcObserver{
+		next:      func(interface{}) {},
+		err:       func(err error) { observed = err },
+		completed: func() { assert.FailNow(t, "unexpected completion") },
+	})
+	assert.Equal(t, errFoo, err)
+	assert.Equal(t, errFoo, observed)
+}
+
 func Test_Observable_BufferWithCount(t *testing.T) {
 	obs := testObservable(1, 2, 3, 4, 5, 6).BufferWithCount(3)
 	Assert(context.Background(), t, obs, HasItems([]interface{}{1, 2, 3}, []interface{}{4, 5, 6}))
@@ -184,6 +317,39 @@ func Test_Observable_BufferWithCount_InputError(t *testing.T) {
 	Assert(context.Background(), t, obs, HasAnError())
 }
 
+func Test_Observable_BufferWithCountAndSkip_Overlapping(t *testing.T) {
+	obs := testObservable(1, 2, 3, 4, 5).BufferWithCountAndSkip(3, 1)
+	Assert(context.Background(), t, obs, HasItems(
+		[]interface{}{1, 2, 3},
+		[]interface{}{2, 3, 4},
+		[]interface{}{3, 4, 5},
+		[]interface{}{4, 5},
+		[]interface{}{5},
+	), HasNoError())
+}
+
+func Test_Observable_BufferWithCountAndSkip_Gap(t *testing.T) {
+	obs := testObservable(1, 2, 3, 4, 5, 6, 7).BufferWithCountAndSkip(2, 3)
+	Assert(context.Background(), t, obs, HasItems(
+		[]interface{}{1, 2},
+		[]interface{}{4, 5},
+		[]interface{}{7},
+	), HasNoError())
+}
+
+func Test_Observable_BufferWithCountAndSkip_Error(t *testing.T) {
+	obs := testObservable(1, 2, 3, errFoo, 4).BufferWithCountAndSkip(2, 1)
+	Assert(context.Background(), t, obs, HasItems(
+		[]interface{}{1, 2},
+		[]interface{}{2, 3},
+	), HasError(errFoo))
+}
+
+func Test_Observable_BufferWithCountAndSkip_InputError(t *testing.T) {
+	Assert(context.Background(), t, testObservable(1).BufferWithCountAndSkip(0, 1), HasAnError())
+	Assert(context.Background(), t, testObservable(1).BufferWithCountAndSkip(1, 0), HasAnError())
+}
+
 func Test_Observable_BufferWithTime_Single(t *testing.T) {
 	obs := Just(1, 2, 3)().BufferWithTime(WithDuration(30 * time.Millisecond))
 	Assert(context.Background(), t, obs, HasItems(
@@ -227,6 +393,48 @@ func Test_Observable_BufferWithTimeOrCount(t *testing.T) {
 	}))
 }
 
+func Test_Observable_BufferWithTime_TestScheduler(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	// Unbuffered, so that an item is sent once the previous one is processed
+	ch := make(chan Item)
+	observe := FromChannel(ch).BufferWithTime(WithDuration(time.Second), WithClock(s), WithBufferedChannel(10)).Observe()
+	ch <- Of(1)
+	ch <- Of(2)
+	s.AdvanceTimeBy(time.Second)
+	ch <- Of(3)
+	s.AdvanceTimeBy(time.Second)
+	s.AdvanceTimeBy(time.Second)
+	close(ch)
+	Assert(context.Background(), t, FromChannel(observe), HasItems([]interface{}{1, 2}, []interface{}{3}), HasNoError())
+}
+
+func Test_Observable_BufferWithTime_Error(t *testing.T) {
+	obs := testObservable(1, 2, errFoo, 3).BufferWithTime(WithDuration(time.Hour))
+	Assert(context.Background(), t, obs, HasItems([]interface{}{1, 2}), HasError(errFoo))
+}
+
+func Test_Observable_BufferWithTimeOrCount_TestScheduler(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	ch := make(chan Item)
+	observe := FromChannel(ch).BufferWithTimeOrCount(WithDuration(time.Second), 2, WithClock(s), WithBufferedChannel(10)).Observe()
+	ch <- Of(1)
+	ch <- Of(2)
+	ch <- Of(3)
+	s.AdvanceTimeBy(999 * time.Millisecond)
+	ch <- Of(4)
+	ch <- Of(5)
+	s.AdvanceTimeBy(time.Millisecond)
+	s.AdvanceTimeBy(time.Second)
+	close(ch)
+	Assert(context.Background(), t, FromChannel(observe), HasItems(
+		[]interface{}{1, 2}, []interface{}{3, 4}, []interface{}{5}), HasNoError())
+}
+
+func Test_Observable_ConcatWith(t *testing.T) {
+	obs := testObservable(1, 2).ConcatWith(testObservable(3, 4))
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3, 4), HasNoError())
+}
+
 func Test_Observable_Contain(t *testing.T) {
 	predicate := func(i interface{}) bool {
 		switch i := i.(type) {
@@ -245,6 +453,18 @@ func Test_Observable_Contain(t *testing.T) {
 		HasItem(false))
 }
 
+func Test_Observable_Contains_Error(t *testing.T) {
+	Assert(context.Background(), t, testObservable(1, errFoo, 2).Contains(func(i interface{}) bool {
+		return i == 2
+	}), IsEmpty(), HasError(errFoo))
+}
+
+func Test_Observable_Contains_ContinueOnError(t *testing.T) {
+	Assert(context.Background(), t, testObservable(2, errFoo, 2).Contains(func(i interface{}) bool {
+		return i == 2
+	}, WithErrorStrategy(ContinueOnError)), HasItems(true), HasNoError())
+}
+
 func Test_Observable_Contain_Parallel(t *testing.T) {
 	predicate := func(i interface{}) bool {
 		switch i := i.(type) {
@@ -273,6 +493,10 @@ func Test_Observable_Count_Parallel(t *testing.T) {
 		HasItem(int64(10001)))
 }
 
+func Test_Observable_Count_Error(t *testing.T) {
+	Assert(context.Background(), t, testObservable(1, errFoo, 3).Count(), IsEmpty(), HasError(errFoo))
+}
+
 func Test_Observable_Debounce(t *testing.T) {
 	ctx, obs, d := timeCausality(1, tick, 2, tick, 3, 4, 5, tick, 6, tick)
 	Assert(context.Background(), t, obs.Debounce(d, WithBufferedChannel(10), WithContext(ctx)),
@@ -285,6 +509,31 @@ func Test_Observable_Debounce_Error(t *testing.T) {
 		HasItems(1, 2), HasError(errFoo))
 }
 
+func Test_Observable_Debounce_Completed(t *testing.T) {
+	obs := testObservable(1, 2, 3).Debounce(WithDuration(time.Hour))
+	Assert(context.Background(), t, obs, HasItems(3), HasNoError())
+}
+
+func Test_Observable_Debounce_TestScheduler(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	ch := make(chan Item)
+	observe := FromChannel(ch).Debounce(WithDuration(time.Second), WithClock(s), WithBufferedChannel(10)).Observe()
+	// A timer is created for each loop, i.e. once started and after each item or emission
+	ch <- Of(1)
+	awaitCreated(s, 2)
+	s.AdvanceTimeBy(time.Second)
+	assert.Equal(t, 1, (<-observe).V)
+	ch <- Of(2)
+	awaitCreated(s, 4)
+	s.AdvanceTimeBy(500 * time.Millisecond)
+	ch <- Of(3)
+	awaitCreated(s, 5)
+	s.AdvanceTimeBy(time.Second)
+	assert.Equal(t, 3, (<-observe).V)
+	close(ch)
+	Assert(context.Background(), t, FromChannel(observe), IsEmpty(), HasNoError())
+}
+
 func Test_Observable_DefaultIfEmpty_Empty(t *testing.T) {
 	obs := Empty().DefaultIfEmpty(3)
 	Assert(context.Background(), t, obs, HasItems(3))
@@ -295,6 +544,11 @@ func Test_Observable_DefaultIfEmpty_NotEmpty(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(1, 2))
 }
 
+func Test_Observable_DefaultIfEmpty_Error(t *testing.T) {
+	obs := testObservable(errFoo).DefaultIfEmpty(3)
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+}
+
 func Test_Observable_DefaultIfEmpty_Parallel_Empty(t *testing.T) {
 	obs := Empty().DefaultIfEmpty(3, WithCPUPool())
 	Assert(context.Background(), t, obs, HasItems(3))
@@ -305,6 +559,86 @@ func Test_Observable_DefaultIfEmpty_Parallel_NotEmpty(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(1, 2))
 }
 
+func Test_Observable_Delay(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	ch := make(chan Item)
+	observe := FromChannel(ch).Delay(WithDuration(time.Second), WithClock(s), WithBufferedChannel(10)).Observe()
+	ch <- Of(1)
+	awaitCreated(s, 1)
+	s.AdvanceTimeBy(500 * time.Millisecond)
+	ch <- Of(2)
+	awaitCreated(s, 2)
+	s.AdvanceTimeBy(600 * time.Millisecond)
+	assert.Equal(t, 1, (<-observe).V)
+	assert.Equal(t, 0, len(observe))
+	awaitCreated(s, 3)
+	s.AdvanceTimeBy(400 * time.Millisecond)
+	assert.Equal(t, 2, (<-observe).V)
+	close(ch)
+	Assert(context.Background(), t, FromChannel(observe), IsEmpty(), HasNoError())
+}
+
+func Test_Observable_Delay_Completed(t *testing.T) {
+	obs := testObservable(1, 2, 3).Delay(WithDuration(time.Millisecond))
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
+}
+
+func Test_Observable_Delay_Error(t *testing.T) {
+	obs := testObservable(1, errFoo, 2).Delay(WithDuration(time.Millisecond))
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errFoo))
+}
+
+func Test_Observable_Delay_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	observe := testObservable(1).Delay(WithDuration(time.Hour), WithContext(ctx)).Observe()
+	cancel()
+	Assert(context.Background(), t, FromChannel(observe), IsEmpty(), HasNoError())
+}
+
+func Test_Observable_DelaySubscription(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	subscribed := make(chan struct{})
+	source := Defer([]Producer{func(_ context.Context, next chan<- Item) {
+		close(subscribed)
+		next <- Of(1)
+	}})
+	observe := source.DelaySubscription(WithDuration(time.Second), WithClock(s)).Observe()
+	s.BlockUntil(1)
+	s.AdvanceTimeBy(900 * time.Millisecond)
+	select {
+	case <-subscribed:
+		assert.FailNow(t, "subscribed too early")
+	default:
+	}
+	s.AdvanceTimeBy(100 * time.Millisecond)
+	<-subscribed
+	Assert(context.Background(), t, FromChannel(observe), HasItems(1), HasNoError())
+}
+
+func Test_Observable_Dematerialize(t *testing.T) {
+	obs := testObservable(
+		Notification{Kind: NextNotification, Value: 1},
+		Notification{Kind: NextNotification, Value: 2},
+		Notification{Kind: CompletedNotification},
+		Notification{Kind: NextNotification, Value: 3},
+	).Dematerialize()
+	Assert(context.Background(), t, obs, HasItems(1, 2), HasNoError())
+}
+
+func Test_Observable_Dematerialize_Error(t *testing.T) {
+	obs := testObservable(
+		Notification{Kind: NextNotification, Value: 1},
+		Notification{Kind: ErrorNotification, Error: errFoo},
+		Notification{Kind: NextNotification, Value: 2},
+	).Dematerialize()
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errFoo))
+}
+
+func Test_Observable_Dematerialize_IllegalInput(t *testing.T) {
+	obs := testObservable(1).Dematerialize()
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(IllegalInputError{error: "expected type: Notification, got: int"}))
+}
+
 func Test_Observable_Distinct(t *testing.T) {
 	obs := testObservable(1, 2, 2, 1, 3).Distinct(func(_ context.Context, item interface{}) (interface{}, error) {
 		return item, nil
@@ -367,6 +701,76 @@ func Test_Observable_DistinctUntilChanged_Parallel(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(1, 2, 1, 3))
 }
 
+func Test_Observable_DistinctUntilChanged_NilFirst(t *testing.T) {
+	obs := Defer([]Producer{func(_ context.Context, next chan<- Item) {
+		next <- Of(nil)
+		next <- Of(nil)
+		next <- Of(1)
+	}}).DistinctUntilChanged(func(_ context.Context, item interface{}) (interface{}, error) {
+		return item, nil
+	})
+	Assert(context.Background(), t, obs, HasItems(nil, 1))
+}
+
+func Test_Observable_DistinctUntilChangedWithComparator(t *testing.T) {
+	obs := testObservable([]int{1, 2}, []int{1, 2}, []int{3}, []int{1, 2}).
+		DistinctUntilChangedWithComparator(func(a interface{}, b interface{}) int {
+			return len(a.([]int)) - len(b.([]int))
+		})
+	Assert(context.Background(), t, obs, HasItems([]int{1, 2}, []int{3}, []int{1, 2}))
+}
+
+func Test_Observable_DistinctWithCapacity(t *testing.T) {
+	obs := testObservable(1, 2, 1, 3, 2, 1).DistinctWithCapacity(func(_ context.Context, item interface{}) (interface{}, error) {
+		return item, nil
+	}, 2)
+	// 2 is forgotten when 3 is seen as 1 was seen more recently
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3, 2, 1))
+}
+
+func Test_Observable_DistinctWithCapacity_Error(t *testing.T) {
+	obs := testObservable(1, 2, 2, errFoo, 3).DistinctWithCapacity(func(_ context.Context, item interface{}) (interface{}, error) {
+		return item, nil
+	}, 10)
+	Assert(context.Background(), t, obs, HasItems(1, 2), HasError(errFoo))
+}
+
+func Test_Observable_DistinctWithCapacity_InvalidCapacity(t *testing.T) {
+	obs := testObservable(1).DistinctWithCapacity(func(_ context.Context, item interface{}) (interface{}, error) {
+		return item, nil
+	}, 0)
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(IllegalInputError{error: "capacity must be strictly positive"}))
+}
+
+func Test_Observable_DoFinally(t *testing.T) {
+	called := make(chan struct{})
+	obs := testObservable(1, errFoo, 2).DoFinally(func() {
+		close(called)
+	})
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errFoo))
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		assert.FailNow(t, "finally function not called")
+	}
+}
+
+func Test_Observable_DoFinally_Disposed(t *testing.T) {
+	called := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	observe := Never().DoFinally(func() {
+		close(called)
+	}).Observe(WithContext(ctx))
+	cancel()
+	for range observe {
+	}
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		assert.FailNow(t, "finally function not called")
+	}
+}
+
 func Test_Observable_DoOnCompleted_NoError(t *testing.T) {
 	called := false
 	<-testObservable(1, 2, 3).DoOnCompleted(func() {
@@ -383,6 +787,31 @@ func Test_Observable_DoOnCompleted_Error(t *testing.T) {
 	assert.True(t, called)
 }
 
+func Test_Observable_DoOnDispose(t *testing.T) {
+	called := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	observe := Never().DoOnDispose(func() {
+		close(called)
+	}).Observe(WithContext(ctx))
+	cancel()
+	for range observe {
+	}
+	select {
+	case <-called:
+	case <-time.After(time.Second):
+		assert.FailNow(t, "dispose function not called")
+	}
+}
+
+func Test_Observable_DoOnDispose_Completed(t *testing.T) {
+	var called int32
+	obs := testObservable(1, 2).DoOnDispose(func() {
+		atomic.StoreInt32(&called, 1)
+	})
+	Assert(context.Background(), t, obs, HasItems(1, 2), HasNoError())
+	assert.Equal(t, int32(0), atomic.LoadInt32(&called))
+}
+
 func Test_Observable_DoOnError_NoError(t *testing.T) {
 	var got error
 	<-testObservable(1, 2, 3).DoOnError(func(err error) {
@@ -415,6 +844,16 @@ func Test_Observable_DoOnNext_Error(t *testing.T) {
 	assert.Equal(t, []interface{}{1}, s)
 }
 
+func Test_Observable_DoOnSubscribe(t *testing.T) {
+	var subscriptions int32
+	obs := Just(1, 2)().DoOnSubscribe(func() {
+		atomic.AddInt32(&subscriptions, 1)
+	})
+	Assert(context.Background(), t, obs, HasItems(1, 2))
+	Assert(context.Background(), t, obs, HasItems(1, 2))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&subscriptions))
+}
+
 func Test_Observable_ElementAt(t *testing.T) {
 	obs := Range(0, 10000).ElementAt(10000)
 	Assert(context.Background(), t, obs, HasItems(10000))
@@ -430,6 +869,21 @@ func Test_Observable_ElementAt_Error(t *testing.T) {
 	Assert(context.Background(), t, obs, IsEmpty(), HasAnError())
 }
 
+func Test_Observable_ElementAt_OutOfBound(t *testing.T) {
+	obs := testObservable(0, 1, 2).ElementAt(3)
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(IndexOutOfBoundError{error: "index 3, length 3"}))
+}
+
+func Test_Observable_ElementAt_SourceError(t *testing.T) {
+	obs := testObservable(0, errFoo, 1).ElementAt(3)
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+}
+
+func Test_Observable_ElementAt_ContinueOnError(t *testing.T) {
+	obs := testObservable(0, errFoo, 1, 2).ElementAt(1, WithErrorStrategy(ContinueOnError))
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errFoo))
+}
+
 func Test_Observable_Error_NoError(t *testing.T) {
 	assert.NoError(t, testObservable(1, 2, 3).Error())
 }
@@ -463,6 +917,94 @@ func Test_Observable_Errors_MultipleErrorFromMap(t *testing.T) {
 	assert.Equal(t, 2, len(errs))
 }
 
+func Test_Observable_Expand(t *testing.T) {
+	obs := testObservable(1).Expand(func(item Item) Observable {
+		if i := item.V.(int); i < 8 {
+			return Just(i * 2)()
+		}
+		return Empty()
+	}, 0)
+	Assert(context.Background(), t, obs, HasItems(1, 2, 4, 8), HasNoError())
+}
+
+func Test_Observable_Expand_Tree(t *testing.T) {
+	children := map[string][]interface{}{
+		"a": {"b", "c"},
+		"b": {"d"},
+		"c": {"e", "f"},
+	}
+	obs := testObservable("a").Expand(func(item Item) Observable {
+		return Just(children[item.V.(string)]...)()
+	}, 2)
+	Assert(context.Background(), t, obs, HasItemsNoOrder("a", "b", "c", "d", "e", "f"), HasNoError())
+}
+
+func Test_Observable_Expand_MaxConcurrency(t *testing.T) {
+	var active, peak int32
+	obs := testObservable(1, 2, 3, 4, 5).Expand(func(item Item) Observable {
+		return Defer([]Producer{func(_ context.Context, _ chan<- Item) {
+			n := atomic.AddInt32(&active, 1)
+			for {
+				m := atomic.LoadInt32(&peak)
+				if n <= m || atomic.CompareAndSwapInt32(&peak, m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&active, -1)
+		}})
+	}, 2)
+	Assert(context.Background(), t, obs, HasItemsNoOrder(1, 2, 3, 4, 5), HasNoError())
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+}
+
+func Test_Observable_Expand_Error(t *testing.T) {
+	obs := testObservable(1).Expand(func(item Item) Observable {
+		if item.V.(int) < 3 {
+			return Just(item.V.(int) + 1)()
+		}
+		return Thrown(errFoo)
+	}, 0)
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasError(errFoo))
+}
+
+func Test_Observable_Expand_Trampoline(t *testing.T) {
+	obs := testObservable(1).Expand(func(item Item) Observable {
+		if i := item.V.(int); i < 10000 {
+			return Just(i + 1)()
+		}
+		return Empty()
+	}, 0, WithScheduler(NewTrampolineScheduler()))
+	items, err := obs.ToSlice(0)
+	assert.NoError(t, err)
+	assert.Len(t, items, 10000)
+	assert.Equal(t, 10000, items[len(items)-1])
+}
+
+func Test_Observable_Expand_Scheduler_Tree(t *testing.T) {
+	children := map[string][]interface{}{
+		"a": {"b", "c"},
+		"b": {"d"},
+		"c": {"e", "f"},
+	}
+	pool := NewPoolScheduler(2)
+	defer pool.Stop()
+	obs := testObservable("a").Expand(func(item Item) Observable {
+		return Just(children[item.V.(string)]...)()
+	}, 0, WithScheduler(pool))
+	Assert(context.Background(), t, obs, HasItemsNoOrder("a", "b", "c", "d", "e", "f"), HasNoError())
+}
+
+func Test_Observable_Expand_Trampoline_Error(t *testing.T) {
+	obs := testObservable(1).Expand(func(item Item) Observable {
+		if item.V.(int) < 3 {
+			return Just(item.V.(int) + 1)()
+		}
+		return Thrown(errFoo)
+	}, 0, WithScheduler(NewTrampolineScheduler()))
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasError(errFoo))
+}
+
 func Test_Observable_Filter(t *testing.T) {
 	obs := testObservable(1, 2, 3, 4).Filter(
 		func(i interface{}) bool {
@@ -499,6 +1041,11 @@ func Test_Observable_First_Parallel_Empty(t *testing.T) {
 	Assert(context.Background(), t, obs, IsEmpty())
 }
 
+func Test_Observable_First_ContinueOnError(t *testing.T) {
+	obs := testObservable(errFoo, 1, 2).First(WithErrorStrategy(ContinueOnError))
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errFoo))
+}
+
 func Test_Observable_FirstOrDefault_NotEmpty(t *testing.T) {
 	obs := testObservable(1, 2, 3).FirstOrDefault(10)
 	Assert(context.Background(), t, obs, HasItem(1))
@@ -519,6 +1066,11 @@ func Test_Observable_FirstOrDefault_Parallel_Empty(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItem(10))
 }
 
+func Test_Observable_FirstOrDefault_Error(t *testing.T) {
+	obs := testObservable(errFoo).FirstOrDefault(10)
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+}
+
 func Test_Observable_FlatMap(t *testing.T) {
 	obs := testObservable(1, 2, 3).FlatMap(func(i Item) Observable {
 		return testObservable(i.V.(int)+1, i.V.(int)*10)
@@ -563,6 +1115,45 @@ func Test_Observable_FlatMap_Parallel_Error1(t *testing.T) {
 	Assert(context.Background(), t, obs, HasError(errFoo))
 }
 
+func Test_Observable_FlatMap_Parallel_MaxConcurrency(t *testing.T) {
+	var current, max int32
+	items := make([]interface{}, 20)
+	for i := range items {
+		items[i] = i
+	}
+	obs := Just(items...)().FlatMap(func(i Item) Observable {
+		return Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+			n := atomic.AddInt32(&current, 1)
+			for {
+				m := atomic.LoadInt32(&max)
+				if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&current, -1)
+			next <- i
+		}})
+	}, WithPool(3))
+	Assert(context.Background(), t, obs, CustomPredicate(func(items []interface{}) error {
+		if len(items) != 20 {
+			return fmt.Errorf("expected 20 items, got %d", len(items))
+		}
+		return nil
+	}), HasNoError())
+	assert.True(t, atomic.LoadInt32(&max) <= 3)
+}
+
+func Test_Observable_FlatMap_Parallel_Error2(t *testing.T) {
+	obs := testObservable(1, 2, 3).FlatMap(func(i Item) Observable {
+		if i.V == 2 {
+			return testObservable(errFoo)
+		}
+		return testObservable(i.V.(int)+1, i.V.(int)*10)
+	}, WithPool(2))
+	Assert(context.Background(), t, obs, HasError(errFoo))
+}
+
 func Test_Observable_ForEach_Error(t *testing.T) {
 	count := 0
 	var gotErr error
@@ -665,6 +1256,44 @@ func Test_Observable_GroupBy_Error(t *testing.T) {
 	Assert(context.Background(), t, s[2].(Observable), HasAnError())
 }
 
+func Test_Observable_GroupByDynamic(t *testing.T) {
+	obs := testObservable(1, 2, 3, 4, 5, 6, 7).GroupByDynamic(func(_ context.Context, i interface{}) (interface{}, error) {
+		if i.(int)%2 == 0 {
+			return "even", nil
+		}
+		return "odd", nil
+	}, WithBufferedChannel(10))
+	s, err := obs.ToSlice(0)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	if len(s) != 2 {
+		assert.FailNow(t, "length", "got=%d, expected=%d", len(s), 2)
+	}
+
+	odd := s[0].(GroupedObservable)
+	assert.Equal(t, "odd", odd.Key())
+	Assert(context.Background(), t, odd, HasItems(1, 3, 5, 7), HasNoError())
+	even := s[1].(GroupedObservable)
+	assert.Equal(t, "even", even.Key())
+	Assert(context.Background(), t, even, HasItems(2, 4, 6), HasNoError())
+}
+
+func Test_Observable_GroupByDynamic_Error(t *testing.T) {
+	obs := testObservable(1, 2, 3).GroupByDynamic(func(_ context.Context, i interface{}) (interface{}, error) {
+		if i == 2 {
+			return nil, errFoo
+		}
+		return i, nil
+	}, WithBufferedChannel(10))
+	Assert(context.Background(), t, obs, CustomPredicate(func(items []interface{}) error {
+		if len(items) != 1 || items[0].(GroupedObservable).Key() != 1 {
+			return fmt.Errorf("expected a single group, got %v", items)
+		}
+		return nil
+	}), HasError(errFoo))
+}
+
 func joinTest(t *testing.T, left, right []interface{}, window Duration, expected []int64) {
 	leftObs := testObservable(left...)
 	rightObs := testObservable(right...)
@@ -817,6 +1446,16 @@ func Test_Observable_Last_Empty(t *testing.T) {
 	Assert(context.Background(), t, obs, IsEmpty())
 }
 
+func Test_Observable_Last_Error(t *testing.T) {
+	obs := testObservable(1, errFoo).Last()
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+}
+
+func Test_Observable_Last_ContinueOnError(t *testing.T) {
+	obs := testObservable(1, errFoo, 2).Last(WithErrorStrategy(ContinueOnError))
+	Assert(context.Background(), t, obs, HasItems(2), HasError(errFoo))
+}
+
 func Test_Observable_Last_Parallel_NotEmpty(t *testing.T) {
 	obs := testObservable(1, 2, 3).Last(WithCPUPool())
 	Assert(context.Background(), t, obs, HasItem(3))
@@ -832,6 +1471,11 @@ func Test_Observable_LastOrDefault_NotEmpty(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItem(3))
 }
 
+func Test_Observable_LastOrDefault_Error(t *testing.T) {
+	obs := testObservable(errFoo).LastOrDefault(10)
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+}
+
 func Test_Observable_LastOrDefault_Empty(t *testing.T) {
 	obs := Empty().LastOrDefault(10)
 	Assert(context.Background(), t, obs, HasItem(10))
@@ -847,41 +1491,248 @@ func Test_Observable_LastOrDefault_Parallel_Empty(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItem(10))
 }
 
-func Test_Observable_Map_One(t *testing.T) {
-	obs := testObservable(1, 2, 3).Map(func(_ context.Context, i interface{}) (interface{}, error) {
-		return i.(int) + 1, nil
-	})
-	Assert(context.Background(), t, obs, HasItems(2, 3, 4), HasNoError())
+type funcObserver struct {
+	next      func(interface{})
+	err       func(error)
+	completed func()
 }
 
-func Test_Observable_Map_Multiple(t *testing.T) {
-	obs := testObservable(1, 2, 3).Map(func(_ context.Context, i interface{}) (interface{}, error) {
-		return i.(int) + 1, nil
-	}).Map(func(_ context.Context, i interface{}) (interface{}, error) {
-		return i.(int) * 10, nil
-	})
-	Assert(context.Background(), t, obs, HasItems(20, 30, 40), HasNoError())
+func (f funcObserver) OnNext(i interface{}) {
+	f.next(i)
 }
 
-func Test_Observable_Map_Error(t *testing.T) {
-	obs := testObservable(1, 2, 3, errFoo).Map(func(_ context.Context, i interface{}) (interface{}, error) {
-		return i.(int) + 1, nil
-	})
-	Assert(context.Background(), t, obs, HasItems(2, 3, 4), HasError(errFoo))
+func (f funcObserver) OnError(err error) {
+	f.err(err)
 }
 
-func Test_Observable_Map_ReturnValueAndError(t *testing.T) {
-	obs := testObservable(1).Map(func(_ context.Context, i interface{}) (interface{}, error) {
-		return 2, errFoo
-	})
-	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+func (f funcObserver) OnCompleted() {
+	f.completed()
 }
 
-func Test_Observable_Map_Multiple_Error(t *testing.T) {
-	called := false
-	obs := testObservable(1, 2, 3).Map(func(_ context.Context, i interface{}) (interface{}, error) {
-		return nil, errFoo
-	}).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+// liftTakeWhile is a custom operator forwarding the items until one does not match the predicate.
+func liftTakeWhile(predicate Predicate) ObserverOperator {
+	return func(_ context.Context, downstream Observer) Observer {
+		return funcObserver{
+			next: func(i interface{}) {
+				if !predicate(i) {
+					downstream.OnCompleted()
+					return
+				}
+				downstream.OnNext(i)
+			},
+			err:       downstream.OnError,
+			completed: downstream.OnCompleted,
+		}
+	}
+}
+
+func Test_Observable_Lift(t *testing.T) {
+	obs := testObservable(1, 2, 3, 4, 1).Lift(liftTakeWhile(func(i interface{}) bool {
+		return i.(int) < 3
+	}))
+	Assert(context.Background(), t, obs, HasItems(1, 2), HasNoError())
+}
+
+func Test_Observable_Lift_Error(t *testing.T) {
+	obs := testObservable(1, errFoo, 2).Lift(liftTakeWhile(func(interface{}) bool {
+		return true
+	}))
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errFoo))
+}
+
+func Test_Observable_Lift_DeferredCompletion(t *testing.T) {
+	// Emits the count of items once the source completes, from another goroutine
+	count := func(_ context.Context, downstream Observer) Observer {
+		n := 0
+		return funcObserver{
+			next: func(interface{}) {
+				n++
+			},
+			err: downstream.OnError,
+			completed: func() {
+				go func() {
+					downstream.OnNext(n)
+					downstream.OnCompleted()
+				}()
+			},
+		}
+	}
+	obs := Just(1, 2, 3)().Lift(count)
+	Assert(context.Background(), t, obs, HasItems(3), HasNoError())
+	Assert(context.Background(), t, obs, HasItems(3), HasNoError())
+}
+
+func Test_Observable_Lift_StopsSource(t *testing.T) {
+	stopped := make(chan struct{})
+	obs := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		defer close(stopped)
+		for i := 0; Of(i).SendContext(ctx, next); i++ {
+		}
+	}}).Lift(liftTakeWhile(func(i interface{}) bool {
+		return i.(int) < 3
+	}))
+	Assert(context.Background(), t, obs, HasItems(0, 1, 2), HasNoError())
+	select {
+	case <-time.After(time.Second):
+		assert.FailNow(t, "source not stopped")
+	case <-stopped:
+	}
+}
+
+func Test_Observable_Lift_Panic(t *testing.T) {
+	obs := testObservable(1, 2, 3).Lift(func(_ context.Context, downstream Observer) Observer {
+		return funcObserver{
+			next: func(i interface{}) {
+				if i == 2 {
+					panic("foo")
+				}
+				downstream.OnNext(i)
+			},
+			err:       downstream.OnError,
+			completed: downstream.OnCompleted,
+		}
+	})
+	items, err := obs.ToSlice(0)
+	assert.Equal(t, []interface{}{1}, items)
+	assert.IsType(t, PanicError{}, err)
+}
+
+func Test_Observable_Map_One(t *testing.T) {
+	obs := testObservable(1, 2, 3).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i.(int) + 1, nil
+	})
+	Assert(context.Background(), t, obs, HasItems(2, 3, 4), HasNoError())
+}
+
+func Test_Observable_Map_Multiple(t *testing.T) {
+	obs := testObservable(1, 2, 3).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i.(int) + 1, nil
+	}).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i.(int) * 10, nil
+	})
+	Assert(context.Background(), t, obs, HasItems(20, 30, 40), HasNoError())
+}
+
+func Test_Observable_Map_StreamError(t *testing.T) {
+	obs := testObservable(1, 2, 3).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		if i == 2 {
+			return nil, errFoo
+		}
+		return i, nil
+	}, WithStreamErrors()).Filter(func(interface{}) bool {
+		return true
+	}, WithStreamErrors())
+	Assert(context.Background(), t, obs, HasItems(1), HasAnError())
+}
+
+func Test_Observable_StreamError(t *testing.T) {
+	err := Just(1, 2, 3)().Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	}, WithStreamErrors()).Filter(func(i interface{}) bool {
+		return true
+	}).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		if i == 3 {
+			return nil, errFoo
+		}
+		return i, nil
+	}, WithStreamErrors()).Error()
+	streamErr, ok := err.(StreamError)
+	if !assert.True(t, ok, "expected a StreamError: %v", err) {
+		return
+	}
+	assert.Equal(t, "map", streamErr.Operator)
+	assert.Equal(t, 2, streamErr.Index)
+	assert.Contains(t, string(streamErr.Stack), "Test_Observable_StreamError")
+	assert.True(t, errors.Is(err, errFoo))
+	assert.Equal(t, "map: item 2: foo", err.Error())
+}
+
+func Test_Observable_StreamError_Source(t *testing.T) {
+	err := Just(1, errFoo)().Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	}, WithStreamErrors()).Error()
+	assert.Equal(t, StreamError{Index: 1, Err: errFoo}, err)
+}
+
+func Test_Observable_StreamError_End(t *testing.T) {
+	item, err := Empty().ToSingle(WithStreamErrors()).Get()
+	assert.NoError(t, err)
+	streamErr, ok := item.E.(StreamError)
+	if !assert.True(t, ok, "expected a StreamError: %v", item.E) {
+		return
+	}
+	assert.Equal(t, "toSingle", streamErr.Operator)
+	assert.Equal(t, -1, streamErr.Index)
+}
+
+func Test_Observable_Map_Panic(t *testing.T) {
+	obs := Just(1, 2, 3)().Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		if i == 2 {
+			panic(errFoo)
+		}
+		return i, nil
+	})
+	Assert(context.Background(), t, obs, HasItems(1), HasAnError())
+	err := obs.Error()
+	panicErr, ok := err.(PanicError)
+	if !assert.True(t, ok, "expected a PanicError: %v", err) {
+		return
+	}
+	assert.Equal(t, errFoo, panicErr.Value)
+	assert.True(t, errors.Is(err, errFoo))
+	assert.NotEmpty(t, panicErr.Stack)
+}
+
+func Test_Observable_FlatMap_Panic(t *testing.T) {
+	obs := testObservable(1, 2).FlatMap(func(item Item) Observable {
+		if item.V == 2 {
+			panic("foo")
+		}
+		return Just(item.V)()
+	})
+	items, err := obs.ToSlice(0)
+	assert.Equal(t, []interface{}{1}, items)
+	assert.Equal(t, "foo", err.(PanicError).Value)
+}
+
+func Test_Observable_Filter_Panic_ContinueOnError(t *testing.T) {
+	obs := testObservable(1, 2, 3).Filter(func(i interface{}) bool {
+		if i == 2 {
+			panic("foo")
+		}
+		return true
+	}, WithErrorStrategy(ContinueOnError))
+	Assert(context.Background(), t, obs, HasItems(1, 3), HasAnError())
+}
+
+func Test_Observable_Map_Panic_Parallel(t *testing.T) {
+	obs := Just(1, 2, 3)().Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		panic("foo")
+	}, WithCPUPool())
+	Assert(context.Background(), t, obs, IsEmpty(), HasAnError())
+	_, ok := obs.Error().(PanicError)
+	assert.True(t, ok)
+}
+
+func Test_Observable_Map_Error(t *testing.T) {
+	obs := testObservable(1, 2, 3, errFoo).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i.(int) + 1, nil
+	})
+	Assert(context.Background(), t, obs, HasItems(2, 3, 4), HasError(errFoo))
+}
+
+func Test_Observable_Map_ReturnValueAndError(t *testing.T) {
+	obs := testObservable(1).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return 2, errFoo
+	})
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+}
+
+func Test_Observable_Map_Multiple_Error(t *testing.T) {
+	called := false
+	obs := testObservable(1, 2, 3).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return nil, errFoo
+	}).Map(func(_ context.Context, i interface{}) (interface{}, error) {
 		called = true
 		return nil, nil
 	})
@@ -916,6 +1767,37 @@ func Test_Observable_Map_Parallel(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItemsNoOrder(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), HasNoError())
 }
 
+func Test_Observable_Map_ParallelPreservedOrder(t *testing.T) {
+	obs := Range(0, 19).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		// The first items are the slowest ones
+		time.Sleep(time.Duration(20-i.(int)) * time.Millisecond)
+		return i.(int) * 10, nil
+	}, WithPool(8), WithPreservedOrder())
+
+	expected := make([]interface{}, 0, 20)
+	for i := 0; i < 20; i++ {
+		expected = append(expected, i*10)
+	}
+	Assert(context.Background(), t, obs, HasItems(expected...), HasNoError())
+}
+
+func Test_Observable_Map_ParallelPreservedOrder_Error(t *testing.T) {
+	obs := testObservable(1, 2, 3, 4).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		if i == 3 {
+			return nil, errFoo
+		}
+		return i, nil
+	}, WithPool(4), WithPreservedOrder())
+	Assert(context.Background(), t, obs, HasItems(1, 2), HasError(errFoo))
+}
+
+func Test_Observable_Map_ParallelPreservedOrder_ContinueOnError(t *testing.T) {
+	obs := testObservable(1, errFoo, 3, 4).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	}, WithPool(4), WithPreservedOrder(), WithErrorStrategy(ContinueOnError))
+	Assert(context.Background(), t, obs, HasItems(1, 3, 4), HasError(errFoo))
+}
+
 func Test_Observable_Marshal(t *testing.T) {
 	obs := testObservable(testStruct{
 		ID: 1,
@@ -936,6 +1818,38 @@ func Test_Observable_Marshal_Parallel(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems([]byte(`{"id":1}`), []byte(`{"id":2}`)))
 }
 
+func Test_Observable_Materialize(t *testing.T) {
+	obs := testObservable(1, 2).Materialize()
+	Assert(context.Background(), t, obs, HasItems(
+		Notification{Kind: NextNotification, Value: 1},
+		Notification{Kind: NextNotification, Value: 2},
+		Notification{Kind: CompletedNotification},
+	), HasNoError())
+}
+
+func Test_Observable_Materialize_Error(t *testing.T) {
+	obs := testObservable(1, errFoo, 2).Materialize()
+	Assert(context.Background(), t, obs, HasItems(
+		Notification{Kind: NextNotification, Value: 1},
+		Notification{Kind: ErrorNotification, Error: errFoo},
+	), HasNoError())
+}
+
+func Test_Observable_Materialize_ContinueOnError(t *testing.T) {
+	obs := testObservable(1, errFoo, 2).Materialize(WithErrorStrategy(ContinueOnError))
+	Assert(context.Background(), t, obs, HasItems(
+		Notification{Kind: NextNotification, Value: 1},
+		Notification{Kind: ErrorNotification, Error: errFoo},
+		Notification{Kind: NextNotification, Value: 2},
+		Notification{Kind: CompletedNotification},
+	), HasNoError())
+}
+
+func Test_Observable_Materialize_Dematerialize(t *testing.T) {
+	obs := testObservable(1, 2, errFoo).Materialize().Dematerialize()
+	Assert(context.Background(), t, obs, HasItems(1, 2), HasError(errFoo))
+}
+
 func Test_Observable_Max(t *testing.T) {
 	obs := Range(0, 10000).Max(func(e1 interface{}, e2 interface{}) int {
 		i1 := e1.(int)
@@ -978,6 +1892,11 @@ func Test_Observable_Max_Parallel(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItem(10000))
 }
 
+func Test_Observable_MergeWith(t *testing.T) {
+	obs := testObservable(1, 2).MergeWith(testObservable(3, 4))
+	Assert(context.Background(), t, obs, HasItemsNoOrder(1, 2, 3, 4), HasNoError())
+}
+
 func Test_Observable_Min(t *testing.T) {
 	obs := Range(0, 10000).Min(func(e1 interface{}, e2 interface{}) int {
 		i1 := e1.(int)
@@ -1017,6 +1936,85 @@ func Test_Observable_Observe(t *testing.T) {
 	assert.Equal(t, []int{1, 2, 3}, got)
 }
 
+func Test_Observable_ObserveOn(t *testing.T) {
+	single := NewSingleScheduler()
+	defer single.Stop()
+	pool := NewPoolScheduler(4)
+	defer pool.Stop()
+	for _, scheduler := range []Scheduler{NewImmediateScheduler(), NewGoroutineScheduler(), single, pool} {
+		obs := Range(1, 99).ObserveOn(scheduler)
+		Assert(context.Background(), t, obs, CustomPredicate(func(items []interface{}) error {
+			if len(items) != 100 {
+				return fmt.Errorf("expected 100 items, got %d", len(items))
+			}
+			for i, item := range items {
+				if item != i+1 {
+					return fmt.Errorf("unexpected item %v at index %d", item, i)
+				}
+			}
+			return nil
+		}))
+	}
+}
+
+func Test_Observable_ObserveOn_Error(t *testing.T) {
+	obs := testObservable(1, errFoo, 2).ObserveOn(NewGoroutineScheduler())
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errFoo))
+}
+
+func Test_Observable_ObserveOn_Mailbox(t *testing.T) {
+	single := NewSingleScheduler()
+	defer single.Stop()
+	obs := Range(1, 99).ObserveOn(single, WithMailbox(8)).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		// The observer is slower than the source
+		time.Sleep(10 * time.Microsecond)
+		return i, nil
+	})
+	Assert(context.Background(), t, obs, CustomPredicate(func(items []interface{}) error {
+		for i, item := range items {
+			if item != i+1 {
+				return fmt.Errorf("unexpected item %v at index %d", item, i)
+			}
+		}
+		return nil
+	}), HasNoError())
+}
+
+func Test_Observable_ObserveOn_Mailbox_Fail(t *testing.T) {
+	failed := make(chan struct{})
+	source := Create([]Producer{func(ctx context.Context, next chan<- Item) {
+		for i := 0; ; i++ {
+			select {
+			case next <- Of(i):
+			case <-ctx.Done():
+				// The source is unsubscribed once the mailbox overflows
+				close(failed)
+				return
+			}
+		}
+	}})
+	observe := source.ObserveOn(NewGoroutineScheduler(), WithMailbox(1), WithBackPressureStrategy(Fail)).Observe()
+	<-failed
+	items, err := collect(context.Background(), observe)
+	assert.NoError(t, err)
+	assert.Equal(t, BackpressureError{error: "observer not ready"}, items[len(items)-1])
+}
+
+func Test_Observable_ObserveOn_StoppedScheduler(t *testing.T) {
+	pool := NewPoolScheduler(1)
+	pool.Stop()
+	obs := Just(1, 2)().ObserveOn(pool)
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errRejectedTask))
+}
+
+func Test_Observable_ObserveOn_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	observe := Never().ObserveOn(NewGoroutineScheduler()).Observe(WithContext(ctx))
+	cancel()
+	for range observe {
+	}
+}
+
 func Test_Observable_OnErrorResumeNext(t *testing.T) {
 	obs := testObservable(1, 2, errFoo, 4).OnErrorResumeNext(func(e error) Observable {
 		return testObservable(10, 20)
@@ -1024,6 +2022,34 @@ func Test_Observable_OnErrorResumeNext(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(1, 2, 10, 20), HasNoError())
 }
 
+func Test_Observable_OnErrorResumeNext_SourceCancelled(t *testing.T) {
+	cancelled := make(chan struct{})
+	obs := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		next <- Of(1)
+		next <- Error(errFoo)
+		<-ctx.Done()
+		close(cancelled)
+	}}).OnErrorResumeNext(func(e error) Observable {
+		return testObservable(10, 20)
+	})
+	Assert(context.Background(), t, obs, HasItems(1, 10, 20), HasNoError())
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		assert.FailNow(t, "source not cancelled")
+	}
+}
+
+func Test_Observable_OnErrorResumeNext_Error(t *testing.T) {
+	obs := testObservable(1, errFoo).OnErrorResumeNext(func(e error) Observable {
+		if e == errFoo {
+			return testObservable(2, errBar)
+		}
+		return testObservable(3)
+	})
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
+}
+
 func Test_Observable_OnErrorReturn(t *testing.T) {
 	obs := testObservable(1, 2, errFoo, 4, errBar, 6).OnErrorReturn(func(err error) interface{} {
 		return err.Error()
@@ -1036,6 +2062,125 @@ func Test_Observable_OnErrorReturnItem(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(1, 2, "foo", 4, "foo", 6), HasNoError())
 }
 
+func Test_Observable_Pairwise(t *testing.T) {
+	obs := testObservable(1, 2, 3).Pairwise()
+	Assert(context.Background(), t, obs, HasItems(
+		PairItem{Previous: 1, Current: 2},
+		PairItem{Previous: 2, Current: 3},
+	), HasNoError())
+}
+
+func Test_Observable_Pairwise_Single(t *testing.T) {
+	obs := testObservable(1).Pairwise()
+	Assert(context.Background(), t, obs, IsEmpty(), HasNoError())
+}
+
+func Test_Observable_Pairwise_Error(t *testing.T) {
+	obs := testObservable(1, 2, errFoo, 3).Pairwise()
+	Assert(context.Background(), t, obs, HasItems(PairItem{Previous: 1, Current: 2}), HasError(errFoo))
+}
+
+func Test_Observable_Pipe(t *testing.T) {
+	double := func(o Observable) Observable {
+		return o.Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i.(int) * 2, nil
+		})
+	}
+	first := func(o Observable) Observable {
+		return o.Take(1)
+	}
+	obs := testObservable(1, 2, 3).Pipe(double, double, first)
+	Assert(context.Background(), t, obs, HasItems(4), HasNoError())
+}
+
+func Test_Observable_Pipe_Empty(t *testing.T) {
+	obs := testObservable(1, 2, 3).Pipe()
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
+}
+
+func Test_Observable_Publish(t *testing.T) {
+	var subscriptions int32
+	obs := Defer([]Producer{func(_ context.Context, next chan<- Item) {
+		atomic.AddInt32(&subscriptions, 1)
+		next <- Of(1)
+		next <- Of(2)
+		next <- Of(3)
+	}}).Publish(WithBufferedChannel(3))
+
+	first := obs.Observe()
+	second := obs.Observe()
+	assert.Equal(t, int32(0), atomic.LoadInt32(&subscriptions))
+	obs.Connect()
+	Assert(context.Background(), t, FromChannel(first), HasItems(1, 2, 3), HasNoError())
+	Assert(context.Background(), t, FromChannel(second), HasItems(1, 2, 3), HasNoError())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&subscriptions))
+}
+
+func Test_Observable_Publish_AutoConnect(t *testing.T) {
+	var subscriptions int32
+	obs := Defer([]Producer{func(_ context.Context, next chan<- Item) {
+		atomic.AddInt32(&subscriptions, 1)
+		next <- Of(1)
+		next <- Of(2)
+	}}).Publish(WithBufferedChannel(2)).AutoConnect(2)
+
+	first := obs.Observe()
+	assert.Equal(t, int32(0), atomic.LoadInt32(&subscriptions))
+	second := obs.Observe()
+	Assert(context.Background(), t, FromChannel(first), HasItems(1, 2), HasNoError())
+	Assert(context.Background(), t, FromChannel(second), HasItems(1, 2), HasNoError())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&subscriptions))
+}
+
+func Test_Observable_Publish_Error(t *testing.T) {
+	obs := testObservable(1, errFoo, 3).Publish(WithBufferedChannel(3))
+	observe := obs.Observe()
+	obs.Connect()
+	Assert(context.Background(), t, FromChannel(observe), HasItems(1), HasError(errFoo))
+}
+
+func Test_Observable_Publish_RefCount(t *testing.T) {
+	var subscriptions int32
+	disconnected := make(chan struct{})
+	obs := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		atomic.AddInt32(&subscriptions, 1)
+		defer close(disconnected)
+		for i := 0; ; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case next <- Of(i):
+			}
+		}
+	}}).Publish(WithBackPressureStrategy(Drop)).RefCount()
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	first := obs.Observe(WithContext(ctx1))
+	second := obs.Observe(WithContext(ctx2))
+	<-first
+	<-second
+	assert.Equal(t, int32(1), atomic.LoadInt32(&subscriptions))
+
+	cancel1()
+	for range first {
+	}
+	select {
+	case <-disconnected:
+		assert.FailNow(t, "disconnected while an observer is still subscribed")
+	default:
+	}
+
+	cancel2()
+	for range second {
+	}
+	select {
+	case <-disconnected:
+	case <-time.After(time.Second):
+		assert.FailNow(t, "not disconnected after the last observer unsubscribed")
+	}
+}
+
 func Test_Observable_Reduce(t *testing.T) {
 	obs := Range(1, 10000).Reduce(func(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
 		if a, ok := acc.(int); ok {
@@ -1123,23 +2268,31 @@ func Test_Observable_Reduce_Parallel_WithErrorStrategy(t *testing.T) {
 }
 
 func Test_Observable_Repeat(t *testing.T) {
-	repeat := testObservable(1, 2, 3).Repeat(1, nil)
+	repeat := Just(1, 2, 3)().Repeat(1, nil)
 	Assert(context.Background(), t, repeat, HasItems(1, 2, 3, 1, 2, 3))
 }
 
+func Test_Observable_Repeat_Resubscribe(t *testing.T) {
+	var subscriptions int32
+	repeat := Defer([]Producer{func(_ context.Context, next chan<- Item) {
+		next <- Of(atomic.AddInt32(&subscriptions, 1))
+	}}).Repeat(2, nil)
+	Assert(context.Background(), t, repeat, HasItems(int32(1), int32(2), int32(3)), HasNoError())
+}
+
 func Test_Observable_Repeat_Zero(t *testing.T) {
-	repeat := testObservable(1, 2, 3).Repeat(0, nil)
+	repeat := Just(1, 2, 3)().Repeat(0, nil)
 	Assert(context.Background(), t, repeat, HasItems(1, 2, 3))
 }
 
-func Test_Observable_Repeat_NegativeCount(t *testing.T) {
-	repeat := testObservable(1, 2, 3).Repeat(-2, nil)
-	Assert(context.Background(), t, repeat, IsEmpty(), HasAnError())
+func Test_Observable_Repeat_Error(t *testing.T) {
+	repeat := Just(1, errFoo, 2)().Repeat(1, nil)
+	Assert(context.Background(), t, repeat, HasItems(1), HasError(errFoo))
 }
 
 func Test_Observable_Repeat_Infinite(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
-	repeat := testObservable(1, 2, 3).Repeat(Infinite, nil, WithContext(ctx))
+	repeat := Just(1, 2, 3)().Repeat(Infinite, nil, WithContext(ctx))
 	go func() {
 		time.Sleep(50 * time.Millisecond)
 		cancel()
@@ -1156,12 +2309,40 @@ func Test_Observable_Repeat_Frequency(t *testing.T) {
 	frequency := new(mockDuration)
 	frequency.On("duration").Return(time.Millisecond)
 
-	repeat := testObservable(1, 2, 3).Repeat(1, frequency)
+	repeat := Just(1, 2, 3)().Repeat(1, frequency)
 	Assert(context.Background(), t, repeat, HasItems(1, 2, 3, 1, 2, 3))
 	frequency.AssertNumberOfCalls(t, "duration", 1)
 	frequency.AssertExpectations(t)
 }
 
+func Test_Observable_RepeatWhen(t *testing.T) {
+	repeat := Just(1, 2)().RepeatWhen(func(completions Observable) Observable {
+		return completions.TakeWhile(func(i interface{}) bool {
+			return i.(int) < 3
+		})
+	})
+	Assert(context.Background(), t, repeat, HasItems(1, 2, 1, 2, 1, 2), HasNoError())
+}
+
+func Test_Observable_RepeatWhen_NotifierError(t *testing.T) {
+	repeat := Just(1)().RepeatWhen(func(completions Observable) Observable {
+		return completions.Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			if i.(int) == 2 {
+				return nil, errFoo
+			}
+			return i, nil
+		})
+	})
+	Assert(context.Background(), t, repeat, HasItems(1, 1), HasError(errFoo))
+}
+
+func Test_Observable_RepeatWhen_SourceError(t *testing.T) {
+	repeat := Just(1, errFoo)().RepeatWhen(func(completions Observable) Observable {
+		return completions
+	})
+	Assert(context.Background(), t, repeat, HasItems(1), HasError(errFoo))
+}
+
 func Test_Observable_Retry(t *testing.T) {
 	i := 0
 	obs := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
@@ -1201,6 +2382,63 @@ func Test_Observable_Retry_Error_ShouldNotRetry(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(1, 2), HasError(errFoo))
 }
 
+func Test_Observable_Retry_ContextCanceled(t *testing.T) {
+	canceled := make(chan struct{})
+	obs := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		<-ctx.Done()
+		close(canceled)
+	}}).Retry(3, func(err error) bool {
+		return true
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	observe := obs.Observe(WithContext(ctx))
+	cancel()
+	<-canceled
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
+func Test_Observable_RetryWhen(t *testing.T) {
+	var subscriptions int32
+	obs := Defer([]Producer{func(_ context.Context, next chan<- Item) {
+		next <- Of(1)
+		if atomic.AddInt32(&subscriptions, 1) <= 2 {
+			next <- Error(errFoo)
+			return
+		}
+		next <- Of(2)
+	}}).RetryWhen(func(errors Observable) Observable {
+		return errors
+	})
+	Assert(context.Background(), t, obs, HasItems(1, 1, 1, 2), HasNoError())
+}
+
+func Test_Observable_RetryWhen_NotifierCompleted(t *testing.T) {
+	obs := Defer([]Producer{func(_ context.Context, next chan<- Item) {
+		next <- Of(1)
+		next <- Error(errFoo)
+	}}).RetryWhen(func(errors Observable) Observable {
+		// Retries twice then completes
+		return Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+			observe := errors.Observe(WithContext(ctx))
+			for i := 0; i < 2; i++ {
+				next <- <-observe
+			}
+		}})
+	})
+	Assert(context.Background(), t, obs, HasItems(1, 1, 1), HasNoError())
+}
+
+func Test_Observable_RetryWhen_NotifierError(t *testing.T) {
+	obs := testObservable(1, errFoo).RetryWhen(func(errors Observable) Observable {
+		return errors.Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return nil, errBar
+		})
+	})
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errBar))
+}
+
 func Test_Observable_Run(t *testing.T) {
 	s := make([]int, 0)
 	<-testObservable(1, 2, 3).Map(func(_ context.Context, i interface{}) (interface{}, error) {
@@ -1234,10 +2472,27 @@ func Test_Observable_Scan(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(1, 3, 6, 10, 15))
 }
 
-func Test_Observable_Scan_Parallel(t *testing.T) {
-	obs := testObservable(1, 2, 3, 4, 5).Scan(func(_ context.Context, x interface{}, y interface{}) (interface{}, error) {
-		if x == nil {
-			return y, nil
+func Test_Observable_ScanWithSeed(t *testing.T) {
+	obs := testObservable(1, 2, 3).ScanWithSeed(func(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
+		return acc.(int) + elem.(int), nil
+	}, 10)
+	Assert(context.Background(), t, obs, HasItems(11, 13, 16))
+}
+
+func Test_Observable_ScanWithSeed_Error(t *testing.T) {
+	obs := testObservable(1, 2, 3).ScanWithSeed(func(_ context.Context, acc interface{}, elem interface{}) (interface{}, error) {
+		if elem == 2 {
+			return nil, errFoo
+		}
+		return append(acc.([]int), elem.(int)), nil
+	}, []int{})
+	Assert(context.Background(), t, obs, HasItems([]int{1}), HasError(errFoo))
+}
+
+func Test_Observable_Scan_Parallel(t *testing.T) {
+	obs := testObservable(1, 2, 3, 4, 5).Scan(func(_ context.Context, x interface{}, y interface{}) (interface{}, error) {
+		if x == nil {
+			return y, nil
 		}
 		return x.(int) + y.(int), nil
 	}, WithCPUPool())
@@ -1272,6 +2527,14 @@ func Test_Observable_SequenceEqual_Empty(t *testing.T) {
 	Assert(context.Background(), t, result, HasItem(true))
 }
 
+func Test_Observable_SequenceEqual_Error(t *testing.T) {
+	result := testObservable(1, 2, errFoo).SequenceEqual(testObservable(1, 2, 3))
+	Assert(context.Background(), t, result, IsEmpty(), HasError(errFoo))
+
+	result = testObservable(1, 2, 3).SequenceEqual(testObservable(1, errFoo))
+	Assert(context.Background(), t, result, IsEmpty(), HasError(errFoo))
+}
+
 func Test_Observable_Send(t *testing.T) {
 	ch := make(chan Item, 10)
 	testObservable(1, 2, 3, errFoo).Send(ch)
@@ -1344,6 +2607,46 @@ func Test_Observable_Serialize_Error(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(message{1}), HasError(errFoo))
 }
 
+func Test_Observable_Publish_RefCount_ResubscribeAfterComplete(t *testing.T) {
+	obs := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		next <- Of(1)
+		next <- Of(2)
+	}}).Publish().RefCount()
+	// Each observer subscribing once the source completed triggers a new connection
+	for i := 0; i < 3; i++ {
+		Assert(context.Background(), t, obs, HasItems(1, 2), HasNoError())
+	}
+}
+
+func Test_Observable_Share(t *testing.T) {
+	var subscriptions int32
+	obs := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		atomic.AddInt32(&subscriptions, 1)
+		for i := 0; ; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case next <- Of(i):
+			}
+		}
+	}}).Share(WithBackPressureStrategy(Drop))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	first := obs.Observe(WithContext(ctx))
+	second := obs.Observe(WithContext(ctx))
+	<-first
+	<-second
+	assert.Equal(t, int32(1), atomic.LoadInt32(&subscriptions))
+}
+
+func Test_Observable_Share_ResubscribeAfterComplete(t *testing.T) {
+	obs := Just(1, 2)().Share()
+	for i := 0; i < 3; i++ {
+		Assert(context.Background(), t, obs, HasItems(1, 2), HasNoError())
+	}
+}
+
 func Test_Observable_Skip(t *testing.T) {
 	obs := testObservable(0, 1, 2, 3, 4, 5).Skip(3)
 	Assert(context.Background(), t, obs, HasItems(3, 4, 5))
@@ -1364,6 +2667,46 @@ func Test_Observable_SkipLast_Parallel(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(0, 1, 2))
 }
 
+func Test_Observable_SkipLast_MoreThanNth(t *testing.T) {
+	obs := testObservable(0, 1, 2, 3, 4, 5, 6).SkipLast(3)
+	Assert(context.Background(), t, obs, HasItems(0, 1, 2, 3))
+}
+
+func Test_Observable_SkipLast_LessThanNth(t *testing.T) {
+	obs := testObservable(0, 1).SkipLast(3)
+	Assert(context.Background(), t, obs, IsEmpty(), HasNoError())
+}
+
+func Test_Observable_SkipLast_Zero(t *testing.T) {
+	obs := testObservable(0, 1, 2).SkipLast(0)
+	Assert(context.Background(), t, obs, HasItems(0, 1, 2))
+}
+
+func Test_Observable_SkipUntil(t *testing.T) {
+	ch := make(chan Item, 10)
+	notifier := make(chan Item, 1)
+	observe := FromChannel(ch).SkipUntil(FromChannel(notifier)).Observe()
+	ch <- Of(1)
+	ch <- Of(2)
+	time.Sleep(50 * time.Millisecond)
+	notifier <- Of(struct{}{})
+	time.Sleep(50 * time.Millisecond)
+	ch <- Of(3)
+	ch <- Of(4)
+	close(ch)
+	Assert(context.Background(), t, FromChannel(observe), HasItems(3, 4), HasNoError())
+}
+
+func Test_Observable_SkipUntil_NotifierCompleted(t *testing.T) {
+	obs := testObservable(1, 2, 3).SkipUntil(Empty())
+	Assert(context.Background(), t, obs, IsEmpty(), HasNoError())
+}
+
+func Test_Observable_SkipUntil_NotifierError(t *testing.T) {
+	obs := Never().SkipUntil(Thrown(errFoo))
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+}
+
 func Test_Observable_SkipWhile(t *testing.T) {
 	obs := testObservable(1, 2, 3, 4, 5).SkipWhile(func(i interface{}) bool {
 		switch i := i.(type) {
@@ -1390,6 +2733,16 @@ func Test_Observable_SkipWhile_Parallel(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(3, 4, 5), HasNoError())
 }
 
+func Test_Observable_StartWithItems(t *testing.T) {
+	obs := testObservable(3, 4).StartWithItems([]interface{}{1, 2})
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3, 4), HasNoError())
+}
+
+func Test_Observable_StartWithObservable(t *testing.T) {
+	obs := testObservable(3, 4).StartWithObservable(testObservable(1, 2))
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3, 4), HasNoError())
+}
+
 func Test_Observable_StartWithIterable(t *testing.T) {
 	obs := testObservable(4, 5, 6).StartWith(testObservable(1, 2, 3))
 	Assert(context.Background(), t, obs, HasItems(1, 2, 3, 4, 5, 6), HasNoError())
@@ -1405,6 +2758,205 @@ func Test_Observable_StartWithIterable_Error2(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(1, 2, 3, 4), HasError(errFoo))
 }
 
+func Test_Observable_StartWithIterable_ContextCanceled(t *testing.T) {
+	canceled := make(chan struct{})
+	obs := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		next <- Of(4)
+		<-ctx.Done()
+		close(canceled)
+	}}).StartWith(testObservable(1, 2, 3)).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	observe := obs.Observe(WithContext(ctx))
+	// Receiving 4 guarantees the source Observable is observed before the cancellation
+	for i := 1; i <= 4; i++ {
+		assert.Equal(t, i, (<-observe).V)
+	}
+	cancel()
+	<-canceled
+	for range observe {
+	}
+}
+
+func Test_Observable_Subscribe(t *testing.T) {
+	recorder := &recordingObserver{}
+	<-testObservable(1, 2, 3).Subscribe(recorder)
+	events, _ := recorder.recorded()
+	assert.Equal(t, []string{"next: 1", "next: 2", "next: 3", "completed"}, events)
+}
+
+func Test_Observable_Subscribe_Error(t *testing.T) {
+	recorder := &recordingObserver{}
+	<-testObservable(1, errFoo, 2).Subscribe(recorder)
+	events, _ := recorder.recorded()
+	assert.Equal(t, []string{"next: 1", "error: foo"}, events)
+}
+
+func Test_Observable_Subscribe_ErrorContinueOnError(t *testing.T) {
+	recorder := &recordingObserver{}
+	<-testObservable(1, errFoo, 2).Subscribe(recorder, WithErrorStrategy(ContinueOnError))
+	events, _ := recorder.recorded()
+	assert.Equal(t, []string{"next: 1", "error: foo"}, events)
+}
+
+func Test_Observable_Subscribe_Cancel(t *testing.T) {
+	recorder := &recordingObserver{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	<-Never().Subscribe(recorder, WithContext(ctx))
+	events, _ := recorder.recorded()
+	assert.Empty(t, events)
+}
+
+func Test_Observable_Subscribe_CancelStopsProducer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	obs := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		defer close(stopped)
+		for i := 0; Of(i).SendContext(ctx, next); i++ {
+		}
+	}}).FlatMap(func(item Item) Observable {
+		return Just(item.V)()
+	}).Filter(func(interface{}) bool {
+		return true
+	})
+	recorder := &recordingObserver{}
+	obs.Subscribe(recorder, WithContext(ctx))
+	for {
+		if events, _ := recorder.recorded(); len(events) > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	select {
+	case <-time.After(time.Second):
+		assert.FailNow(t, "producer not stopped")
+	case <-stopped:
+	}
+}
+
+func Test_Observable_SubscribeWithContext(t *testing.T) {
+	recorder := &recordingObserver{}
+	<-testObservable(1, 2).SubscribeWithContext(context.Background(), recorder)
+	events, _ := recorder.recorded()
+	assert.Equal(t, []string{"next: 1", "next: 2", "completed"}, events)
+}
+
+func Test_Observable_SubscribeWithContext_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	obs := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		defer close(stopped)
+		for i := 0; Of(i).SendContext(ctx, next); i++ {
+		}
+	}}).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	}).FlatMap(func(item Item) Observable {
+		return Just(item.V)()
+	}).BufferWithCount(2)
+	recorder := &recordingObserver{}
+	disposed := obs.SubscribeWithContext(ctx, recorder)
+	for {
+		if events, _ := recorder.recorded(); len(events) > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	for _, done := range []<-chan struct{}{disposed, stopped} {
+		select {
+		case <-time.After(time.Second):
+			assert.FailNow(t, "upstream not torn down")
+		case <-done:
+		}
+	}
+	events, _ := recorder.recorded()
+	assert.NotContains(t, events, "completed")
+}
+
+func Test_Observable_FlatMap_ErrorStopsSource(t *testing.T) {
+	stopped := make(chan struct{})
+	obs := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		defer close(stopped)
+		for i := 0; Of(i).SendContext(ctx, next); i++ {
+		}
+	}}).FlatMap(func(item Item) Observable {
+		return Thrown(errFoo)
+	})
+	Assert(context.Background(), t, obs, HasError(errFoo))
+	select {
+	case <-time.After(time.Second):
+		assert.FailNow(t, "source not stopped")
+	case <-stopped:
+	}
+}
+
+type panickingObserver struct {
+	recordingObserver
+}
+
+func (p *panickingObserver) OnNext(i interface{}) {
+	if i == 2 {
+		panic("foo")
+	}
+	p.recordingObserver.OnNext(i)
+}
+
+func Test_Observable_Subscribe_Panic(t *testing.T) {
+	recorder := &panickingObserver{}
+	<-testObservable(1, 2, 3).Subscribe(recorder)
+	events, _ := recorder.recorded()
+	assert.Equal(t, []string{"next: 1", "error: panic: foo"}, events)
+}
+
+func Test_Observable_SubscribeOn(t *testing.T) {
+	single := NewSingleScheduler()
+	defer single.Stop()
+	obs := testObservable(1, 2, 3).SubscribeOn(single)
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
+}
+
+func Test_Observable_SubscribeOn_Error(t *testing.T) {
+	obs := testObservable(1, errFoo, 2).SubscribeOn(NewGoroutineScheduler())
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errFoo))
+}
+
+func Test_Observable_SubscribeOn_ReleasesScheduler(t *testing.T) {
+	single := NewSingleScheduler()
+	defer single.Stop()
+	ch := make(chan Item)
+	first := FromChannel(ch).SubscribeOn(single).Observe()
+	// The first source is still running: it does not hold the goroutine of the scheduler
+	second := Just(1)().SubscribeOn(single).Observe()
+	item := <-second
+	assert.Equal(t, 1, item.V)
+	close(ch)
+	for range first {
+	}
+}
+
+func Test_Observable_SubscribeOn_ObserveOn_SingleScheduler(t *testing.T) {
+	single := NewSingleScheduler()
+	defer single.Stop()
+	obs := Just(1, 2, 3)().SubscribeOn(single).ObserveOn(single)
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
+}
+
+func Test_Observable_SubscribeOn_StoppedScheduler(t *testing.T) {
+	pool := NewPoolScheduler(1)
+	pool.Stop()
+	obs := Just(1)().SubscribeOn(pool)
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errRejectedTask))
+}
+
+func Test_Observable_SumInt64_Parallel(t *testing.T) {
+	Assert(context.Background(), t, Range(1, 9999).SumInt64(WithCPUPool()), HasItem(int64(50005000)))
+}
+
 func Test_Observable_SumFloat32_OnlyFloat32(t *testing.T) {
 	Assert(context.Background(), t, testObservable(float32(1.0), float32(2.0), float32(3.0)).SumFloat32(),
 		HasItem(float32(6.)))
@@ -1458,11 +3010,93 @@ func Test_Observable_SumInt64_Empty(t *testing.T) {
 	Assert(context.Background(), t, Empty().SumInt64(), IsEmpty())
 }
 
+func Test_Observable_SwitchIfEmpty_Empty(t *testing.T) {
+	obs := Empty().SwitchIfEmpty(testObservable(1, 2))
+	Assert(context.Background(), t, obs, HasItems(1, 2), HasNoError())
+}
+
+func Test_Observable_SwitchIfEmpty_NotEmpty(t *testing.T) {
+	subscribed := false
+	other := Defer([]Producer{func(_ context.Context, next chan<- Item) {
+		subscribed = true
+	}})
+	obs := testObservable(1, 2).SwitchIfEmpty(other)
+	Assert(context.Background(), t, obs, HasItems(1, 2), HasNoError())
+	assert.False(t, subscribed)
+}
+
+func Test_Observable_SwitchIfEmpty_Error(t *testing.T) {
+	obs := testObservable(errFoo).SwitchIfEmpty(testObservable(1, 2))
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+}
+
+func Test_Observable_SwitchIfEmpty_OtherError(t *testing.T) {
+	obs := Empty().SwitchIfEmpty(testObservable(1, errFoo))
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errFoo))
+}
+
+func Test_Observable_SwitchMap(t *testing.T) {
+	outer := make(chan Item)
+	second := make(chan Item)
+	unsubscribed := make(chan struct{})
+	obs := FromChannel(outer).SwitchMap(func(item Item) Observable {
+		if item.V == 1 {
+			return Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+				next <- Of("a")
+				<-ctx.Done()
+				close(unsubscribed)
+			}})
+		}
+		return FromChannel(second)
+	})
+	observe := obs.Observe()
+
+	outer <- Of(1)
+	assert.Equal(t, "a", (<-observe).V)
+	outer <- Of(2)
+	select {
+	case <-unsubscribed:
+	case <-time.After(time.Second):
+		assert.FailNow(t, "previous inner observable not unsubscribed")
+	}
+	second <- Of("b")
+	assert.Equal(t, "b", (<-observe).V)
+	close(outer)
+	second <- Of("c")
+	assert.Equal(t, "c", (<-observe).V)
+	close(second)
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
+func Test_Observable_SwitchMap_Error(t *testing.T) {
+	obs := testObservable(1, 2).SwitchMap(func(item Item) Observable {
+		return Thrown(errFoo)
+	})
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+}
+
 func Test_Observable_Take(t *testing.T) {
 	obs := testObservable(1, 2, 3, 4, 5).Take(3)
 	Assert(context.Background(), t, obs, HasItems(1, 2, 3))
 }
 
+func Test_Observable_Take_InfiniteSource(t *testing.T) {
+	obs := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		for i := 0; ; i++ {
+			if !Of(i).SendContext(ctx, next) {
+				return
+			}
+		}
+	}}).Take(3)
+	Assert(context.Background(), t, obs, HasItems(0, 1, 2), HasNoError())
+}
+
+func Test_Observable_Take_ContinueOnError(t *testing.T) {
+	obs := testObservable(1, errFoo, 2, 3, 4).Take(2, WithErrorStrategy(ContinueOnError))
+	Assert(context.Background(), t, obs, HasItems(1, 2), HasError(errFoo))
+}
+
 func Test_Observable_TakeLast(t *testing.T) {
 	obs := testObservable(1, 2, 3, 4, 5).TakeLast(3)
 	Assert(context.Background(), t, obs, HasItems(3, 4, 5))
@@ -1485,6 +3119,44 @@ func Test_Observable_TakeUntil(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(1, 2, 3))
 }
 
+func Test_Observable_TakeUntilObservable(t *testing.T) {
+	ch := make(chan Item, 10)
+	notifier := make(chan Item)
+	observe := FromChannel(ch).TakeUntilObservable(FromChannel(notifier)).Observe()
+	ch <- Of(1)
+	ch <- Of(2)
+	assert.Equal(t, 1, (<-observe).V)
+	assert.Equal(t, 2, (<-observe).V)
+	notifier <- Of(struct{}{})
+	ch <- Of(3)
+	Assert(context.Background(), t, FromChannel(observe), IsEmpty(), HasNoError())
+}
+
+func Test_Observable_TakeUntilObservable_NotifierCompleted(t *testing.T) {
+	ch := make(chan Item, 10)
+	notifier := make(chan Item)
+	observe := FromChannel(ch).TakeUntilObservable(FromChannel(notifier)).Observe()
+	ch <- Of(1)
+	assert.Equal(t, 1, (<-observe).V)
+	close(notifier)
+	Assert(context.Background(), t, FromChannel(observe), IsEmpty(), HasNoError())
+}
+
+func Test_Observable_TakeUntilObservable_NotifierError(t *testing.T) {
+	obs := Never().TakeUntilObservable(Thrown(errFoo))
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+}
+
+func Test_Observable_TakeUntilObservable_SourceCompleted(t *testing.T) {
+	obs := testObservable(1, 2, 3).TakeUntilObservable(Never())
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
+}
+
+func Test_Observable_TakeUntilObservable_Error(t *testing.T) {
+	obs := testObservable(1, errFoo, 2).TakeUntilObservable(Never())
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errFoo))
+}
+
 func Test_Observable_TakeWhile(t *testing.T) {
 	obs := testObservable(1, 2, 3, 4, 5).TakeWhile(func(item interface{}) bool {
 		return item != 3
@@ -1492,6 +3164,68 @@ func Test_Observable_TakeWhile(t *testing.T) {
 	Assert(context.Background(), t, obs, HasItems(1, 2))
 }
 
+func Test_Observable_Tap(t *testing.T) {
+	s := make([]interface{}, 0)
+	completed := false
+	obs := testObservable(1, 2, 3).Tap(func(i interface{}) {
+		s = append(s, i)
+	}, nil, func() {
+		completed = true
+	})
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
+	assert.Equal(t, []interface{}{1, 2, 3}, s)
+	assert.True(t, completed)
+}
+
+func Test_Observable_Tap_Error(t *testing.T) {
+	var got error
+	completed := false
+	obs := testObservable(1, errFoo, 2).Tap(nil, func(err error) {
+		got = err
+	}, func() {
+		completed = true
+	})
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errFoo))
+	assert.Equal(t, errFoo, got)
+	assert.False(t, completed)
+}
+
+func Test_Observable_ThrottleFirst(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	ch := make(chan Item)
+	observe := FromChannel(ch).ThrottleFirst(WithDuration(time.Second), WithClock(s), WithBufferedChannel(10)).Observe()
+	ch <- Of(1)
+	s.AdvanceTimeBy(100 * time.Millisecond)
+	ch <- Of(2)
+	s.AdvanceTimeBy(time.Second)
+	ch <- Of(3)
+	s.AdvanceTimeBy(100 * time.Millisecond)
+	ch <- Of(4)
+	close(ch)
+	Assert(context.Background(), t, FromChannel(observe), HasItems(1, 3), HasNoError())
+}
+
+func Test_Observable_ThrottleLast(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	ch := make(chan Item)
+	observe := FromChannel(ch).ThrottleLast(WithDuration(time.Second), WithClock(s), WithBufferedChannel(10)).Observe()
+	ch <- Of(1)
+	ch <- Of(2)
+	s.AdvanceTimeBy(time.Second)
+	s.AdvanceTimeBy(time.Second)
+	ch <- Of(3)
+	s.AdvanceTimeBy(time.Second)
+	ch <- Of(4)
+	s.AdvanceTimeBy(500 * time.Millisecond)
+	close(ch)
+	Assert(context.Background(), t, FromChannel(observe), HasItems(2, 3), HasNoError())
+}
+
+func Test_Observable_ThrottleFirst_Error(t *testing.T) {
+	obs := testObservable(1, errFoo, 2).ThrottleFirst(WithDuration(time.Hour))
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errFoo))
+}
+
 func Test_Observable_TimeInterval(t *testing.T) {
 	obs := testObservable(1, 2, 3).TimeInterval()
 	Assert(context.Background(), t, obs, CustomPredicate(func(items []interface{}) error {
@@ -1502,6 +3236,117 @@ func Test_Observable_TimeInterval(t *testing.T) {
 	}))
 }
 
+func Test_Observable_TimeInterval_TestScheduler(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	ch := make(chan Item)
+	observe := FromChannel(ch).TimeInterval(WithClock(s)).Observe()
+	// Once the first item is received, the subscription time was read
+	ch <- Of(1)
+	assert.Equal(t, time.Duration(0), (<-observe).V)
+	s.AdvanceTimeBy(time.Second)
+	ch <- Of(2)
+	assert.Equal(t, time.Second, (<-observe).V)
+	s.AdvanceTimeBy(3 * time.Second)
+	ch <- Of(3)
+	assert.Equal(t, 3*time.Second, (<-observe).V)
+	close(ch)
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
+func Test_Observable_TimeInterval_Error(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	obs := testObservable(1, errFoo, 2).TimeInterval(WithClock(s))
+	Assert(context.Background(), t, obs, HasItems(time.Duration(0)), HasError(errFoo))
+}
+
+func Test_Observable_Timeout(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	ch := make(chan Item)
+	observe := FromChannel(ch).Timeout(WithDuration(time.Second), WithClock(s), WithBufferedChannel(10)).Observe()
+	// Each item restarts the timespan
+	ch <- Of(1)
+	awaitCreated(s, 2)
+	s.AdvanceTimeBy(500 * time.Millisecond)
+	ch <- Of(2)
+	awaitCreated(s, 3)
+	s.AdvanceTimeBy(900 * time.Millisecond)
+	ch <- Of(3)
+	awaitCreated(s, 4)
+	s.AdvanceTimeBy(time.Second)
+	Assert(context.Background(), t, FromChannel(observe), HasItems(1, 2, 3),
+		HasError(TimeoutError{error: "no item emitted within 1s"}))
+}
+
+func Test_Observable_Timeout_Completed(t *testing.T) {
+	obs := testObservable(1, 2).Timeout(WithDuration(time.Hour))
+	Assert(context.Background(), t, obs, HasItems(1, 2), HasNoError())
+}
+
+func Test_Observable_TimeoutWith(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	canceled := make(chan struct{})
+	source := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		next <- Of(1)
+		<-ctx.Done()
+		close(canceled)
+	}})
+	observe := source.TimeoutWith(WithDuration(time.Second), testObservable(10, 11), WithClock(s)).Observe()
+	assert.Equal(t, 1, (<-observe).V)
+	awaitCreated(s, 2)
+	s.AdvanceTimeBy(time.Second)
+	Assert(context.Background(), t, FromChannel(observe), HasItems(10, 11), HasNoError())
+	<-canceled
+}
+
+func Test_Observable_Timeout_FirstItem(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	ch := make(chan Item)
+	observe := FromChannel(ch).Timeout(WithDuration(time.Second), WithClock(s), WithBufferedChannel(10),
+		WithTimeoutStrategy(TimeoutFirstItem)).Observe()
+	ch <- Of(1)
+	s.AdvanceTimeBy(500 * time.Millisecond)
+	// Once the first item is emitted, no timespan applies
+	s.AdvanceTimeBy(time.Hour)
+	ch <- Of(2)
+	close(ch)
+	Assert(context.Background(), t, FromChannel(observe), HasItems(1, 2), HasNoError())
+}
+
+func Test_Observable_Timeout_FirstItem_Expired(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	observe := Never().Timeout(WithDuration(time.Second), WithClock(s),
+		WithTimeoutStrategy(TimeoutFirstItem)).Observe()
+	s.BlockUntil(1)
+	s.AdvanceTimeBy(time.Second)
+	Assert(context.Background(), t, FromChannel(observe), IsEmpty(),
+		HasError(TimeoutError{error: "no item emitted within 1s"}))
+}
+
+func Test_Observable_TimeoutAt(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	deadline := s.Now().Add(time.Second)
+	ch := make(chan Item)
+	observe := FromChannel(ch).TimeoutAt(deadline, WithClock(s), WithBufferedChannel(10)).Observe()
+	ch <- Of(1)
+	s.AdvanceTimeBy(500 * time.Millisecond)
+	// The items do not postpone the deadline
+	ch <- Of(2)
+	s.AdvanceTimeBy(500 * time.Millisecond)
+	Assert(context.Background(), t, FromChannel(observe), HasItems(1, 2),
+		HasError(TimeoutError{error: fmt.Sprintf("not terminated by %v", deadline)}))
+}
+
+func Test_Observable_TimeoutAt_Completed(t *testing.T) {
+	obs := testObservable(1, 2).TimeoutAt(time.Now().Add(time.Hour))
+	Assert(context.Background(), t, obs, HasItems(1, 2), HasNoError())
+}
+
+func Test_Observable_TimeoutAt_Passed(t *testing.T) {
+	obs := Never().TimeoutAt(time.Now().Add(-time.Second))
+	Assert(context.Background(), t, obs, IsEmpty(), HasAnError())
+}
+
 func Test_Observable_Timestamp(t *testing.T) {
 	observe := testObservable(1, 2, 3).Timestamp().Observe()
 	v := (<-observe).V.(TimestampItem)
@@ -1512,6 +3357,13 @@ func Test_Observable_Timestamp(t *testing.T) {
 	assert.Equal(t, 3, v.V)
 }
 
+func Test_Observable_Timestamp_TestScheduler(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewTestScheduler(start)
+	observe := testObservable(1).Timestamp(WithClock(s)).Observe()
+	assert.Equal(t, TimestampItem{Timestamp: start, V: 1}, (<-observe).V)
+}
+
 func Test_Observable_Error(t *testing.T) {
 	observe := testObservable(1, errFoo).Timestamp().Observe()
 	v := (<-observe).V.(TimestampItem)
@@ -1519,6 +3371,43 @@ func Test_Observable_Error(t *testing.T) {
 	assert.True(t, (<-observe).Error())
 }
 
+func Test_Observable_ToChannel(t *testing.T) {
+	ch := testObservable(1, 2, errFoo, 3).ToChannel(WithBufferedChannel(3))
+	assert.Equal(t, 3, cap(ch))
+	var got []interface{}
+	for v := range ch {
+		got = append(got, v)
+	}
+	assert.Equal(t, []interface{}{1, 2}, got)
+}
+
+func Test_Observable_ToChannel_ErrorValues(t *testing.T) {
+	ch := testObservable(1, errFoo, 2).ToChannel(WithErrorValues())
+	var got []interface{}
+	for v := range ch {
+		got = append(got, v)
+	}
+	assert.Equal(t, []interface{}{1, errFoo}, got)
+}
+
+func Test_Observable_ToChannel_ErrorValuesContinueOnError(t *testing.T) {
+	ch := testObservable(1, errFoo, 2).ToChannel(WithErrorValues(), WithErrorStrategy(ContinueOnError))
+	var got []interface{}
+	for v := range ch {
+		got = append(got, v)
+	}
+	assert.Equal(t, []interface{}{1, errFoo, 2}, got)
+}
+
+func Test_Observable_ToList(t *testing.T) {
+	Assert(context.Background(), t, testObservable(1, 2, 3).ToList(), HasItem([]interface{}{1, 2, 3}), HasNoError())
+	Assert(context.Background(), t, Empty().ToList(), HasItem([]interface{}{}), HasNoError())
+}
+
+func Test_Observable_ToList_Error(t *testing.T) {
+	Assert(context.Background(), t, testObservable(1, errFoo, 3).ToList(), IsEmpty(), HasError(errFoo))
+}
+
 func Test_Observable_ToMap(t *testing.T) {
 	obs := testObservable(3, 4, 5, true, false).ToMap(func(_ context.Context, i interface{}) (interface{}, error) {
 		switch v := i.(type) {
@@ -1542,6 +3431,19 @@ func Test_Observable_ToMap(t *testing.T) {
 	}))
 }
 
+func Test_Observable_ToMap_Error(t *testing.T) {
+	obs := testObservable(1, errFoo, 3).ToMap(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	})
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+
+	// The map is still emitted once the error is skipped
+	obs = testObservable(1, errFoo, 3).ToMap(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	}, WithErrorStrategy(ContinueOnError))
+	Assert(context.Background(), t, obs, HasItem(map[interface{}]interface{}{1: 1, 3: 3}), HasError(errFoo))
+}
+
 func Test_Observable_ToMapWithValueSelector(t *testing.T) {
 	keySelector := func(_ context.Context, i interface{}) (interface{}, error) {
 		switch v := i.(type) {
@@ -1576,6 +3478,71 @@ func Test_Observable_ToMapWithValueSelector(t *testing.T) {
 	}))
 }
 
+func Test_Observable_ToMultimap(t *testing.T) {
+	obs := testObservable(1, 2, 3, 4, 5).ToMultimap(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i.(int) % 2, nil
+	})
+	Assert(context.Background(), t, obs, HasItem(map[interface{}][]interface{}{
+		0: {2, 4},
+		1: {1, 3, 5},
+	}), HasNoError())
+}
+
+func Test_Observable_ToMultimapWithValueSelector(t *testing.T) {
+	obs := testObservable(1, 2, 3).ToMultimapWithValueSelector(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i.(int) % 2, nil
+	}, func(_ context.Context, i interface{}) (interface{}, error) {
+		return i.(int) * 10, nil
+	})
+	Assert(context.Background(), t, obs, HasItem(map[interface{}][]interface{}{
+		0: {20},
+		1: {10, 30},
+	}), HasNoError())
+}
+
+func Test_Observable_ToMultimap_Error(t *testing.T) {
+	obs := testObservable(1, 2, 3).ToMultimap(func(_ context.Context, i interface{}) (interface{}, error) {
+		if i.(int) == 2 {
+			return nil, errFoo
+		}
+		return i, nil
+	})
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+}
+
+func Test_Observable_ToCompletable(t *testing.T) {
+	completable := testObservable(1, 2, 3).ToCompletable()
+	Assert(context.Background(), t, completable, IsEmpty(), HasNoError())
+}
+
+func Test_Observable_ToCompletable_Error(t *testing.T) {
+	completable := testObservable(1, errFoo, 2, errBar).ToCompletable(WithErrorStrategy(ContinueOnError))
+	Assert(context.Background(), t, completable, IsEmpty(), HasError(errFoo))
+	assert.Equal(t, errFoo, Just(1, errFoo)().ToCompletable().Await())
+}
+
+func Test_Observable_ToOptionalSingle(t *testing.T) {
+	Assert(context.Background(), t, testObservable(1).ToOptionalSingle(), HasItem(1), HasNoError())
+	Assert(context.Background(), t, Empty().ToOptionalSingle(), IsEmpty(), HasNoError())
+	Assert(context.Background(), t, testObservable(1, 2).ToOptionalSingle(), IsEmpty(),
+		HasError(IllegalInputError{error: "more than one item emitted"}))
+}
+
+func Test_Observable_ToSingle(t *testing.T) {
+	Assert(context.Background(), t, testObservable(1).ToSingle(), HasItem(1), HasNoError())
+	Assert(context.Background(), t, testObservable(errFoo).ToSingle(), HasError(errFoo))
+}
+
+func Test_Observable_ToSingle_Empty(t *testing.T) {
+	Assert(context.Background(), t, Empty().ToSingle(), IsEmpty(),
+		HasError(IllegalInputError{error: "no item emitted"}))
+}
+
+func Test_Observable_ToSingle_MoreThanOneItem(t *testing.T) {
+	Assert(context.Background(), t, testObservable(1, 2, 3).ToSingle(), IsEmpty(),
+		HasError(IllegalInputError{error: "more than one item emitted"}))
+}
+
 func Test_Observable_ToSlice(t *testing.T) {
 	s, err := testObservable(1, 2, 3).ToSlice(5)
 	assert.Equal(t, []interface{}{1, 2, 3}, s)
@@ -1668,6 +3635,28 @@ func Test_Observable_WindowWithTime(t *testing.T) {
 	Assert(context.Background(), t, (<-observe).V.(Observable), HasItems(3))
 }
 
+func Test_Observable_WindowWithTime_TestScheduler(t *testing.T) {
+	s := NewTestScheduler(time.Time{})
+	ch := make(chan Item)
+	observe := FromChannel(ch).WindowWithTime(WithDuration(time.Second), WithClock(s), WithBufferedChannel(10)).Observe()
+	ch <- Of(1)
+	ch <- Of(2)
+	s.AdvanceTimeBy(time.Second)
+	s.AdvanceTimeBy(time.Second)
+	ch <- Of(3)
+	close(ch)
+	Assert(context.Background(), t, (<-observe).V.(Observable), HasItems(1, 2))
+	Assert(context.Background(), t, (<-observe).V.(Observable), HasItems(3))
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
+func Test_Observable_WindowWithTime_ContinueOnError(t *testing.T) {
+	observe := testObservable(1, errFoo, 2).WindowWithTime(WithDuration(time.Hour),
+		WithErrorStrategy(ContinueOnError), WithBufferedChannel(10)).Observe()
+	Assert(context.Background(), t, (<-observe).V.(Observable), HasItems(1, 2), HasErrors(errFoo))
+}
+
 func Test_Observable_WindowWithTimeOrCount(t *testing.T) {
 	ch := make(chan Item, 10)
 	ch <- Of(1)
@@ -1685,6 +3674,103 @@ func Test_Observable_WindowWithTimeOrCount(t *testing.T) {
 	Assert(context.Background(), t, (<-observe).V.(Observable), HasItems(3))
 }
 
+func Test_Observable_WithLatestFrom(t *testing.T) {
+	main := make(chan Item)
+	other := make(chan Item)
+	observe := FromChannel(main).WithLatestFrom(FromChannel(other), func(_ context.Context, a, b interface{}) (interface{}, error) {
+		return fmt.Sprintf("%v%v", a, b), nil
+	}).Observe()
+
+	other <- Of("a")
+	time.Sleep(50 * time.Millisecond)
+	main <- Of(1)
+	assert.Equal(t, "1a", (<-observe).V)
+	other <- Of("b")
+	time.Sleep(50 * time.Millisecond)
+	main <- Of(2)
+	assert.Equal(t, "2b", (<-observe).V)
+	close(other)
+	main <- Of(3)
+	assert.Equal(t, "3b", (<-observe).V)
+	close(main)
+	_, ok := <-observe
+	assert.False(t, ok)
+}
+
+func Test_Observable_WithLatestFrom_NoLatest(t *testing.T) {
+	obs := testObservable(1, 2).WithLatestFrom(Never(), func(_ context.Context, a, b interface{}) (interface{}, error) {
+		return a, nil
+	})
+	Assert(context.Background(), t, obs, IsEmpty(), HasNoError())
+}
+
+func Test_Observable_WithLatestFrom_OtherError(t *testing.T) {
+	obs := Never().WithLatestFrom(testObservable(errFoo), func(_ context.Context, a, b interface{}) (interface{}, error) {
+		return a, nil
+	})
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+}
+
+func Test_Observable_WithLatestFrom_CombinerError(t *testing.T) {
+	other := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		next <- Of("a")
+		<-ctx.Done()
+	}})
+	main := Defer([]Producer{func(ctx context.Context, next chan<- Item) {
+		time.Sleep(50 * time.Millisecond)
+		next <- Of(1)
+		next <- Of(2)
+	}})
+	obs := main.WithLatestFrom(other, func(_ context.Context, a, b interface{}) (interface{}, error) {
+		return nil, errFoo
+	})
+	Assert(context.Background(), t, obs, IsEmpty(), HasError(errFoo))
+}
+
+func Test_Observable_WriteTo(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := testObservable(1, 2, 3).WriteTo(&buf, func(i interface{}) ([]byte, error) {
+		return []byte(fmt.Sprintf("%d\n", i)), nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, "1\n2\n3\n", buf.String())
+}
+
+func Test_Observable_WriteTo_Error(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := testObservable(1, errFoo, 2).WriteTo(&buf, json.Marshal)
+	assert.Equal(t, errFoo, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "1", buf.String())
+}
+
+func Test_Observable_WriteTo_MarshallerError(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := testObservable(1, 2, 3).WriteTo(&buf, func(i interface{}) ([]byte, error) {
+		if i == 2 {
+			return nil, errFoo
+		}
+		return json.Marshal(i)
+	}, WithErrorStrategy(ContinueOnError))
+	assert.Equal(t, errFoo, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "1", buf.String())
+}
+
+func Test_Observable_WriteTo_WriterError(t *testing.T) {
+	n, err := testObservable(1, 2, 3).WriteTo(failingWriter{}, json.Marshal)
+	assert.Equal(t, errFoo, err)
+	assert.Equal(t, 0, n)
+}
+
+// failingWriter always fails with errFoo.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errFoo
+}
+
 func Test_Observable_ZipFromObservable(t *testing.T) {
 	obs1 := testObservable(1, 2, 3)
 	obs2 := testObservable(10, 20, 30)
@@ -0,0 +1,128 @@
+package rxgo
+
+import "sync"
+
+// Observer is the interface to feed a Subject, or to be fed by an Observable using Subscribe.
+type Observer interface {
+	OnNext(i interface{})
+	OnError(err error)
+	OnCompleted()
+}
+
+type serializedObserver struct {
+	mutex      sync.Mutex
+	observer   Observer
+	terminated bool
+}
+
+// SerializeObserver wraps an Observer so that it can be fed from several goroutines.
+// The calls to the wrapped Observer are made sequentially, and none is made once OnError or OnCompleted was called.
+func SerializeObserver(observer Observer) Observer {
+	if serialized, ok := observer.(*serializedObserver); ok {
+		return serialized
+	}
+	return &serializedObserver{observer: observer}
+}
+
+func (s *serializedObserver) OnNext(i interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.terminated {
+		return
+	}
+	s.observer.OnNext(i)
+}
+
+func (s *serializedObserver) OnError(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.terminated {
+		return
+	}
+	s.terminated = true
+	s.observer.OnError(err)
+}
+
+func (s *serializedObserver) OnCompleted() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.terminated {
+		return
+	}
+	s.terminated = true
+	s.observer.OnCompleted()
+}
+
+var globalInterceptors struct {
+	sync.RWMutex
+	seq          int
+	interceptors []registeredInterceptor
+}
+
+type registeredInterceptor struct {
+	id          int
+	interceptor ObserverInterceptor
+}
+
+// RegisterObserverInterceptor registers an interceptor wrapping the Observer of every subscription made with
+// Subscribe, BlockingSubscribe or ForEach, e.g. for logging, metrics or tracing. The global interceptors wrap the
+// ones passed with WithObserverInterceptors, the first registered being the outermost.
+// It returns a function unregistering the interceptor from the next subscriptions.
+func RegisterObserverInterceptor(interceptor ObserverInterceptor) func() {
+	globalInterceptors.Lock()
+	defer globalInterceptors.Unlock()
+	globalInterceptors.seq++
+	id := globalInterceptors.seq
+	globalInterceptors.interceptors = append(globalInterceptors.interceptors, registeredInterceptor{
+		id:          id,
+		interceptor: interceptor,
+	})
+	return func() {
+		globalInterceptors.Lock()
+		defer globalInterceptors.Unlock()
+		for i, registered := range globalInterceptors.interceptors {
+			if registered.id == id {
+				globalInterceptors.interceptors = append(globalInterceptors.interceptors[:i:i],
+					globalInterceptors.interceptors[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// interceptObserver wraps observer with the interceptors passed as options, then with the global ones. The
+// resulting Observer is called from the scheduler set with WithObserverScheduler, if any.
+func interceptObserver(observer Observer, option Option) Observer {
+	interceptors := option.getObserverInterceptors()
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		observer = interceptors[i](observer)
+	}
+	globalInterceptors.RLock()
+	for i := len(globalInterceptors.interceptors) - 1; i >= 0; i-- {
+		observer = globalInterceptors.interceptors[i].interceptor(observer)
+	}
+	globalInterceptors.RUnlock()
+	if scheduler := option.getObserverScheduler(); scheduler != nil {
+		observer = scheduledObserver{observer: observer, scheduler: scheduler}
+	}
+	return observer
+}
+
+// funcsObserver is the Observer calling the functions passed to ForEach.
+type funcsObserver struct {
+	nextFunc      NextFunc
+	errFunc       ErrFunc
+	completedFunc CompletedFunc
+}
+
+func (f funcsObserver) OnNext(i interface{}) {
+	f.nextFunc(i)
+}
+
+func (f funcsObserver) OnError(err error) {
+	f.errFunc(err)
+}
+
+func (f funcsObserver) OnCompleted() {
+	f.completedFunc()
+}
@@ -0,0 +1,155 @@
+package rxgo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingObserver records the calls it receives and whether any of them overlapped.
+type recordingObserver struct {
+	mutex       sync.Mutex
+	events      []string
+	running     int32
+	overlapping bool
+}
+
+func (r *recordingObserver) record(event string) {
+	if !atomic.CompareAndSwapInt32(&r.running, 0, 1) {
+		r.mutex.Lock()
+		r.overlapping = true
+		r.mutex.Unlock()
+		return
+	}
+	defer atomic.StoreInt32(&r.running, 0)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recordingObserver) OnNext(i interface{}) {
+	r.record(fmt.Sprintf("next: %v", i))
+}
+
+func (r *recordingObserver) OnError(err error) {
+	r.record(fmt.Sprintf("error: %v", err))
+}
+
+func (r *recordingObserver) OnCompleted() {
+	r.record("completed")
+}
+
+func (r *recordingObserver) recorded() ([]string, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.events, r.overlapping
+}
+
+func Test_SerializeObserver_Concurrent(t *testing.T) {
+	recorder := &recordingObserver{}
+	observer := SerializeObserver(recorder)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				observer.OnNext(j)
+			}
+		}()
+	}
+	wg.Wait()
+	observer.OnCompleted()
+
+	events, overlapping := recorder.recorded()
+	assert.False(t, overlapping)
+	assert.Len(t, events, 1001)
+	assert.Equal(t, "completed", events[1000])
+}
+
+func Test_SerializeObserver_NothingAfterTerminalEvent(t *testing.T) {
+	recorder := &recordingObserver{}
+	observer := SerializeObserver(recorder)
+
+	observer.OnNext(1)
+	observer.OnError(errFoo)
+	observer.OnNext(2)
+	observer.OnError(errFoo)
+	observer.OnCompleted()
+
+	events, _ := recorder.recorded()
+	assert.Equal(t, []string{"next: 1", "error: foo"}, events)
+}
+
+func Test_SerializeObserver_Idempotent(t *testing.T) {
+	observer := SerializeObserver(&recordingObserver{})
+	assert.True(t, observer == SerializeObserver(observer))
+}
+
+// wrappingInterceptor wraps the values and errors received within the given tag.
+type wrappingInterceptor struct {
+	tag  string
+	next Observer
+}
+
+func newWrappingInterceptor(tag string) ObserverInterceptor {
+	return func(next Observer) Observer {
+		return wrappingInterceptor{tag: tag, next: next}
+	}
+}
+
+func (w wrappingInterceptor) OnNext(i interface{}) {
+	w.next.OnNext(fmt.Sprintf("%s(%v)", w.tag, i))
+}
+
+func (w wrappingInterceptor) OnError(err error) {
+	w.next.OnError(fmt.Errorf("%s(%w)", w.tag, err))
+}
+
+func (w wrappingInterceptor) OnCompleted() {
+	w.next.OnCompleted()
+}
+
+func Test_ObserverInterceptors(t *testing.T) {
+	recorder := &recordingObserver{}
+	<-testObservable(1, errFoo).Subscribe(recorder,
+		WithObserverInterceptors(newWrappingInterceptor("a"), newWrappingInterceptor("b")))
+	events, _ := recorder.recorded()
+	assert.Equal(t, []string{"next: b(a(1))", "error: b(a(foo))"}, events)
+}
+
+func Test_ObserverInterceptors_Global(t *testing.T) {
+	unregister := RegisterObserverInterceptor(newWrappingInterceptor("global"))
+	recorder := &recordingObserver{}
+	<-testObservable(1).Subscribe(recorder, WithObserverInterceptors(newWrappingInterceptor("a")))
+	events, _ := recorder.recorded()
+	assert.Equal(t, []string{"next: a(global(1))", "completed"}, events)
+
+	unregister()
+	recorder = &recordingObserver{}
+	<-testObservable(1).Subscribe(recorder)
+	events, _ = recorder.recorded()
+	assert.Equal(t, []string{"next: 1", "completed"}, events)
+}
+
+func Test_ObserverInterceptors_ForEach(t *testing.T) {
+	recorder := &recordingObserver{}
+	<-testObservable(1, errFoo, 2).ForEach(recorder.OnNext, recorder.OnError, recorder.OnCompleted,
+		WithObserverInterceptors(newWrappingInterceptor("a")), WithErrorStrategy(ContinueOnError))
+	events, _ := recorder.recorded()
+	assert.Equal(t, []string{"next: a(1)", "error: a(foo)", "next: a(2)", "completed"}, events)
+}
+
+func Test_ObserverInterceptors_BlockingSubscribe(t *testing.T) {
+	recorder := &recordingObserver{}
+	err := testObservable(1, errFoo).BlockingSubscribe(context.Background(), recorder,
+		WithObserverInterceptors(newWrappingInterceptor("a")))
+	assert.Equal(t, errFoo, err)
+	events, _ := recorder.recorded()
+	assert.Equal(t, []string{"next: a(1)", "error: a(foo)"}, events)
+}
@@ -0,0 +1,11 @@
+// Package operators provides the Observable operators as OperatorFuncs, to be composed using Observable.Pipe.
+//
+// Each function returns an OperatorFunc applying the Observable method of the same name, for example:
+//
+//	observable.Pipe(
+//		operators.Filter(isEven),
+//		operators.Map(double),
+//	)
+//
+// It also provides operators without Observable method counterpart, such as MarshalJSON.
+package operators
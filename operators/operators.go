@@ -0,0 +1,534 @@
+package operators
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/reactivex/rxgo/v2"
+)
+
+// BackOffRetry returns an OperatorFunc applying Observable.BackOffRetry.
+func BackOffRetry(backOffCfg backoff.BackOff, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.BackOffRetry(backOffCfg, opts...)
+	}
+}
+
+// BufferWithCount returns an OperatorFunc applying Observable.BufferWithCount.
+func BufferWithCount(count int, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.BufferWithCount(count, opts...)
+	}
+}
+
+// BufferWithCountAndSkip returns an OperatorFunc applying Observable.BufferWithCountAndSkip.
+func BufferWithCountAndSkip(count int, skip int, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.BufferWithCountAndSkip(count, skip, opts...)
+	}
+}
+
+// BufferWithTime returns an OperatorFunc applying Observable.BufferWithTime.
+func BufferWithTime(timespan rxgo.Duration, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.BufferWithTime(timespan, opts...)
+	}
+}
+
+// BufferWithTimeOrCount returns an OperatorFunc applying Observable.BufferWithTimeOrCount.
+func BufferWithTimeOrCount(timespan rxgo.Duration, count int, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.BufferWithTimeOrCount(timespan, count, opts...)
+	}
+}
+
+// ConcatWith returns an OperatorFunc applying Observable.ConcatWith.
+func ConcatWith(other rxgo.Observable, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.ConcatWith(other, opts...)
+	}
+}
+
+// Debounce returns an OperatorFunc applying Observable.Debounce.
+func Debounce(timespan rxgo.Duration, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Debounce(timespan, opts...)
+	}
+}
+
+// DefaultIfEmpty returns an OperatorFunc applying Observable.DefaultIfEmpty.
+func DefaultIfEmpty(defaultValue interface{}, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.DefaultIfEmpty(defaultValue, opts...)
+	}
+}
+
+// Delay returns an OperatorFunc applying Observable.Delay.
+func Delay(timespan rxgo.Duration, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Delay(timespan, opts...)
+	}
+}
+
+// DelaySubscription returns an OperatorFunc applying Observable.DelaySubscription.
+func DelaySubscription(timespan rxgo.Duration, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.DelaySubscription(timespan, opts...)
+	}
+}
+
+// Dematerialize returns an OperatorFunc applying Observable.Dematerialize.
+func Dematerialize(opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Dematerialize(opts...)
+	}
+}
+
+// Distinct returns an OperatorFunc applying Observable.Distinct.
+func Distinct(apply rxgo.Func, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Distinct(apply, opts...)
+	}
+}
+
+// DistinctUntilChanged returns an OperatorFunc applying Observable.DistinctUntilChanged.
+func DistinctUntilChanged(apply rxgo.Func, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.DistinctUntilChanged(apply, opts...)
+	}
+}
+
+// DistinctUntilChangedWithComparator returns an OperatorFunc applying Observable.DistinctUntilChangedWithComparator.
+func DistinctUntilChangedWithComparator(comparator rxgo.Comparator, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.DistinctUntilChangedWithComparator(comparator, opts...)
+	}
+}
+
+// DistinctWithCapacity returns an OperatorFunc applying Observable.DistinctWithCapacity.
+func DistinctWithCapacity(apply rxgo.Func, capacity int, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.DistinctWithCapacity(apply, capacity, opts...)
+	}
+}
+
+// DoFinally returns an OperatorFunc applying Observable.DoFinally.
+func DoFinally(finallyFunc func(), opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.DoFinally(finallyFunc, opts...)
+	}
+}
+
+// DoOnDispose returns an OperatorFunc applying Observable.DoOnDispose.
+func DoOnDispose(disposeFunc func(), opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.DoOnDispose(disposeFunc, opts...)
+	}
+}
+
+// DoOnSubscribe returns an OperatorFunc applying Observable.DoOnSubscribe.
+func DoOnSubscribe(subscribeFunc func(), opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.DoOnSubscribe(subscribeFunc, opts...)
+	}
+}
+
+// Expand returns an OperatorFunc applying Observable.Expand.
+func Expand(apply rxgo.ItemToObservable, maxConcurrency int, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Expand(apply, maxConcurrency, opts...)
+	}
+}
+
+// Filter returns an OperatorFunc applying Observable.Filter.
+func Filter(apply rxgo.Predicate, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Filter(apply, opts...)
+	}
+}
+
+// FlatMap returns an OperatorFunc applying Observable.FlatMap.
+func FlatMap(apply rxgo.ItemToObservable, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.FlatMap(apply, opts...)
+	}
+}
+
+// GroupBy returns an OperatorFunc applying Observable.GroupBy.
+func GroupBy(length int, distribution func(rxgo.Item) int, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.GroupBy(length, distribution, opts...)
+	}
+}
+
+// GroupByDynamic returns an OperatorFunc applying Observable.GroupByDynamic.
+func GroupByDynamic(keySelector rxgo.Func, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.GroupByDynamic(keySelector, opts...)
+	}
+}
+
+// IgnoreElements returns an OperatorFunc applying Observable.IgnoreElements.
+func IgnoreElements(opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.IgnoreElements(opts...)
+	}
+}
+
+// Join returns an OperatorFunc applying Observable.Join.
+func Join(joiner rxgo.Func2, right rxgo.Observable, timeExtractor func(interface{}) time.Time, window rxgo.Duration, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Join(joiner, right, timeExtractor, window, opts...)
+	}
+}
+
+// Lift returns an OperatorFunc applying Observable.Lift.
+func Lift(operator rxgo.ObserverOperator, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Lift(operator, opts...)
+	}
+}
+
+// Map returns an OperatorFunc applying Observable.Map.
+func Map(apply rxgo.Func, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Map(apply, opts...)
+	}
+}
+
+// Marshal returns an OperatorFunc applying Observable.Marshal.
+func Marshal(marshaller rxgo.Marshaller, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Marshal(marshaller, opts...)
+	}
+}
+
+// MarshalJSON returns an OperatorFunc marshalling each item into JSON, see Observable.Marshal.
+func MarshalJSON(opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Marshal(json.Marshal, opts...)
+	}
+}
+
+// Materialize returns an OperatorFunc applying Observable.Materialize.
+func Materialize(opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Materialize(opts...)
+	}
+}
+
+// MergeWith returns an OperatorFunc applying Observable.MergeWith.
+func MergeWith(other rxgo.Observable, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.MergeWith(other, opts...)
+	}
+}
+
+// ObserveOn returns an OperatorFunc applying Observable.ObserveOn.
+func ObserveOn(scheduler rxgo.Scheduler, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.ObserveOn(scheduler, opts...)
+	}
+}
+
+// OnErrorResumeNext returns an OperatorFunc applying Observable.OnErrorResumeNext.
+func OnErrorResumeNext(resumeSequence rxgo.ErrorToObservable, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.OnErrorResumeNext(resumeSequence, opts...)
+	}
+}
+
+// OnErrorReturn returns an OperatorFunc applying Observable.OnErrorReturn.
+func OnErrorReturn(resumeFunc rxgo.ErrorFunc, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.OnErrorReturn(resumeFunc, opts...)
+	}
+}
+
+// OnErrorReturnItem returns an OperatorFunc applying Observable.OnErrorReturnItem.
+func OnErrorReturnItem(resume interface{}, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.OnErrorReturnItem(resume, opts...)
+	}
+}
+
+// Pairwise returns an OperatorFunc applying Observable.Pairwise.
+func Pairwise(opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Pairwise(opts...)
+	}
+}
+
+// Repeat returns an OperatorFunc applying Observable.Repeat.
+func Repeat(count int64, frequency rxgo.Duration, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Repeat(count, frequency, opts...)
+	}
+}
+
+// RepeatWhen returns an OperatorFunc applying Observable.RepeatWhen.
+func RepeatWhen(handler func(completions rxgo.Observable) rxgo.Observable, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.RepeatWhen(handler, opts...)
+	}
+}
+
+// Retry returns an OperatorFunc applying Observable.Retry.
+func Retry(count int, shouldRetry func(error) bool, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Retry(count, shouldRetry, opts...)
+	}
+}
+
+// RetryWhen returns an OperatorFunc applying Observable.RetryWhen.
+func RetryWhen(handler func(errors rxgo.Observable) rxgo.Observable, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.RetryWhen(handler, opts...)
+	}
+}
+
+// Sample returns an OperatorFunc applying Observable.Sample.
+func Sample(iterable rxgo.Iterable, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Sample(iterable, opts...)
+	}
+}
+
+// SampleWithTime returns an OperatorFunc applying Observable.SampleWithTime.
+func SampleWithTime(timespan rxgo.Duration, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.SampleWithTime(timespan, opts...)
+	}
+}
+
+// Scan returns an OperatorFunc applying Observable.Scan.
+func Scan(apply rxgo.Func2, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Scan(apply, opts...)
+	}
+}
+
+// ScanWithSeed returns an OperatorFunc applying Observable.ScanWithSeed.
+func ScanWithSeed(apply rxgo.Func2, seed interface{}, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.ScanWithSeed(apply, seed, opts...)
+	}
+}
+
+// Serialize returns an OperatorFunc applying Observable.Serialize.
+func Serialize(from int, identifier func(interface{}) int, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Serialize(from, identifier, opts...)
+	}
+}
+
+// Share returns an OperatorFunc applying Observable.Share.
+func Share(opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Share(opts...)
+	}
+}
+
+// Skip returns an OperatorFunc applying Observable.Skip.
+func Skip(nth uint, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Skip(nth, opts...)
+	}
+}
+
+// SkipLast returns an OperatorFunc applying Observable.SkipLast.
+func SkipLast(nth uint, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.SkipLast(nth, opts...)
+	}
+}
+
+// SkipUntil returns an OperatorFunc applying Observable.SkipUntil.
+func SkipUntil(notifier rxgo.Observable, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.SkipUntil(notifier, opts...)
+	}
+}
+
+// SkipWhile returns an OperatorFunc applying Observable.SkipWhile.
+func SkipWhile(apply rxgo.Predicate, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.SkipWhile(apply, opts...)
+	}
+}
+
+// StartWith returns an OperatorFunc applying Observable.StartWith.
+func StartWith(iterable rxgo.Iterable, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.StartWith(iterable, opts...)
+	}
+}
+
+// StartWithItems returns an OperatorFunc applying Observable.StartWithItems.
+func StartWithItems(items []interface{}, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.StartWithItems(items, opts...)
+	}
+}
+
+// StartWithObservable returns an OperatorFunc applying Observable.StartWithObservable.
+func StartWithObservable(other rxgo.Observable, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.StartWithObservable(other, opts...)
+	}
+}
+
+// SubscribeOn returns an OperatorFunc applying Observable.SubscribeOn.
+func SubscribeOn(scheduler rxgo.Scheduler, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.SubscribeOn(scheduler, opts...)
+	}
+}
+
+// SwitchIfEmpty returns an OperatorFunc applying Observable.SwitchIfEmpty.
+func SwitchIfEmpty(other rxgo.Observable, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.SwitchIfEmpty(other, opts...)
+	}
+}
+
+// SwitchMap returns an OperatorFunc applying Observable.SwitchMap.
+func SwitchMap(apply rxgo.ItemToObservable, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.SwitchMap(apply, opts...)
+	}
+}
+
+// Take returns an OperatorFunc applying Observable.Take.
+func Take(nth uint, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Take(nth, opts...)
+	}
+}
+
+// TakeLast returns an OperatorFunc applying Observable.TakeLast.
+func TakeLast(nth uint, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.TakeLast(nth, opts...)
+	}
+}
+
+// TakeUntil returns an OperatorFunc applying Observable.TakeUntil.
+func TakeUntil(apply rxgo.Predicate, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.TakeUntil(apply, opts...)
+	}
+}
+
+// TakeUntilObservable returns an OperatorFunc applying Observable.TakeUntilObservable.
+func TakeUntilObservable(notifier rxgo.Observable, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.TakeUntilObservable(notifier, opts...)
+	}
+}
+
+// TakeWhile returns an OperatorFunc applying Observable.TakeWhile.
+func TakeWhile(apply rxgo.Predicate, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.TakeWhile(apply, opts...)
+	}
+}
+
+// Tap returns an OperatorFunc applying Observable.Tap.
+func Tap(nextFunc rxgo.NextFunc, errFunc rxgo.ErrFunc, completedFunc rxgo.CompletedFunc, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Tap(nextFunc, errFunc, completedFunc, opts...)
+	}
+}
+
+// ThrottleFirst returns an OperatorFunc applying Observable.ThrottleFirst.
+func ThrottleFirst(timespan rxgo.Duration, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.ThrottleFirst(timespan, opts...)
+	}
+}
+
+// ThrottleLast returns an OperatorFunc applying Observable.ThrottleLast.
+func ThrottleLast(timespan rxgo.Duration, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.ThrottleLast(timespan, opts...)
+	}
+}
+
+// TimeInterval returns an OperatorFunc applying Observable.TimeInterval.
+func TimeInterval(opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.TimeInterval(opts...)
+	}
+}
+
+// Timeout returns an OperatorFunc applying Observable.Timeout.
+func Timeout(timespan rxgo.Duration, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Timeout(timespan, opts...)
+	}
+}
+
+// TimeoutWith returns an OperatorFunc applying Observable.TimeoutWith.
+func TimeoutWith(timespan rxgo.Duration, fallback rxgo.Observable, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.TimeoutWith(timespan, fallback, opts...)
+	}
+}
+
+// TimeoutAt returns an OperatorFunc applying Observable.TimeoutAt.
+func TimeoutAt(deadline time.Time, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.TimeoutAt(deadline, opts...)
+	}
+}
+
+// Timestamp returns an OperatorFunc applying Observable.Timestamp.
+func Timestamp(opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Timestamp(opts...)
+	}
+}
+
+// Unmarshal returns an OperatorFunc applying Observable.Unmarshal.
+func Unmarshal(unmarshaller rxgo.Unmarshaller, factory func() interface{}, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.Unmarshal(unmarshaller, factory, opts...)
+	}
+}
+
+// WindowWithCount returns an OperatorFunc applying Observable.WindowWithCount.
+func WindowWithCount(count int, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.WindowWithCount(count, opts...)
+	}
+}
+
+// WindowWithTime returns an OperatorFunc applying Observable.WindowWithTime.
+func WindowWithTime(timespan rxgo.Duration, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.WindowWithTime(timespan, opts...)
+	}
+}
+
+// WindowWithTimeOrCount returns an OperatorFunc applying Observable.WindowWithTimeOrCount.
+func WindowWithTimeOrCount(timespan rxgo.Duration, count int, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.WindowWithTimeOrCount(timespan, count, opts...)
+	}
+}
+
+// WithLatestFrom returns an OperatorFunc applying Observable.WithLatestFrom.
+func WithLatestFrom(other rxgo.Observable, combiner rxgo.Func2, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.WithLatestFrom(other, combiner, opts...)
+	}
+}
+
+// ZipFromIterable returns an OperatorFunc applying Observable.ZipFromIterable.
+func ZipFromIterable(iterable rxgo.Iterable, zipper rxgo.Func2, opts ...rxgo.Option) rxgo.OperatorFunc {
+	return func(o rxgo.Observable) rxgo.Observable {
+		return o.ZipFromIterable(iterable, zipper, opts...)
+	}
+}
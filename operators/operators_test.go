@@ -0,0 +1,47 @@
+package operators
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/reactivex/rxgo/v2"
+)
+
+func Test_Pipe(t *testing.T) {
+	obs := rxgo.Just(1, 2, 3, 4)().Pipe(
+		Filter(func(i interface{}) bool {
+			return i.(int)%2 == 0
+		}),
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i.(int) * 10, nil
+		}),
+	)
+	rxgo.Assert(context.Background(), t, obs, rxgo.HasItems(20, 40), rxgo.HasNoError())
+}
+
+func Test_Pipe_Reusable(t *testing.T) {
+	firstTwoSquares := func(o rxgo.Observable) rxgo.Observable {
+		return o.Pipe(
+			Map(func(_ context.Context, i interface{}) (interface{}, error) {
+				return i.(int) * i.(int), nil
+			}),
+			Take(2),
+		)
+	}
+	obs := rxgo.Just(1, 2, 3)().Pipe(firstTwoSquares, Skip(1))
+	rxgo.Assert(context.Background(), t, obs, rxgo.HasItems(4), rxgo.HasNoError())
+}
+
+func Test_Pipe_Error(t *testing.T) {
+	errFoo := errors.New("foo")
+	obs := rxgo.Just(1, 1, errFoo, 2)().Pipe(OnErrorReturnItem(0), DistinctUntilChanged(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	}))
+	rxgo.Assert(context.Background(), t, obs, rxgo.HasItems(1, 0, 2), rxgo.HasNoError())
+}
+
+func Test_MarshalJSON(t *testing.T) {
+	obs := rxgo.Just(map[string]int{"a": 1}, "foo")().Pipe(MarshalJSON())
+	rxgo.Assert(context.Background(), t, obs, rxgo.HasItems([]byte(`{"a":1}`), []byte(`"foo"`)), rxgo.HasNoError())
+}
@@ -5,19 +5,42 @@ import "context"
 // OptionalSingleEmpty is the constant returned when an OptionalSingle is empty.
 var OptionalSingleEmpty = Item{}
 
-// OptionalSingle is an optional single.
+// OptionalSingle is an optional single: it emits zero or one item, or an error.
 type OptionalSingle interface {
 	Iterable
+	DefaultIfEmpty(defaultValue interface{}, opts ...Option) Single
+	Filter(apply Predicate, opts ...Option) OptionalSingle
 	Get(opts ...Option) (Item, error)
 	Map(apply Func, opts ...Option) OptionalSingle
 	Run(opts ...Option) Disposed
+	ToObservable() Observable
 }
 
+// Maybe is the ReactiveX name of an OptionalSingle.
+type Maybe = OptionalSingle
+
 // OptionalSingleImpl implements OptionalSingle.
 type OptionalSingleImpl struct {
 	iterable Iterable
 }
 
+// DefaultIfEmpty returns a Single emitting the item of the OptionalSingle, or the default value if it is empty.
+func (o *OptionalSingleImpl) DefaultIfEmpty(defaultValue interface{}, opts ...Option) Single {
+	return single(o, func() operator {
+		return &defaultIfEmptyOperator{
+			defaultValue: defaultValue,
+			empty:        true,
+		}
+	}, true, false, opts...)
+}
+
+// Filter emits the item of the OptionalSingle only if it passes a predicate test.
+func (o *OptionalSingleImpl) Filter(apply Predicate, opts ...Option) OptionalSingle {
+	return optionalSingle(o, func() operator {
+		return &filterOperatorSingle{apply: apply}
+	}, true, true, opts...)
+}
+
 // Get returns the item or rxgo.OptionalEmpty. The error returned is if the context has been cancelled.
 // This method is blocking.
 func (o *OptionalSingleImpl) Get(opts ...Option) (Item, error) {
@@ -57,11 +80,11 @@ type mapOperatorOptionalSingle struct {
 func (op *mapOperatorOptionalSingle) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
 	res, err := op.apply(ctx, item.V)
 	if err != nil {
-		dst <- Error(err)
+		Error(err).SendContext(ctx, dst)
 		operatorOptions.stop()
 		return
 	}
-	dst <- Of(res)
+	Of(res).SendContext(ctx, dst)
 }
 
 func (op *mapOperatorOptionalSingle) err(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
@@ -71,12 +94,12 @@ func (op *mapOperatorOptionalSingle) err(ctx context.Context, item Item, dst cha
 func (op *mapOperatorOptionalSingle) end(_ context.Context, _ chan<- Item) {
 }
 
-func (op *mapOperatorOptionalSingle) gatherNext(_ context.Context, item Item, dst chan<- Item, _ operatorOptions) {
+func (op *mapOperatorOptionalSingle) gatherNext(ctx context.Context, item Item, dst chan<- Item, _ operatorOptions) {
 	switch item.V.(type) {
 	case *mapOperatorOptionalSingle:
 		return
 	}
-	dst <- item
+	item.SendContext(ctx, dst)
 }
 
 // Run creates an observer without consuming the emitted items.
@@ -102,3 +125,8 @@ func (o *OptionalSingleImpl) Run(opts ...Option) Disposed {
 
 	return dispose
 }
+
+// ToObservable converts an OptionalSingle into an Observable emitting zero or one item.
+func (o *OptionalSingleImpl) ToObservable() Observable {
+	return &ObservableImpl{iterable: o.iterable}
+}
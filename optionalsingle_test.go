@@ -7,6 +7,20 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func Test_OptionalSingle_DefaultIfEmpty(t *testing.T) {
+	var os OptionalSingle = &OptionalSingleImpl{iterable: Empty()}
+	Assert(context.Background(), t, os.DefaultIfEmpty(3), HasItem(3), HasNoError())
+	os = &OptionalSingleImpl{iterable: Just(1)()}
+	Assert(context.Background(), t, os.DefaultIfEmpty(3), HasItem(1), HasNoError())
+}
+
+func Test_OptionalSingle_Filter(t *testing.T) {
+	var os OptionalSingle = &OptionalSingleImpl{iterable: Just(1)()}
+	Assert(context.Background(), t, os.Filter(func(i interface{}) bool {
+		return i == 2
+	}), IsEmpty(), HasNoError())
+}
+
 func Test_OptionalSingle_Get_Item(t *testing.T) {
 	var os OptionalSingle = &OptionalSingleImpl{iterable: Just(1)()}
 	get, err := os.Get()
@@ -51,3 +65,8 @@ func Test_OptionalSingle_Observe(t *testing.T) {
 	})
 	Assert(context.Background(), t, os, HasItem(1), HasNoError())
 }
+
+func Test_OptionalSingle_ToObservable(t *testing.T) {
+	var os Maybe = &OptionalSingleImpl{iterable: Empty()}
+	Assert(context.Background(), t, os.ToObservable().DefaultIfEmpty(0), HasItems(0), HasNoError())
+}
@@ -3,6 +3,8 @@ package rxgo
 import (
 	"context"
 	"runtime"
+
+	"github.com/cenkalti/backoff/v4"
 )
 
 // Option handles configurable options.
@@ -12,12 +14,30 @@ type Option interface {
 	isEagerObservation() bool
 	getPool() (bool, int)
 	buildChannel() chan Item
+	getBufferedChannelCapacity() int
+	getChannelCapacity() (bool, int)
 	buildContext() context.Context
 	getBackPressureStrategy() BackpressureStrategy
 	getErrorStrategy() OnErrorStrategy
 	isConnectable() bool
 	isConnectOperation() bool
 	isSerialized() (bool, func(interface{}) int)
+	getClock() Clock
+	isErrorValues() bool
+	isOrderPreserved() bool
+	getPanicStrategy() PanicStrategy
+	getObserverScheduler() Scheduler
+	getScheduler() Scheduler
+	isStreamErrors() bool
+	getObserverInterceptors() []ObserverInterceptor
+	getMetrics() Metrics
+	getTracer() Tracer
+	getLogger() (Logger, int)
+	getDrain() <-chan struct{}
+	getValuePool() *ValuePool
+	getBatchSize() int
+	getBatchRequest() int
+	isItemContextRequested() bool
 }
 
 type funcOption struct {
@@ -33,6 +53,23 @@ type funcOption struct {
 	connectable          bool
 	connectOperation     bool
 	serialized           func(interface{}) int
+	clock                Clock
+	errorValues          bool
+	orderPreserved       bool
+	panicStrategy        PanicStrategy
+	observerScheduler    Scheduler
+	scheduler            Scheduler
+	streamErrors         bool
+	itemContexts         bool
+	observerInterceptors []ObserverInterceptor
+	metrics              Metrics
+	tracer               Tracer
+	logger               Logger
+	logSampling          int
+	drain                <-chan struct{}
+	valuePool            *ValuePool
+	batchSize            int
+	batchRequest         int
 }
 
 func (fdo *funcOption) toPropagate() bool {
@@ -54,6 +91,17 @@ func (fdo *funcOption) buildChannel() chan Item {
 	return make(chan Item)
 }
 
+func (fdo *funcOption) getBufferedChannelCapacity() int {
+	if fdo.isBuffer {
+		return fdo.buffer
+	}
+	return 0
+}
+
+func (fdo *funcOption) getChannelCapacity() (bool, int) {
+	return fdo.isBuffer, fdo.buffer
+}
+
 func (fdo *funcOption) buildContext() context.Context {
 	if fdo.ctx == nil {
 		return context.Background()
@@ -88,6 +136,76 @@ func (fdo *funcOption) isSerialized() (bool, func(interface{}) int) {
 	return true, fdo.serialized
 }
 
+func (fdo *funcOption) getClock() Clock {
+	if fdo.clock == nil {
+		return realClock{}
+	}
+	return fdo.clock
+}
+
+func (fdo *funcOption) isErrorValues() bool {
+	return fdo.errorValues
+}
+
+func (fdo *funcOption) isOrderPreserved() bool {
+	return fdo.orderPreserved
+}
+
+func (fdo *funcOption) getPanicStrategy() PanicStrategy {
+	return fdo.panicStrategy
+}
+
+func (fdo *funcOption) getObserverScheduler() Scheduler {
+	return fdo.observerScheduler
+}
+
+func (fdo *funcOption) getScheduler() Scheduler {
+	return fdo.scheduler
+}
+
+func (fdo *funcOption) isStreamErrors() bool {
+	return fdo.streamErrors
+}
+
+func (fdo *funcOption) getObserverInterceptors() []ObserverInterceptor {
+	return fdo.observerInterceptors
+}
+
+func (fdo *funcOption) getMetrics() Metrics {
+	return fdo.metrics
+}
+
+func (fdo *funcOption) getTracer() Tracer {
+	return fdo.tracer
+}
+
+func (fdo *funcOption) getLogger() (Logger, int) {
+	if fdo.logSampling < 1 {
+		return fdo.logger, 1
+	}
+	return fdo.logger, fdo.logSampling
+}
+
+func (fdo *funcOption) getDrain() <-chan struct{} {
+	return fdo.drain
+}
+
+func (fdo *funcOption) getValuePool() *ValuePool {
+	return fdo.valuePool
+}
+
+func (fdo *funcOption) getBatchSize() int {
+	return fdo.batchSize
+}
+
+func (fdo *funcOption) getBatchRequest() int {
+	return fdo.batchRequest
+}
+
+func (fdo *funcOption) isItemContextRequested() bool {
+	return fdo.itemContexts
+}
+
 func newFuncOption(f func(*funcOption)) *funcOption {
 	return &funcOption{
 		f: f,
@@ -102,7 +220,114 @@ func parseOptions(opts ...Option) Option {
 	return o
 }
 
+// mergeOptions merges the options of an Observable with the ones propagated when it is observed, the latter
+// taking precedence except for the channel capacity: the one set on the Observable is kept.
+func mergeOptions(opts []Option, propagatedOptions []Option) []Option {
+	merged := make([]Option, 0, len(opts)+len(propagatedOptions)+1)
+	merged = append(merged, opts...)
+	merged = append(merged, propagatedOptions...)
+	if set, capacity := parseOptions(opts...).getChannelCapacity(); set {
+		merged = append(merged, WithBufferedChannel(capacity))
+	}
+	// The batches are only requested from the source observed directly
+	return append(merged, noBatchRequest)
+}
+
+// ignoredOption is embedded by the options read by a single factory or operator, such as CSVOption, so that they
+// are passed along with the other options without changing them.
+var ignoredOption Option = newFuncOption(func(*funcOption) {})
+
+// CSVOption is an Option read by FromCSV only, see WithCSVHeader, WithCSVDelimiter and WithCSVSkipInvalidRecords.
+type CSVOption struct {
+	Option
+	configure func(*csvConfig)
+}
+
+type csvConfig struct {
+	header      bool
+	delimiter   rune
+	skipInvalid bool
+}
+
+func parseCSVOptions(opts ...Option) csvConfig {
+	var config csvConfig
+	for _, opt := range opts {
+		if o, ok := opt.(CSVOption); ok {
+			o.configure(&config)
+		}
+	}
+	return config
+}
+
+// FetchOption is an Option read by FromPaginatedFetch only, see WithFetchBackOff.
+type FetchOption struct {
+	Option
+	backOff func() backoff.BackOff
+}
+
+// parseFetchBackOff returns the factory of the last FetchOption, defaultFetchBackOff if none.
+func parseFetchBackOff(opts ...Option) func() backoff.BackOff {
+	factory := defaultFetchBackOff
+	for _, opt := range opts {
+		if o, ok := opt.(FetchOption); ok && o.backOff != nil {
+			factory = o.backOff
+		}
+	}
+	return factory
+}
+
+// SSEOption is an Option read by FromSSE only, see WithSSEBackOff.
+type SSEOption struct {
+	Option
+	backOff func() backoff.BackOff
+}
+
+// parseSSEBackOff returns the factory of the last SSEOption, defaultSSEBackOff if none.
+func parseSSEBackOff(opts ...Option) func() backoff.BackOff {
+	factory := defaultSSEBackOff
+	for _, opt := range opts {
+		if o, ok := opt.(SSEOption); ok && o.backOff != nil {
+			factory = o.backOff
+		}
+	}
+	return factory
+}
+
+// TimeoutOption is an Option read by Timeout and TimeoutWith only, see WithTimeoutStrategy.
+type TimeoutOption struct {
+	Option
+	strategy TimeoutStrategy
+}
+
+func parseTimeoutStrategy(opts ...Option) TimeoutStrategy {
+	strategy := TimeoutEachItem
+	for _, opt := range opts {
+		if o, ok := opt.(TimeoutOption); ok {
+			strategy = o.strategy
+		}
+	}
+	return strategy
+}
+
+// MailboxOption is an Option read by ObserveOn only, see WithMailbox.
+type MailboxOption struct {
+	Option
+	capacity int
+}
+
+func parseMailboxCapacity(opts ...Option) int {
+	capacity := 0
+	for _, opt := range opts {
+		if o, ok := opt.(MailboxOption); ok {
+			capacity = o.capacity
+		}
+	}
+	return capacity
+}
+
 // WithBufferedChannel allows to configure the capacity of a buffered channel.
+// Set on an operator, the capacity applies to its channels, whatever the capacity set by the downstream
+// Observables.
 func WithBufferedChannel(capacity int) Option {
 	return newFuncOption(func(options *funcOption) {
 		options.isBuffer = true
@@ -110,6 +335,12 @@ func WithBufferedChannel(capacity int) Option {
 	})
 }
 
+// WithUnbufferedChannel makes the channels unbuffered, e.g. to override the capacity set by the downstream
+// Observables.
+func WithUnbufferedChannel() Option {
+	return WithBufferedChannel(0)
+}
+
 // WithContext allows to pass a context.
 func WithContext(ctx context.Context) Option {
 	return newFuncOption(func(options *funcOption) {
@@ -117,6 +348,13 @@ func WithContext(ctx context.Context) Option {
 	})
 }
 
+// WithClock sets the clock used by the time-based operators (a TestScheduler for example).
+func WithClock(clock Clock) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.clock = clock
+	})
+}
+
 // WithObservationStrategy uses the eager observation mode meaning consuming the items even without subscription.
 func WithObservationStrategy(strategy ObservationStrategy) Option {
 	return newFuncOption(func(options *funcOption) {
@@ -138,6 +376,13 @@ func WithCPUPool() Option {
 	})
 }
 
+// WithPreservedOrder makes a parallel Map emit the items in the order of the source items.
+func WithPreservedOrder() Option {
+	return newFuncOption(func(options *funcOption) {
+		options.orderPreserved = true
+	})
+}
+
 // WithBackPressureStrategy sets the back pressure strategy: drop or block.
 func WithBackPressureStrategy(strategy BackpressureStrategy) Option {
 	return newFuncOption(func(options *funcOption) {
@@ -153,6 +398,18 @@ func WithErrorStrategy(strategy OnErrorStrategy) Option {
 	})
 }
 
+// WithPanicStrategy defines how a panic in a function passed to an operator, or in an Observer, is handled.
+func WithPanicStrategy(strategy PanicStrategy) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.panicStrategy = strategy
+	})
+}
+
+// WithTimeoutStrategy defines when the timespan of Timeout and TimeoutWith is measured.
+func WithTimeoutStrategy(strategy TimeoutStrategy) TimeoutOption {
+	return TimeoutOption{Option: ignoredOption, strategy: strategy}
+}
+
 // WithPublishStrategy converts an ordinary Observable into a connectable Observable.
 func WithPublishStrategy() Option {
 	return newFuncOption(func(options *funcOption) {
@@ -160,6 +417,158 @@ func WithPublishStrategy() Option {
 	})
 }
 
+// WithErrorValues makes ToChannel send the errors as values instead of closing the channel silently.
+func WithErrorValues() Option {
+	return newFuncOption(func(options *funcOption) {
+		options.errorValues = true
+	})
+}
+
+// WithStreamErrors makes an operator wrap the errors it emits into StreamErrors, recording its name, the index
+// of the item being processed and the stack trace of its creation.
+func WithStreamErrors() Option {
+	return newFuncOption(func(options *funcOption) {
+		options.streamErrors = true
+	})
+}
+
+// WithCSVHeader makes FromCSV use the first record as a header: the next records are emitted as
+// map[string]string, keyed by the header columns.
+func WithCSVHeader() CSVOption {
+	return CSVOption{Option: ignoredOption, configure: func(config *csvConfig) {
+		config.header = true
+	}}
+}
+
+// WithCSVDelimiter sets the field delimiter of FromCSV, a comma by default.
+func WithCSVDelimiter(delimiter rune) CSVOption {
+	return CSVOption{Option: ignoredOption, configure: func(config *csvConfig) {
+		config.delimiter = delimiter
+	}}
+}
+
+// WithCSVSkipInvalidRecords makes FromCSV skip the records which cannot be parsed instead of emitting an error.
+func WithCSVSkipInvalidRecords() CSVOption {
+	return CSVOption{Option: ignoredOption, configure: func(config *csvConfig) {
+		config.skipInvalid = true
+	}}
+}
+
+// WithFetchBackOff sets the factory of the policy used by FromPaginatedFetch to retry a page, instead of 5
+// retries with an exponential backoff from 500 milliseconds. As a policy is stateful, factory is called once
+// per Observe so that concurrent observers never share one.
+func WithFetchBackOff(factory func() backoff.BackOff) FetchOption {
+	return FetchOption{Option: ignoredOption, backOff: factory}
+}
+
+// WithSSEBackOff sets the factory of the policy used by FromSSE to reconnect after a request failure or an error
+// status, instead of 5 retries with an exponential backoff from 1 second. The factory is called once per Observe,
+// the policy being reset once connected.
+func WithSSEBackOff(factory func() backoff.BackOff) SSEOption {
+	return SSEOption{Option: ignoredOption, backOff: factory}
+}
+
+// WithObserverInterceptors wraps the Observer of a subscription made with Subscribe, BlockingSubscribe or ForEach
+// with interceptors, the first one being the outermost. They are wrapped by the global interceptors, see
+// RegisterObserverInterceptor.
+func WithObserverInterceptors(interceptors ...ObserverInterceptor) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.observerInterceptors = append(options.observerInterceptors, interceptors...)
+	})
+}
+
+// WithMetrics records the activity of an operator, or of a source applying a backpressure strategy, with metrics.
+// Unlike most options, it only applies to the Observable created, not to its parents.
+func WithMetrics(metrics Metrics) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.metrics = metrics
+	})
+}
+
+// WithTracer makes an operator start a span for each item it processes, as a child of the span carried by the
+// item context (see Item.WithContext). The items emitted carry the new span, recording the errors emitted.
+// The operators combining or flattening Observables, such as FlatMap or Merge, start a single span per
+// subscription instead. Unlike most options, it only applies to the Observable created, not to its parents.
+func WithTracer(tracer Tracer) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.tracer = tracer
+	})
+}
+
+// WithLogger logs the subscriptions to an operator, its terminal events and disposals, along with one item out of
+// sampling at debug level (every item if sampling is not greater than one). The messages are tagged with the
+// subscription ID and the operator name. Unlike most options, it only applies to the Observable created, not to
+// its parents.
+func WithLogger(logger Logger, sampling int) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.logger = logger
+		options.logSampling = sampling
+	})
+}
+
+// WithValuePool puts the values of the items back in pool once processed by the observer of ForEach, Subscribe,
+// SubscribeAwait, BlockingForEach or BlockingSubscribe. The observer must not retain the values.
+func WithValuePool(pool *ValuePool) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.valuePool = pool
+	})
+}
+
+// WithBatchSize makes a sequential operator request batches of up to size items from its source, amortizing the
+// channel synchronization. The functions of the operator are still called for each item. The batches are sent by
+// the Range source and by Just, other sources sending their items one by one.
+func WithBatchSize(size int) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.batchSize = size
+	})
+}
+
+// WithMailbox sets the capacity of the mailbox holding the items handed off by ObserveOn, a single item by
+// default. The strategy applied once it is full is set with WithBackPressureStrategy: Block blocks the source,
+// Latest drops the oldest item, Drop drops the newest one and Fail terminates the observer with a
+// BackpressureError.
+func WithMailbox(capacity int) MailboxOption {
+	return MailboxOption{Option: ignoredOption, capacity: capacity}
+}
+
+// WithScheduler makes an operator run in parallel with WithPool or WithCPUPool process each item from a task run
+// by the given Scheduler, e.g. a PoolScheduler shared by several operators, instead of its own goroutines.
+// The pool still bounds the number of items processed concurrently by the operator. If the scheduler rejects a task,
+// a RejectedTaskError is emitted.
+// Expand observes each expanded Observable from a task run by the given Scheduler too.
+func WithScheduler(scheduler Scheduler) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.scheduler = scheduler
+	})
+}
+
+// WithObserverScheduler makes Subscribe, SubscribeAwait, BlockingSubscribe and ForEach call the Observer from
+// tasks run by the given Scheduler, e.g. an EventLoopScheduler shared by several subscriptions. Each call is
+// awaited: the Observer must not wait for a task of the same scheduler. A call rejected by the scheduler is not
+// made, a rejected OnNext failing the subscription with an error wrapping a RejectedTaskError.
+func WithObserverScheduler(scheduler Scheduler) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.observerScheduler = scheduler
+	})
+}
+
+// requestBatches is passed by an operator created with WithBatchSize to the source it observes.
+func requestBatches(size int) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.batchRequest = size
+	})
+}
+
+var noBatchRequest = requestBatches(0)
+
+// requestItemContexts is set by an operator unwrapping the values carrying a context (see Item.WithContext) when
+// observing its source, for the source to emit such values in turn.
+func requestItemContexts(requested bool) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.itemContexts = requested
+	})
+}
+
 // Serialize forces an Observable to make serialized calls and to be well-behaved.
 func Serialize(identifier func(interface{}) int) Option {
 	return newFuncOption(func(options *funcOption) {
@@ -167,6 +576,13 @@ func Serialize(identifier func(interface{}) int) Option {
 	})
 }
 
+// withDrain propagates the drain signal of a Subscription to the operators.
+func withDrain(drain <-chan struct{}) Option {
+	return newFuncOption(func(options *funcOption) {
+		options.drain = drain
+	})
+}
+
 func connect() Option {
 	return newFuncOption(func(options *funcOption) {
 		options.connectOperation = true
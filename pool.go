@@ -0,0 +1,42 @@
+package rxgo
+
+import (
+	"sync"
+)
+
+// ValuePool is a pool of values reused across emissions, e.g. pointers to structs, to avoid allocating a value per
+// item in high-throughput pipelines. The values are put back in the pool once processed by the observer, see
+// WithValuePool.
+type ValuePool struct {
+	pool sync.Pool
+	owns func(interface{}) bool
+}
+
+// NewValuePool creates a ValuePool allocating its values with newValue. owns reports whether a value processed by
+// an observer comes from the pool, e.g. with a type assertion, so that the values emitted by another source are
+// not put in it.
+func NewValuePool(newValue func() interface{}, owns func(interface{}) bool) *ValuePool {
+	return &ValuePool{
+		pool: sync.Pool{New: newValue},
+		owns: owns,
+	}
+}
+
+// Get returns a value from the pool, allocating it if the pool is empty.
+func (p *ValuePool) Get() interface{} {
+	return p.pool.Get()
+}
+
+// Put puts a value back in the pool. The values not owned by the pool are ignored.
+func (p *ValuePool) Put(v interface{}) {
+	if v != nil && p.owns(v) {
+		p.pool.Put(v)
+	}
+}
+
+// recycle puts a value processed by an observer back in the pool set with WithValuePool, if any.
+func recycle(pool *ValuePool, v interface{}) {
+	if pool != nil {
+		pool.Put(v)
+	}
+}
@@ -0,0 +1,72 @@
+package rxgo
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type pooledValue struct {
+	n int
+}
+
+func ownsPooledValue(v interface{}) bool {
+	_, ok := v.(*pooledValue)
+	return ok
+}
+
+func Test_ValuePool_Recycle(t *testing.T) {
+	var allocated int32
+	pool := NewValuePool(func() interface{} {
+		atomic.AddInt32(&allocated, 1)
+		return &pooledValue{}
+	}, ownsPooledValue)
+
+	const items = 100
+	sum := 0
+	err := Range(0, items-1).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		v := pool.Get().(*pooledValue)
+		v.n = i.(int)
+		return v, nil
+	}).BlockingForEach(context.Background(), func(i interface{}) error {
+		sum += i.(*pooledValue).n
+		return nil
+	}, WithValuePool(pool))
+	assert.NoError(t, err)
+	assert.Equal(t, items*(items-1)/2, sum)
+	// The values are reused once processed
+	assert.Less(t, int(atomic.LoadInt32(&allocated)), items)
+}
+
+func Test_ValuePool_OtherType(t *testing.T) {
+	pool := NewValuePool(func() interface{} {
+		return &pooledValue{}
+	}, ownsPooledValue)
+	pool.Put(1)
+	pool.Put(nil)
+	_, ok := pool.Get().(*pooledValue)
+	assert.True(t, ok)
+}
+
+func Test_ValuePool_Subscribe(t *testing.T) {
+	pool := NewValuePool(func() interface{} {
+		return &pooledValue{}
+	}, ownsPooledValue)
+	observer := &recordingObserver{}
+	<-Just(1, 2)().Subscribe(observer, WithValuePool(pool))
+	events, _ := observer.recorded()
+	assert.Equal(t, []string{"next: 1", "next: 2", "completed"}, events)
+}
+
+func Test_ValuePool_BlockingLast(t *testing.T) {
+	pool := NewValuePool(func() interface{} {
+		return &pooledValue{}
+	}, ownsPooledValue)
+	last := &pooledValue{n: 2}
+	v, err := Just(&pooledValue{n: 1}, last)().BlockingLast(context.Background(), WithValuePool(pool))
+	assert.NoError(t, err)
+	assert.Same(t, last, v)
+	assert.Equal(t, 2, last.n)
+}
@@ -0,0 +1,48 @@
+package rxgo
+
+import "sync/atomic"
+
+// ringBuffer is a preallocated circular buffer of items. A single producer calling push and a single consumer
+// calling peek and pop can use it without lock. Popping from the producer side, e.g. to drop the oldest item with
+// the Latest strategy, requires the callers to synchronize.
+type ringBuffer struct {
+	// head is the index of the next item to read and tail the index of the next slot to write, both increasing
+	// forever: their values modulo the capacity give the position in items.
+	head  uint64
+	tail  uint64
+	items []Item
+}
+
+func newRingBuffer(capacity int) *ringBuffer {
+	return &ringBuffer{
+		items: make([]Item, capacity),
+	}
+}
+
+// push adds an item, it returns false if the buffer is full.
+func (r *ringBuffer) push(item Item) bool {
+	tail := atomic.LoadUint64(&r.tail)
+	if tail-atomic.LoadUint64(&r.head) == uint64(len(r.items)) {
+		return false
+	}
+	r.items[tail%uint64(len(r.items))] = item
+	atomic.StoreUint64(&r.tail, tail+1)
+	return true
+}
+
+// peek returns the oldest item along with its index, without removing it.
+func (r *ringBuffer) peek() (Item, uint64, bool) {
+	head := atomic.LoadUint64(&r.head)
+	if head == atomic.LoadUint64(&r.tail) {
+		return Item{}, head, false
+	}
+	return r.items[head%uint64(len(r.items))], head, true
+}
+
+// pop removes the oldest item, the buffer being not empty.
+func (r *ringBuffer) pop() {
+	head := atomic.LoadUint64(&r.head)
+	// The slot no longer references the item
+	r.items[head%uint64(len(r.items))] = Item{}
+	atomic.StoreUint64(&r.head, head+1)
+}
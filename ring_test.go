@@ -0,0 +1,59 @@
+package rxgo
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RingBuffer(t *testing.T) {
+	r := newRingBuffer(2)
+	_, _, ok := r.peek()
+	assert.False(t, ok)
+
+	assert.True(t, r.push(Of(1)))
+	assert.True(t, r.push(Of(2)))
+	assert.False(t, r.push(Of(3)))
+
+	item, index, ok := r.peek()
+	assert.True(t, ok)
+	assert.Equal(t, 1, item.V)
+	assert.Equal(t, uint64(0), index)
+	r.pop()
+
+	// The slots are reused once the items are popped
+	assert.True(t, r.push(Of(3)))
+	for _, expected := range []int{2, 3} {
+		item, _, ok = r.peek()
+		assert.True(t, ok)
+		assert.Equal(t, expected, item.V)
+		r.pop()
+	}
+	_, index, ok = r.peek()
+	assert.False(t, ok)
+	assert.Equal(t, uint64(3), index)
+}
+
+func Test_RingBuffer_Concurrent(t *testing.T) {
+	r := newRingBuffer(4)
+	const n = 100
+	go func() {
+		for i := 0; i < n; {
+			if r.push(Of(i)) {
+				i++
+			} else {
+				runtime.Gosched()
+			}
+		}
+	}()
+	for i := 0; i < n; {
+		if item, _, ok := r.peek(); ok {
+			assert.Equal(t, i, item.V)
+			r.pop()
+			i++
+		} else {
+			runtime.Gosched()
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// Package rxfsnotify turns the notifications of a file watcher into an RxGo Observable, coalescing the bursts of
+// events an editor or a deployment produces on a file, e.g. a create followed by several writes.
+//
+// There is no FromFileWatcher(paths ...string) creating the watcher: the module does not depend on
+// github.com/fsnotify/fsnotify, whose watcher is platform specific and must be closed by its owner. The caller
+// creates the watcher and adds the paths to watch, FromWatcher reading its event and error channels:
+//
+//	watcher, err := fsnotify.NewWatcher()
+//	if err != nil {
+//		return err
+//	}
+//	defer watcher.Close()
+//	if err := watcher.Add("config"); err != nil {
+//		return err
+//	}
+//	observable := rxfsnotify.FromWatcher(watcher.Events, watcher.Errors, rxfsnotify.Config[fsnotify.Event]{
+//		Coalesce: 100 * time.Millisecond,
+//		Key: func(e fsnotify.Event) string {
+//			return e.Name
+//		},
+//	})
+//
+// The package requires Go 1.18 or later.
+package rxfsnotify
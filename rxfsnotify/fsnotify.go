@@ -0,0 +1,115 @@
+//go:build go1.18
+// +build go1.18
+
+package rxfsnotify
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/reactivex/rxgo/v2"
+)
+
+// Config configures FromWatcher.
+type Config[E any] struct {
+	// Coalesce enables the coalescing of the events: the events of a file are dropped until no event occurred
+	// on this file for Coalesce, the last one being then emitted. The events are emitted as they are if zero.
+	Coalesce time.Duration
+	// Key returns the file of an event. It is required to coalesce the events.
+	Key func(E) string
+}
+
+type pendingEvent[E any] struct {
+	event    E
+	deadline time.Time
+}
+
+// FromWatcher creates an Observable emitting the events received from a watcher. The Observable completes once
+// events is closed, the pending coalesced events being emitted first. An error received from errs is emitted and
+// stops the Observable. The channels are read as long as an observer is subscribed.
+//
+// The coalescing follows the clock set with rxgo.WithClock, e.g. a TestScheduler.
+func FromWatcher[E any](events <-chan E, errs <-chan error, config Config[E], opts ...rxgo.Option) rxgo.Observable {
+	clock := rxgo.ClockOf(opts...)
+	return rxgo.Defer([]rxgo.Producer{func(ctx context.Context, next chan<- rxgo.Item) {
+		pending := make(map[string]*pendingEvent[E])
+		var timer rxgo.ClockTimer
+		var timerC <-chan time.Time
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+
+		// flush emits the pending events whose deadline is before until, by deadline, and resets the timer from now
+		flush := func(now, until time.Time) bool {
+			keys := make([]string, 0, len(pending))
+			for key, p := range pending {
+				if !p.deadline.After(until) {
+					keys = append(keys, key)
+				}
+			}
+			sort.Slice(keys, func(i, j int) bool {
+				return pending[keys[i]].deadline.Before(pending[keys[j]].deadline)
+			})
+			for _, key := range keys {
+				if !rxgo.Of(pending[key].event).SendContext(ctx, next) {
+					return false
+				}
+				delete(pending, key)
+			}
+
+			timerC = nil
+			var earliest time.Time
+			for _, p := range pending {
+				if earliest.IsZero() || p.deadline.Before(earliest) {
+					earliest = p.deadline
+				}
+			}
+			if !earliest.IsZero() {
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = clock.NewTimer(earliest.Sub(now))
+				timerC = timer.C()
+			}
+			return true
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-events:
+				now := clock.Now()
+				if !ok {
+					flush(now, now.Add(config.Coalesce))
+					return
+				}
+				if config.Coalesce <= 0 || config.Key == nil {
+					if !rxgo.Of(event).SendContext(ctx, next) {
+						return
+					}
+					continue
+				}
+				pending[config.Key(event)] = &pendingEvent[E]{event: event, deadline: now.Add(config.Coalesce)}
+				if timerC == nil {
+					// Nothing is due yet: only arm the timer
+					flush(now, time.Time{})
+				}
+			case err, ok := <-errs:
+				if !ok {
+					errs = nil
+					continue
+				}
+				rxgo.Error(err).SendContext(ctx, next)
+				return
+			case now := <-timerC:
+				if !flush(now, now) {
+					return
+				}
+			}
+		}
+	}}, opts...)
+}
@@ -0,0 +1,88 @@
+//go:build go1.18
+// +build go1.18
+
+package rxfsnotify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/v2"
+)
+
+var errFoo = errors.New("foo")
+
+type event struct {
+	name string
+	op   string
+}
+
+func Test_FromWatcher(t *testing.T) {
+	events := make(chan event, 2)
+	events <- event{name: "a", op: "create"}
+	events <- event{name: "a", op: "write"}
+	close(events)
+
+	obs := FromWatcher(events, nil, Config[event]{})
+	rxgo.Assert(context.Background(), t, obs, rxgo.HasItems(event{name: "a", op: "create"}, event{name: "a", op: "write"}), rxgo.HasNoError())
+}
+
+// readClock signals each read of the time, i.e. each event processed by FromWatcher.
+type readClock struct {
+	*rxgo.TestScheduler
+	read chan struct{}
+}
+
+func (c readClock) Now() time.Time {
+	now := c.TestScheduler.Now()
+	c.read <- struct{}{}
+	return now
+}
+
+func Test_FromWatcher_Coalesce(t *testing.T) {
+	scheduler := readClock{TestScheduler: rxgo.NewTestScheduler(time.Unix(0, 0)), read: make(chan struct{}, 1)}
+	events := make(chan event)
+	send := func(e event) {
+		events <- e
+		<-scheduler.read
+	}
+	go func() {
+		send(event{name: "a", op: "create"})
+		scheduler.BlockUntil(1)
+		scheduler.AdvanceTimeBy(5 * time.Millisecond)
+		send(event{name: "b", op: "create"})
+		scheduler.AdvanceTimeBy(5 * time.Millisecond)
+		send(event{name: "a", op: "write"})
+		// The timer is re-armed at 25ms, b being emitted, then at 30ms, a being emitted
+		for _, ms := range []int64{20, 25, 30} {
+			scheduler.AdvanceTimeTo(time.Unix(0, ms*int64(time.Millisecond)))
+			if ms < 30 {
+				scheduler.BlockUntil(1)
+			}
+		}
+		send(event{name: "a", op: "remove"})
+		close(events)
+	}()
+
+	obs := FromWatcher(events, nil, Config[event]{
+		Coalesce: 20 * time.Millisecond,
+		Key: func(e event) string {
+			return e.name
+		},
+	}, rxgo.WithClock(scheduler))
+	rxgo.Assert(context.Background(), t, obs, rxgo.HasItems(
+		event{name: "b", op: "create"},
+		event{name: "a", op: "write"},
+		event{name: "a", op: "remove"},
+	), rxgo.HasNoError())
+}
+
+func Test_FromWatcher_Error(t *testing.T) {
+	events := make(chan event)
+	errs := make(chan error, 1)
+	errs <- errFoo
+	obs := FromWatcher(events, errs, Config[event]{})
+	rxgo.Assert(context.Background(), t, obs, rxgo.IsEmpty(), rxgo.HasError(errFoo))
+}
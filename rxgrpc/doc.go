@@ -0,0 +1,9 @@
+// Package rxgrpc turns the streaming RPCs of gRPC into RxGo Observables, and Observables into streaming RPCs.
+//
+// Receiver and Sender are the Recv and Send halves of the streams generated by protoc-gen-go-grpc: a
+// server-streaming client or a client-streaming server is observed with FromGRPCStream, whereas ToGRPCStream feeds
+// a client-streaming client or a server-streaming server. As Recv takes no context, an observation is cancelled
+// through the context of the call.
+//
+// The package requires Go 1.18 or later.
+package rxgrpc
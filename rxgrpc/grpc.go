@@ -0,0 +1,62 @@
+//go:build go1.18
+// +build go1.18
+
+package rxgrpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/reactivex/rxgo/v2"
+	"github.com/reactivex/rxgo/v2/internal/sink"
+)
+
+// Receiver is a stream receiving messages, e.g. a server-streaming or bidi client, or a client-streaming server.
+type Receiver[T any] interface {
+	// Recv blocks until the next message is received. It returns io.EOF once the stream ends.
+	Recv() (T, error)
+}
+
+// Sender is a stream sending messages, e.g. a client-streaming or bidi client, or a server-streaming server.
+type Sender[T any] interface {
+	Send(T) error
+}
+
+// FromGRPCStream creates an Observable emitting the messages received from a stream. The Observable completes
+// once the stream ends and a receiving error, e.g. a status error, is emitted as it is.
+// Recv is not interrupted by the observer context: the stream has to be created with a context cancelled at the
+// same time, the resulting error being then not emitted. The stream should be observed only once.
+func FromGRPCStream[T any](stream Receiver[T], opts ...rxgo.Option) rxgo.Observable {
+	return rxgo.Defer([]rxgo.Producer{func(ctx context.Context, next chan<- rxgo.Item) {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF && ctx.Err() == nil {
+					rxgo.Error(err).SendContext(ctx, next)
+				}
+				return
+			}
+			if !rxgo.Of(msg).SendContext(ctx, next) {
+				return
+			}
+		}
+	}}, opts...)
+}
+
+// ToGRPCStream sends the items emitted by an Observable to a stream, an item being a message of type T.
+// It blocks until the Observable terminates or ctx is cancelled, and returns the number of messages sent, along
+// with the first error: emitted by the Observable, returned by the stream, or the error of ctx. The stream is not
+// closed: a client has to call CloseSend, or CloseAndRecv for a client-streaming call.
+func ToGRPCStream[T any](ctx context.Context, stream Sender[T], observable rxgo.Observable, opts ...rxgo.Option) (int, error) {
+	return sink.Run(ctx, observable.Observe, func(_ context.Context, v interface{}) (int, error) {
+		msg, ok := v.(T)
+		if !ok {
+			return 0, fmt.Errorf("rxgrpc: unexpected item type %T", v)
+		}
+		if err := stream.Send(msg); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}, opts...)
+}
@@ -0,0 +1,77 @@
+//go:build go1.18
+// +build go1.18
+
+package rxgrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/reactivex/rxgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var errFoo = errors.New("foo")
+
+type message struct {
+	value string
+}
+
+// stream receives its messages, then err, and records the messages sent.
+type stream struct {
+	received []*message
+	err      error
+	sent     []*message
+}
+
+func (s *stream) Recv() (*message, error) {
+	if len(s.received) == 0 {
+		return nil, s.err
+	}
+	msg := s.received[0]
+	s.received = s.received[1:]
+	return msg, nil
+}
+
+func (s *stream) Send(msg *message) error {
+	if msg.value == "fail" {
+		return errFoo
+	}
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func Test_FromGRPCStream(t *testing.T) {
+	s := &stream{received: []*message{{value: "foo"}, {value: "bar"}}, err: io.EOF}
+	obs := FromGRPCStream[*message](s)
+	rxgo.Assert(context.Background(), t, obs, rxgo.HasItems(&message{value: "foo"}, &message{value: "bar"}), rxgo.HasNoError())
+}
+
+func Test_FromGRPCStream_Error(t *testing.T) {
+	s := &stream{received: []*message{{value: "foo"}}, err: errFoo}
+	obs := FromGRPCStream[*message](s)
+	rxgo.Assert(context.Background(), t, obs, rxgo.HasItems(&message{value: "foo"}), rxgo.HasError(errFoo))
+}
+
+func Test_ToGRPCStream(t *testing.T) {
+	s := &stream{}
+	n, err := ToGRPCStream[*message](context.Background(), s, rxgo.Just(&message{value: "foo"}, &message{value: "bar"})())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []*message{{value: "foo"}, {value: "bar"}}, s.sent)
+}
+
+func Test_ToGRPCStream_Error(t *testing.T) {
+	s := &stream{}
+	n, err := ToGRPCStream[*message](context.Background(), s, rxgo.Just(&message{value: "foo"}, errFoo)())
+	assert.Equal(t, errFoo, err)
+	assert.Equal(t, 1, n)
+
+	_, err = ToGRPCStream[*message](context.Background(), s, rxgo.Just(&message{value: "fail"})())
+	assert.Equal(t, errFoo, err)
+
+	_, err = ToGRPCStream[*message](context.Background(), s, rxgo.Just("foo")())
+	assert.EqualError(t, err, "rxgrpc: unexpected item type string")
+}
@@ -0,0 +1,12 @@
+// Package rxkafka streams Kafka messages through RxGo Observables with an at-least-once delivery.
+//
+// FromKafkaConsumer fetches a message only once the previous one has been consumed and leaves the commit of its
+// offset to the observer, once the message is processed. ToKafkaProducer writes the items by batches, bounded by
+// a count and a linger time. Reader and Writer are generic over the message type and match the *kafka.Reader and
+// *kafka.Writer of github.com/segmentio/kafka-go:
+//
+//	reader := kafka.NewReader(kafka.ReaderConfig{Brokers: brokers, GroupID: "billing", Topic: "orders"})
+//	records := rxkafka.FromKafkaConsumer[kafka.Message](reader)
+//
+// The package requires Go 1.18 or later.
+package rxkafka
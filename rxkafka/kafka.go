@@ -0,0 +1,89 @@
+//go:build go1.18
+// +build go1.18
+
+package rxkafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reactivex/rxgo/v2"
+	"github.com/reactivex/rxgo/v2/internal/sink"
+)
+
+// Reader is a Kafka consumer.
+type Reader[M any] interface {
+	// FetchMessage blocks until the next message is available, without committing it.
+	FetchMessage(ctx context.Context) (M, error)
+	// CommitMessages commits the offsets of the messages.
+	CommitMessages(ctx context.Context, msgs ...M) error
+}
+
+// Writer is a Kafka producer.
+type Writer[M any] interface {
+	// WriteMessages writes a batch of messages.
+	WriteMessages(ctx context.Context, msgs ...M) error
+}
+
+// Record is a message emitted by FromKafkaConsumer.
+type Record[M any] struct {
+	// Message is the message fetched, holding its topic, partition and offset metadata.
+	Message M
+	reader  Reader[M]
+}
+
+// Commit commits the offset of the message.
+func (r Record[M]) Commit(ctx context.Context) error {
+	return r.reader.CommitMessages(ctx, r.Message)
+}
+
+// FromKafkaConsumer creates an Observable emitting the messages fetched from a reader as Records, the offsets
+// being committed using Record.Commit.
+// A message is fetched only once the previous one has been consumed: the reader is not consumed faster than the
+// Observable, its own buffer bounding the memory used. A fetch error is emitted and stops the Observable.
+// Each observer fetches from the reader: to share it, see Observable.Publish.
+func FromKafkaConsumer[M any](reader Reader[M], opts ...rxgo.Option) rxgo.Observable {
+	return rxgo.Defer([]rxgo.Producer{func(ctx context.Context, next chan<- rxgo.Item) {
+		for {
+			msg, err := reader.FetchMessage(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					rxgo.Error(err).SendContext(ctx, next)
+				}
+				return
+			}
+			if !rxgo.Of(Record[M]{Message: msg, reader: reader}).SendContext(ctx, next) {
+				return
+			}
+		}
+	}}, opts...)
+}
+
+// ToKafkaProducer writes the messages emitted by an Observable to a writer, by batches of at most batchSize
+// messages. A batch is written once full, once linger has elapsed since the previous one, or once the
+// Observable completes. It blocks until the Observable terminates or ctx is cancelled, and returns the number of
+// messages written, along with the first error: emitted by the Observable, returned by the writer, or the error of
+// ctx. An item which is not of type M is an error.
+func ToKafkaProducer[M any](ctx context.Context, writer Writer[M], observable rxgo.Observable, batchSize int, linger rxgo.Duration, opts ...rxgo.Option) (int, error) {
+	observe := func(opts ...rxgo.Option) <-chan rxgo.Item {
+		return observable.BufferWithTimeOrCount(linger, batchSize, opts...).Observe(opts...)
+	}
+	return sink.Run(ctx, observe, func(ctx context.Context, v interface{}) (int, error) {
+		batch := v.([]interface{})
+		if len(batch) == 0 {
+			return 0, nil
+		}
+		msgs := make([]M, 0, len(batch))
+		for _, v := range batch {
+			msg, ok := v.(M)
+			if !ok {
+				return 0, fmt.Errorf("rxkafka: unexpected item type %T", v)
+			}
+			msgs = append(msgs, msg)
+		}
+		if err := writer.WriteMessages(ctx, msgs...); err != nil {
+			return 0, err
+		}
+		return len(msgs), nil
+	}, opts...)
+}
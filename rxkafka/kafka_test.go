@@ -0,0 +1,126 @@
+//go:build go1.18
+// +build go1.18
+
+package rxkafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var errFoo = errors.New("foo")
+
+type message struct {
+	offset int
+	value  string
+}
+
+// fakeReader returns the messages, then errFoo, and records the messages committed.
+type fakeReader struct {
+	mutex     sync.Mutex
+	messages  []message
+	fetched   int
+	committed []message
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (message, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if err := ctx.Err(); err != nil {
+		return message{}, err
+	}
+	if r.fetched == len(r.messages) {
+		return message{}, errFoo
+	}
+	r.fetched++
+	return r.messages[r.fetched-1], nil
+}
+
+func (r *fakeReader) CommitMessages(_ context.Context, msgs ...message) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.committed = append(r.committed, msgs...)
+	return nil
+}
+
+type fakeWriter struct {
+	batches [][]message
+	err     error
+}
+
+func (w *fakeWriter) WriteMessages(_ context.Context, msgs ...message) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.batches = append(w.batches, msgs)
+	return nil
+}
+
+func Test_FromKafkaConsumer(t *testing.T) {
+	reader := &fakeReader{messages: []message{{offset: 1, value: "foo"}, {offset: 2, value: "bar"}}}
+	var values []string
+	for item := range FromKafkaConsumer[message](reader).Observe() {
+		if item.Error() {
+			assert.Equal(t, errFoo, item.E)
+			break
+		}
+		record := item.V.(Record[message])
+		values = append(values, record.Message.value)
+		assert.NoError(t, record.Commit(context.Background()))
+	}
+	assert.Equal(t, []string{"foo", "bar"}, values)
+	assert.Equal(t, reader.messages, reader.committed)
+}
+
+func Test_FromKafkaConsumer_Backpressure(t *testing.T) {
+	reader := &fakeReader{messages: []message{{offset: 1}, {offset: 2}, {offset: 3}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	observe := FromKafkaConsumer[message](reader).Observe(rxgo.WithContext(ctx))
+	<-observe
+	time.Sleep(10 * time.Millisecond)
+	reader.mutex.Lock()
+	defer reader.mutex.Unlock()
+	// The second message is pending, the third one is not fetched
+	assert.Equal(t, 2, reader.fetched)
+}
+
+func Test_ToKafkaProducer(t *testing.T) {
+	writer := &fakeWriter{}
+	obs := rxgo.Just(message{offset: 1}, message{offset: 2}, message{offset: 3})()
+	n, err := ToKafkaProducer[message](context.Background(), writer, obs, 2, rxgo.WithDuration(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, [][]message{{{offset: 1}, {offset: 2}}, {{offset: 3}}}, writer.batches)
+}
+
+func Test_ToKafkaProducer_Linger(t *testing.T) {
+	writer := &fakeWriter{}
+	next := make(chan rxgo.Item)
+	go func() {
+		next <- rxgo.Of(message{offset: 1})
+		time.Sleep(50 * time.Millisecond)
+		next <- rxgo.Of(message{offset: 2})
+		close(next)
+	}()
+	n, err := ToKafkaProducer[message](context.Background(), writer, rxgo.FromChannel(next), 10, rxgo.WithDuration(10*time.Millisecond))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, [][]message{{{offset: 1}}, {{offset: 2}}}, writer.batches)
+}
+
+func Test_ToKafkaProducer_Error(t *testing.T) {
+	writer := &fakeWriter{err: errFoo}
+	n, err := ToKafkaProducer[message](context.Background(), writer, rxgo.Just(message{offset: 1})(), 1, rxgo.WithDuration(time.Hour))
+	assert.Equal(t, errFoo, err)
+	assert.Equal(t, 0, n)
+
+	_, err = ToKafkaProducer[message](context.Background(), &fakeWriter{}, rxgo.Just("foo")(), 1, rxgo.WithDuration(time.Hour))
+	assert.EqualError(t, err, "rxkafka: unexpected item type string")
+}
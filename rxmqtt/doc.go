@@ -0,0 +1,15 @@
+// Package rxmqtt observes MQTT topic filters and publishes Observables to a topic.
+//
+// The handler of a subscription blocks until its message is consumed downstream, so that a QoS 1 or 2 message is
+// acknowledged to the broker only once processed. As the MQTT clients report their results through tokens or
+// callbacks, Subscriber and Publisher are implemented by a small adapter, e.g. with
+// github.com/eclipse/paho.mqtt.golang:
+//
+//	func (c client) Subscribe(_ context.Context, topic string, qos byte, handler func(rxmqtt.Message)) error {
+//		token := c.Client.Subscribe(topic, qos, func(_ mqtt.Client, msg mqtt.Message) {
+//			handler(rxmqtt.Message{Topic: msg.Topic(), Payload: msg.Payload(), QoS: msg.Qos(), Retained: msg.Retained()})
+//		})
+//		token.Wait()
+//		return token.Error()
+//	}
+package rxmqtt
@@ -0,0 +1,80 @@
+package rxmqtt
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/reactivex/rxgo/v2"
+	"github.com/reactivex/rxgo/v2/internal/sink"
+)
+
+// Message is an MQTT message.
+type Message struct {
+	Topic    string
+	Payload  []byte
+	QoS      byte
+	Retained bool
+}
+
+// Subscriber subscribes to MQTT topics.
+type Subscriber interface {
+	// Subscribe subscribes to a topic filter, handler being called for each message received. The message is
+	// acknowledged, for QoS 1 and 2, once handler returns.
+	Subscribe(ctx context.Context, topic string, qos byte, handler func(Message)) error
+	// Unsubscribe unsubscribes from a topic filter.
+	Unsubscribe(ctx context.Context, topic string) error
+}
+
+// Publisher publishes MQTT messages.
+type Publisher interface {
+	// Publish publishes a message, waiting for its acknowledgement for QoS 1 and 2.
+	Publish(ctx context.Context, topic string, qos byte, retained bool, payload []byte) error
+}
+
+// FromMQTT creates an Observable emitting the Messages received on a topic filter.
+// Each observer subscribes to the topic filter, and unsubscribes once its context is cancelled. The handler
+// blocks until the message is consumed, so that a QoS 1 or 2 message is acknowledged only once consumed.
+// A subscription error is emitted and stops the Observable.
+func FromMQTT(subscriber Subscriber, topic string, qos byte, opts ...rxgo.Option) rxgo.Observable {
+	return rxgo.Defer([]rxgo.Producer{func(ctx context.Context, next chan<- rxgo.Item) {
+		// closed prevents the handler from sending once the producer returned
+		var mutex sync.RWMutex
+		closed := false
+		defer func() {
+			mutex.Lock()
+			closed = true
+			mutex.Unlock()
+		}()
+
+		err := subscriber.Subscribe(ctx, topic, qos, func(msg Message) {
+			mutex.RLock()
+			defer mutex.RUnlock()
+			if !closed {
+				rxgo.Of(msg).SendContext(ctx, next)
+			}
+		})
+		if err != nil {
+			rxgo.Error(err).SendContext(ctx, next)
+			return
+		}
+		<-ctx.Done()
+		_ = subscriber.Unsubscribe(context.Background(), topic)
+	}}, opts...)
+}
+
+// ToMQTT publishes the items emitted by an Observable to a topic, an item being either a []byte or a string.
+// It blocks until the Observable terminates or ctx is cancelled, and returns the number of messages published,
+// along with the first error: emitted by the Observable, returned by the publisher, or the error of ctx.
+func ToMQTT(ctx context.Context, publisher Publisher, topic string, qos byte, retained bool, observable rxgo.Observable, opts ...rxgo.Option) (int, error) {
+	return sink.Run(ctx, observable.Observe, func(ctx context.Context, v interface{}) (int, error) {
+		payload, ok := sink.Bytes(v)
+		if !ok {
+			return 0, fmt.Errorf("rxmqtt: unsupported item type %T", v)
+		}
+		if err := publisher.Publish(ctx, topic, qos, retained, payload); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}, opts...)
+}
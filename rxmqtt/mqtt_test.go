@@ -0,0 +1,97 @@
+package rxmqtt
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var errFoo = errors.New("foo")
+
+// broker delivers the messages published to the handlers subscribed, from the publishing goroutine.
+type broker struct {
+	mutex    sync.Mutex
+	handlers map[string]func(Message)
+}
+
+func newBroker() *broker {
+	return &broker{handlers: make(map[string]func(Message))}
+}
+
+func (b *broker) Subscribe(_ context.Context, topic string, _ byte, handler func(Message)) error {
+	if topic == "fail" {
+		return errFoo
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.handlers[topic] = handler
+	return nil
+}
+
+func (b *broker) Unsubscribe(_ context.Context, topic string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.handlers, topic)
+	return nil
+}
+
+func (b *broker) Publish(_ context.Context, topic string, qos byte, _ bool, payload []byte) error {
+	if topic == "fail" {
+		return errFoo
+	}
+	b.mutex.Lock()
+	handler := b.handlers[topic]
+	b.mutex.Unlock()
+	if handler != nil {
+		handler(Message{Topic: topic, Payload: payload, QoS: qos})
+	}
+	return nil
+}
+
+func (b *broker) subscribed(topic string) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.handlers[topic] != nil
+}
+
+func Test_FromMQTT(t *testing.T) {
+	b := newBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+	observe := FromMQTT(b, "topic", 1).Observe(rxgo.WithContext(ctx))
+	for !b.subscribed("topic") {
+		time.Sleep(time.Millisecond)
+	}
+
+	go func() {
+		_, _ = ToMQTT(context.Background(), b, "topic", 1, false, rxgo.Just("foo", "bar")())
+	}()
+	assert.Equal(t, Message{Topic: "topic", Payload: []byte("foo"), QoS: 1}, (<-observe).V)
+	assert.Equal(t, Message{Topic: "topic", Payload: []byte("bar"), QoS: 1}, (<-observe).V)
+
+	cancel()
+	for range observe {
+	}
+	assert.False(t, b.subscribed("topic"))
+}
+
+func Test_FromMQTT_Error(t *testing.T) {
+	rxgo.Assert(context.Background(), t, FromMQTT(newBroker(), "fail", 0), rxgo.IsEmpty(), rxgo.HasError(errFoo))
+}
+
+func Test_ToMQTT(t *testing.T) {
+	b := newBroker()
+	n, err := ToMQTT(context.Background(), b, "topic", 0, false, rxgo.Just("foo", errFoo)())
+	assert.Equal(t, errFoo, err)
+	assert.Equal(t, 1, n)
+
+	_, err = ToMQTT(context.Background(), b, "fail", 0, false, rxgo.Just("foo")())
+	assert.Equal(t, errFoo, err)
+
+	_, err = ToMQTT(context.Background(), b, "topic", 0, false, rxgo.Just(1)())
+	assert.EqualError(t, err, "rxmqtt: unsupported item type int")
+}
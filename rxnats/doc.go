@@ -0,0 +1,9 @@
+// Package rxnats observes NATS subscriptions, JetStream consumers included, and publishes Observables to a subject.
+//
+// Each message is emitted in an Envelope carrying its acknowledgement, so that a JetStream message is acked, or
+// naked to be redelivered, once processed downstream rather than once received. With github.com/nats-io/nats.go,
+// a *nats.Subscription is observed with FromNATSSubscription[*nats.Msg, nats.AckOpt] and a *nats.Conn is a
+// Publisher.
+//
+// The package requires Go 1.18 or later.
+package rxnats
@@ -0,0 +1,93 @@
+//go:build go1.18
+// +build go1.18
+
+package rxnats
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reactivex/rxgo/v2"
+	"github.com/reactivex/rxgo/v2/internal/sink"
+)
+
+// Msg is a message which can be acknowledged, O being the type of the acknowledgement options.
+type Msg[O any] interface {
+	// Ack acknowledges the message.
+	Ack(opts ...O) error
+	// Nak negatively acknowledges the message, so that it is redelivered.
+	Nak(opts ...O) error
+}
+
+// Subscription is a synchronous subscription.
+type Subscription[M any] interface {
+	// NextMsgWithContext blocks until the next message is received.
+	NextMsgWithContext(ctx context.Context) (M, error)
+}
+
+// Publisher publishes messages.
+type Publisher interface {
+	Publish(subject string, data []byte) error
+}
+
+// Envelope is a message emitted by FromNATSSubscription.
+type Envelope[M any] struct {
+	Msg M
+	ack func() error
+	nak func() error
+}
+
+// Ack acknowledges the message, see JetStream.
+func (e Envelope[M]) Ack() error {
+	return e.ack()
+}
+
+// Nak negatively acknowledges the message, so that JetStream redelivers it.
+func (e Envelope[M]) Nak() error {
+	return e.nak()
+}
+
+// FromNATSSubscription creates an Observable emitting the messages received by a subscription as Envelopes.
+// A message is requested only once the previous one has been consumed. A subscription error is emitted and
+// stops the Observable. Each observer reads from the subscription: to share it, see Observable.Publish.
+func FromNATSSubscription[M Msg[O], O any](subscription Subscription[M], opts ...rxgo.Option) rxgo.Observable {
+	return rxgo.Defer([]rxgo.Producer{func(ctx context.Context, next chan<- rxgo.Item) {
+		for {
+			msg, err := subscription.NextMsgWithContext(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					rxgo.Error(err).SendContext(ctx, next)
+				}
+				return
+			}
+			envelope := Envelope[M]{
+				Msg: msg,
+				ack: func() error {
+					return msg.Ack()
+				},
+				nak: func() error {
+					return msg.Nak()
+				},
+			}
+			if !rxgo.Of(envelope).SendContext(ctx, next) {
+				return
+			}
+		}
+	}}, opts...)
+}
+
+// ToNATSPublisher publishes the items emitted by an Observable to a subject, an item being either a []byte or
+// a string. It blocks until the Observable terminates or ctx is cancelled, and returns the number of messages
+// published, along with the first error: emitted by the Observable, returned by the publisher, or the error of ctx.
+func ToNATSPublisher(ctx context.Context, publisher Publisher, subject string, observable rxgo.Observable, opts ...rxgo.Option) (int, error) {
+	return sink.Run(ctx, observable.Observe, func(_ context.Context, v interface{}) (int, error) {
+		data, ok := sink.Bytes(v)
+		if !ok {
+			return 0, fmt.Errorf("rxnats: unsupported item type %T", v)
+		}
+		if err := publisher.Publish(subject, data); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}, opts...)
+}
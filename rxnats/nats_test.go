@@ -0,0 +1,99 @@
+//go:build go1.18
+// +build go1.18
+
+package rxnats
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/reactivex/rxgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var errFoo = errors.New("foo")
+
+type ackOpt struct{}
+
+type msg struct {
+	data  string
+	acked *[]string
+}
+
+func (m *msg) Ack(...ackOpt) error {
+	*m.acked = append(*m.acked, "ack: "+m.data)
+	return nil
+}
+
+func (m *msg) Nak(...ackOpt) error {
+	*m.acked = append(*m.acked, "nak: "+m.data)
+	return nil
+}
+
+type subscription struct {
+	msgs []*msg
+}
+
+func (s *subscription) NextMsgWithContext(ctx context.Context) (*msg, error) {
+	if len(s.msgs) == 0 {
+		return nil, errFoo
+	}
+	m := s.msgs[0]
+	s.msgs = s.msgs[1:]
+	return m, nil
+}
+
+type publisher struct {
+	published []string
+}
+
+func (p *publisher) Publish(subject string, data []byte) error {
+	if subject == "fail" {
+		return errFoo
+	}
+	p.published = append(p.published, subject+": "+string(data))
+	return nil
+}
+
+func Test_FromNATSSubscription(t *testing.T) {
+	var acked []string
+	sub := &subscription{msgs: []*msg{{data: "foo", acked: &acked}, {data: "bar", acked: &acked}}}
+	var data []string
+	for item := range FromNATSSubscription(sub).Observe() {
+		if item.Error() {
+			assert.Equal(t, errFoo, item.E)
+			break
+		}
+		envelope := item.V.(Envelope[*msg])
+		data = append(data, envelope.Msg.data)
+		if envelope.Msg.data == "foo" {
+			assert.NoError(t, envelope.Ack())
+		} else {
+			assert.NoError(t, envelope.Nak())
+		}
+	}
+	assert.Equal(t, []string{"foo", "bar"}, data)
+	assert.Equal(t, []string{"ack: foo", "nak: bar"}, acked)
+}
+
+func Test_ToNATSPublisher(t *testing.T) {
+	p := &publisher{}
+	n, err := ToNATSPublisher(context.Background(), p, "subject", rxgo.FromSlice([]interface{}{"foo", []byte("bar")}))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"subject: foo", "subject: bar"}, p.published)
+}
+
+func Test_ToNATSPublisher_Error(t *testing.T) {
+	p := &publisher{}
+	n, err := ToNATSPublisher(context.Background(), p, "subject", rxgo.Just("foo", errFoo, "bar")())
+	assert.Equal(t, errFoo, err)
+	assert.Equal(t, 1, n)
+
+	_, err = ToNATSPublisher(context.Background(), p, "fail", rxgo.Just("foo")())
+	assert.Equal(t, errFoo, err)
+
+	_, err = ToNATSPublisher(context.Background(), p, "subject", rxgo.Just(1)())
+	assert.EqualError(t, err, "rxnats: unsupported item type int")
+}
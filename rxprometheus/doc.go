@@ -0,0 +1,22 @@
+// Package rxprometheus records the activity of RxGo Observables into Prometheus metrics: the items, errors,
+// completions and drops, and the processing latency of the items.
+//
+// Each vector is labelled by the name given to the Observable with WithMetrics. The caller creates and registers
+// the vectors, choosing their names and histogram buckets, e.g. with github.com/prometheus/client_golang:
+//
+//	factory := promauto.With(registerer)
+//	metrics := rxprometheus.Metrics[prometheus.Counter, prometheus.Observer]{
+//		Items: factory.NewCounterVec(prometheus.CounterOpts{
+//			Name: "rxgo_items_total",
+//			Help: "Items emitted.",
+//		}, []string{"observable"}),
+//		Latency: factory.NewHistogramVec(prometheus.HistogramOpts{
+//			Name: "rxgo_item_processing_seconds",
+//			Help: "Item processing latency.",
+//		}, []string{"observable"}),
+//		// ...
+//	}
+//	observable.Map(parse, rxprometheus.WithMetrics(metrics, "parse"))
+//
+// The package requires Go 1.18 or later.
+package rxprometheus
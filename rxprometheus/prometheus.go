@@ -0,0 +1,108 @@
+//go:build go1.18
+// +build go1.18
+
+package rxprometheus
+
+import (
+	"time"
+
+	"github.com/reactivex/rxgo/v2"
+)
+
+// Counter is a counter, e.g. a prometheus.Counter.
+type Counter interface {
+	Inc()
+}
+
+// Observer records observations, e.g. a prometheus.Observer of a histogram.
+type Observer interface {
+	Observe(float64)
+}
+
+// CounterVec is a vector of counters, e.g. a *prometheus.CounterVec.
+type CounterVec[C Counter] interface {
+	WithLabelValues(lvs ...string) C
+}
+
+// ObserverVec is a vector of observers, e.g. a *prometheus.HistogramVec.
+type ObserverVec[O Observer] interface {
+	WithLabelValues(lvs ...string) O
+}
+
+// Metrics holds the metric vectors recording the activity of Observables. Each vector has a single label, set
+// to the name of the Observable. A nil vector is not recorded.
+type Metrics[C Counter, O Observer] struct {
+	// Items counts the items emitted.
+	Items CounterVec[C]
+	// Errors counts the errors emitted.
+	Errors CounterVec[C]
+	// Completions counts the completions without error.
+	Completions CounterVec[C]
+	// Drops counts the items dropped by the backpressure strategy.
+	Drops CounterVec[C]
+	// Latency observes the processing latency of each item, in seconds.
+	Latency ObserverVec[O]
+}
+
+// WithMetrics returns an option recording the activity of an Observable, named name, with metrics.
+// See rxgo.WithMetrics for the Observables recording their activity.
+func WithMetrics[C Counter, O Observer](metrics Metrics[C, O], name string) rxgo.Option {
+	return rxgo.WithMetrics(&recorder{
+		items:       counter(metrics.Items, name),
+		errors:      counter(metrics.Errors, name),
+		completions: counter(metrics.Completions, name),
+		drops:       counter(metrics.Drops, name),
+		latency:     observer(metrics.Latency, name),
+	})
+}
+
+func counter[C Counter](vec CounterVec[C], name string) Counter {
+	if vec == nil {
+		return nil
+	}
+	return vec.WithLabelValues(name)
+}
+
+func observer[O Observer](vec ObserverVec[O], name string) Observer {
+	if vec == nil {
+		return nil
+	}
+	return vec.WithLabelValues(name)
+}
+
+// recorder implements rxgo.Metrics.
+type recorder struct {
+	items       Counter
+	errors      Counter
+	completions Counter
+	drops       Counter
+	latency     Observer
+}
+
+func (r *recorder) ItemEmitted() {
+	inc(r.items)
+}
+
+func (r *recorder) ErrorEmitted() {
+	inc(r.errors)
+}
+
+func (r *recorder) Completed() {
+	inc(r.completions)
+}
+
+func (r *recorder) ItemDropped() {
+	inc(r.drops)
+}
+
+func (r *recorder) ItemProcessed(d time.Duration) {
+	if r.latency != nil {
+		r.latency.Observe(d.Seconds())
+	}
+}
+
+func inc(c Counter) {
+	if c != nil {
+		c.Inc()
+	}
+}
@@ -0,0 +1,97 @@
+//go:build go1.18
+// +build go1.18
+
+package rxprometheus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/reactivex/rxgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var errFoo = errors.New("foo")
+
+// metric is both a counter and an observer.
+type metric struct {
+	mutex        sync.Mutex
+	count        int
+	observations []float64
+}
+
+func (m *metric) Inc() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.count++
+}
+
+func (m *metric) Observe(v float64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.observations = append(m.observations, v)
+}
+
+// vec holds a metric per label value.
+type vec struct {
+	mutex   sync.Mutex
+	metrics map[string]*metric
+}
+
+func newVec() *vec {
+	return &vec{metrics: make(map[string]*metric)}
+}
+
+func (v *vec) WithLabelValues(lvs ...string) *metric {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	m, ok := v.metrics[lvs[0]]
+	if !ok {
+		m = &metric{}
+		v.metrics[lvs[0]] = m
+	}
+	return m
+}
+
+func (v *vec) count(name string) int {
+	return v.WithLabelValues(name).count
+}
+
+func Test_WithMetrics(t *testing.T) {
+	items, errs, completions, latency := newVec(), newVec(), newVec(), newVec()
+	metrics := Metrics[*metric, *metric]{
+		Items:       items,
+		Errors:      errs,
+		Completions: completions,
+		Latency:     latency,
+	}
+	double := func(_ context.Context, i interface{}) (interface{}, error) {
+		return i.(int) * 2, nil
+	}
+
+	obs := rxgo.Just(1, 2, 3)().Map(double, WithMetrics(metrics, "double"))
+	rxgo.Assert(context.Background(), t, obs, rxgo.HasItems(2, 4, 6))
+	obs = rxgo.Just(1, errFoo)().Map(double, WithMetrics(metrics, "failing"))
+	rxgo.Assert(context.Background(), t, obs, rxgo.HasItems(2), rxgo.HasError(errFoo))
+
+	assert.Equal(t, 3, items.count("double"))
+	assert.Equal(t, 0, errs.count("double"))
+	assert.Equal(t, 1, completions.count("double"))
+	assert.Len(t, latency.WithLabelValues("double").observations, 3)
+
+	assert.Equal(t, 1, items.count("failing"))
+	assert.Equal(t, 1, errs.count("failing"))
+	assert.Equal(t, 0, completions.count("failing"))
+}
+
+func Test_WithMetrics_Drops(t *testing.T) {
+	drops := newVec()
+	s := rxgo.PublishSubject(rxgo.WithBackPressureStrategy(rxgo.Drop),
+		WithMetrics(Metrics[*metric, *metric]{Drops: drops}, "subject"))
+	s.Observe()
+	s.OnNext(1)
+	s.OnNext(2)
+	assert.Equal(t, 1, drops.count("subject"))
+}
@@ -0,0 +1,23 @@
+// Package rxredis observes Redis Pub/Sub channels and Streams.
+//
+// A Pub/Sub message is lost if no observer is subscribed, whereas a stream entry read by a consumer group stays
+// pending until acknowledged with StreamMessage.Ack, the entries of a crashed consumer being claimed by another
+// one (see StreamConfig.MinIdle). The *redis.PubSub of github.com/redis/go-redis is a PubSub; the stream commands, whose replies differ
+// between clients, are wrapped by a StreamClient, e.g.:
+//
+//	func (c client) ReadGroup(ctx context.Context, stream, group, consumer string, count int64) ([]rxredis.StreamEntry, error) {
+//		streams, err := c.XReadGroup(ctx, &redis.XReadGroupArgs{
+//			Group: group, Consumer: consumer, Streams: []string{stream, ">"}, Count: count,
+//		}).Result()
+//		if err != nil {
+//			return nil, err
+//		}
+//		entries := make([]rxredis.StreamEntry, 0, len(streams[0].Messages))
+//		for _, msg := range streams[0].Messages {
+//			entries = append(entries, rxredis.StreamEntry{ID: msg.ID, Values: msg.Values})
+//		}
+//		return entries, nil
+//	}
+//
+// The package requires Go 1.18 or later.
+package rxredis
@@ -0,0 +1,127 @@
+//go:build go1.18
+// +build go1.18
+
+package rxredis
+
+import (
+	"context"
+	"time"
+
+	"github.com/reactivex/rxgo/v2"
+)
+
+// PubSub is a Pub/Sub subscription, reconnecting by itself if the connection is lost.
+type PubSub[M any] interface {
+	// ReceiveMessage blocks until the next message is received.
+	ReceiveMessage(ctx context.Context) (M, error)
+}
+
+// StreamEntry is an entry of a stream.
+type StreamEntry struct {
+	ID     string
+	Values map[string]interface{}
+}
+
+// StreamClient runs the stream commands of a consumer group.
+type StreamClient interface {
+	// ReadGroup reads at most count new entries for a consumer (XREADGROUP GROUP group consumer COUNT count
+	// BLOCK 0 STREAMS stream >), blocking until at least one is available.
+	ReadGroup(ctx context.Context, stream, group, consumer string, count int64) ([]StreamEntry, error)
+	// AutoClaim claims for a consumer at most count pending entries idle for at least minIdle, starting from
+	// start (XAUTOCLAIM stream group consumer minIdle start COUNT count). It returns the entries claimed and the
+	// start of the next call, "0-0" once all the pending entries have been scanned.
+	AutoClaim(ctx context.Context, stream, group, consumer string, minIdle time.Duration, start string, count int64) ([]StreamEntry, string, error)
+	// Ack acknowledges entries (XACK stream group id...).
+	Ack(ctx context.Context, stream, group string, ids ...string) error
+}
+
+// StreamConfig configures FromRedisStream.
+type StreamConfig struct {
+	Stream   string
+	Group    string
+	Consumer string
+	// Count is the maximum number of entries read at once, 10 by default.
+	Count int64
+	// MinIdle enables the recovery of the pending entries: the entries pending for at least MinIdle, e.g. those
+	// of a consumer which crashed, are claimed and emitted first. No entry is recovered if zero.
+	MinIdle time.Duration
+}
+
+// StreamMessage is an entry emitted by FromRedisStream.
+type StreamMessage struct {
+	StreamEntry
+	client StreamClient
+	config StreamConfig
+}
+
+// Ack acknowledges the entry so that it is removed from the pending entries of the group.
+func (m StreamMessage) Ack(ctx context.Context) error {
+	return m.client.Ack(ctx, m.config.Stream, m.config.Group, m.ID)
+}
+
+// FromRedisPubSub creates an Observable emitting the messages received by a Pub/Sub subscription.
+// A receiving error is emitted and stops the Observable. Each observer reads from the subscription: to share it,
+// see Observable.Publish.
+func FromRedisPubSub[M any](pubSub PubSub[M], opts ...rxgo.Option) rxgo.Observable {
+	return rxgo.Defer([]rxgo.Producer{func(ctx context.Context, next chan<- rxgo.Item) {
+		for {
+			msg, err := pubSub.ReceiveMessage(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					rxgo.Error(err).SendContext(ctx, next)
+				}
+				return
+			}
+			if !rxgo.Of(msg).SendContext(ctx, next) {
+				return
+			}
+		}
+	}}, opts...)
+}
+
+// FromRedisStream creates an Observable emitting the entries of a stream read by a consumer of a group, as
+// StreamMessages to acknowledge. The pending entries are recovered first, see StreamConfig.MinIdle.
+// A command error is emitted and stops the Observable: as the group keeps track of the entries delivered, the
+// consumer resumes where it stopped once resubscribed, e.g. using Retry or BackOffRetry.
+func FromRedisStream(client StreamClient, config StreamConfig, opts ...rxgo.Option) rxgo.Observable {
+	if config.Count <= 0 {
+		config.Count = 10
+	}
+	return rxgo.Defer([]rxgo.Producer{func(ctx context.Context, next chan<- rxgo.Item) {
+		send := func(entries []StreamEntry, err error) bool {
+			if err != nil {
+				if ctx.Err() == nil {
+					rxgo.Error(err).SendContext(ctx, next)
+				}
+				return false
+			}
+			for _, entry := range entries {
+				if !rxgo.Of(StreamMessage{StreamEntry: entry, client: client, config: config}).SendContext(ctx, next) {
+					return false
+				}
+			}
+			return true
+		}
+
+		if config.MinIdle > 0 {
+			start := "0-0"
+			for {
+				entries, nextStart, err := client.AutoClaim(ctx, config.Stream, config.Group, config.Consumer, config.MinIdle, start, config.Count)
+				if !send(entries, err) {
+					return
+				}
+				if nextStart == "0-0" {
+					break
+				}
+				start = nextStart
+			}
+		}
+
+		for {
+			entries, err := client.ReadGroup(ctx, config.Stream, config.Group, config.Consumer, config.Count)
+			if !send(entries, err) {
+				return
+			}
+		}
+	}}, opts...)
+}
@@ -0,0 +1,105 @@
+//go:build go1.18
+// +build go1.18
+
+package rxredis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var errFoo = errors.New("foo")
+
+type pubSub struct {
+	messages []string
+}
+
+func (p *pubSub) ReceiveMessage(context.Context) (string, error) {
+	if len(p.messages) == 0 {
+		return "", errFoo
+	}
+	msg := p.messages[0]
+	p.messages = p.messages[1:]
+	return msg, nil
+}
+
+// streamClient has pending entries, split in pages of one entry, then new entries and then fails.
+type streamClient struct {
+	mutex   sync.Mutex
+	pending []StreamEntry
+	entries [][]StreamEntry
+	acked   []string
+}
+
+func (c *streamClient) ReadGroup(_ context.Context, _, _, _ string, _ int64) ([]StreamEntry, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.entries) == 0 {
+		return nil, errFoo
+	}
+	entries := c.entries[0]
+	c.entries = c.entries[1:]
+	return entries, nil
+}
+
+func (c *streamClient) AutoClaim(_ context.Context, _, _, _ string, _ time.Duration, start string, _ int64) ([]StreamEntry, string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for i, entry := range c.pending {
+		if start == "0-0" || entry.ID == start {
+			if i+1 < len(c.pending) {
+				return []StreamEntry{entry}, c.pending[i+1].ID, nil
+			}
+			return []StreamEntry{entry}, "0-0", nil
+		}
+	}
+	return nil, "0-0", nil
+}
+
+func (c *streamClient) Ack(_ context.Context, _, _ string, ids ...string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.acked = append(c.acked, ids...)
+	return nil
+}
+
+func Test_FromRedisPubSub(t *testing.T) {
+	obs := FromRedisPubSub[string](&pubSub{messages: []string{"foo", "bar"}})
+	rxgo.Assert(context.Background(), t, obs, rxgo.HasItems("foo", "bar"), rxgo.HasError(errFoo))
+}
+
+func Test_FromRedisStream(t *testing.T) {
+	client := &streamClient{
+		pending: []StreamEntry{{ID: "1-0"}, {ID: "2-0"}},
+		entries: [][]StreamEntry{{{ID: "3-0"}, {ID: "4-0"}}, {{ID: "5-0"}}},
+	}
+	var ids []string
+	for item := range FromRedisStream(client, StreamConfig{MinIdle: time.Minute}).Observe() {
+		if item.Error() {
+			assert.Equal(t, errFoo, item.E)
+			break
+		}
+		msg := item.V.(StreamMessage)
+		ids = append(ids, msg.ID)
+		assert.NoError(t, msg.Ack(context.Background()))
+	}
+	assert.Equal(t, []string{"1-0", "2-0", "3-0", "4-0", "5-0"}, ids)
+	assert.Equal(t, ids, client.acked)
+}
+
+func Test_FromRedisStream_NoRecovery(t *testing.T) {
+	client := &streamClient{
+		pending: []StreamEntry{{ID: "1-0"}},
+		entries: [][]StreamEntry{{{ID: "2-0"}}},
+	}
+	obs := FromRedisStream(client, StreamConfig{}).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i.(StreamMessage).ID, nil
+	})
+	rxgo.Assert(context.Background(), t, obs, rxgo.HasItems("2-0"), rxgo.HasError(errFoo))
+}
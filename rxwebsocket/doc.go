@@ -0,0 +1,7 @@
+// Package rxwebsocket observes the data messages of a WebSocket connection and writes Observables to one.
+//
+// FromWebSocket can keep the connection alive with pings, a missing pong failing the Observable. ToWebSocket
+// closes the connection with a status reflecting how the Observable terminated: a normal closure once completed,
+// an internal error otherwise. Conn is the subset of the *websocket.Conn of github.com/gorilla/websocket used by
+// the package.
+package rxwebsocket
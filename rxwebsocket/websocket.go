@@ -0,0 +1,161 @@
+package rxwebsocket
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/reactivex/rxgo/v2"
+)
+
+// The message types defined by RFC 6455.
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+	CloseMessage  = 8
+	PingMessage   = 9
+	PongMessage   = 10
+)
+
+// The close codes sent by ToWebSocket, defined by RFC 6455.
+const (
+	CloseNormalClosure = 1000
+	CloseInternalError = 1011
+)
+
+// closeTimeout is the time allowed to send a control message.
+const closeTimeout = time.Second
+
+// Conn is a WebSocket connection.
+type Conn interface {
+	// ReadMessage reads the next data message.
+	ReadMessage() (messageType int, data []byte, err error)
+	// WriteMessage writes a data message.
+	WriteMessage(messageType int, data []byte) error
+	// WriteControl writes a control message (close, ping or pong) with the given deadline.
+	// It can be called concurrently with the other methods.
+	WriteControl(messageType int, data []byte, deadline time.Time) error
+	// SetReadDeadline sets the deadline of the reads, a zero value meaning no deadline.
+	SetReadDeadline(t time.Time) error
+	// SetPongHandler sets the handler called once a pong is received.
+	SetPongHandler(h func(appData string) error)
+}
+
+// Message is a WebSocket data message.
+type Message struct {
+	// Type is either TextMessage or BinaryMessage.
+	Type int
+	Data []byte
+}
+
+// Config configures FromWebSocket.
+type Config struct {
+	// PingInterval is the interval between two pings. The connection is considered lost if no pong is received
+	// within twice this interval. No ping is sent if zero.
+	PingInterval time.Duration
+	// IsNormalClose reports whether a read error is a close message completing the Observable instead of an
+	// error, e.g. using websocket.IsCloseError of gorilla/websocket. If nil, every read error is emitted.
+	IsNormalClose func(error) bool
+}
+
+// FromWebSocket creates an Observable emitting the Messages read from a connection, until a read error or the
+// observer context cancellation. Each observer reads the connection: to share it, see Observable.Publish.
+func FromWebSocket(conn Conn, config Config, opts ...rxgo.Option) rxgo.Observable {
+	return rxgo.Defer([]rxgo.Producer{func(ctx context.Context, next chan<- rxgo.Item) {
+		done := make(chan struct{})
+		defer close(done)
+
+		if config.PingInterval > 0 {
+			timeout := 2 * config.PingInterval
+			_ = conn.SetReadDeadline(time.Now().Add(timeout))
+			conn.SetPongHandler(func(string) error {
+				return conn.SetReadDeadline(time.Now().Add(timeout))
+			})
+			go ping(conn, config.PingInterval, done)
+		}
+		go func() {
+			select {
+			case <-done:
+			case <-ctx.Done():
+				// Unblock the pending read
+				_ = conn.SetReadDeadline(time.Now())
+			}
+		}()
+
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				if ctx.Err() != nil || (config.IsNormalClose != nil && config.IsNormalClose(err)) {
+					return
+				}
+				rxgo.Error(err).SendContext(ctx, next)
+				return
+			}
+			if !rxgo.Of(Message{Type: messageType, Data: data}).SendContext(ctx, next) {
+				return
+			}
+		}
+	}}, opts...)
+}
+
+func ping(conn Conn, interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(PingMessage, nil, time.Now().Add(interval)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// ToWebSocket writes the items of an Observable to a connection. It blocks until the Observable terminates or ctx
+// is cancelled. A Message is written with its type, a []byte as a binary message and a string as a text message.
+// Once the Observable completes, a normal closure message is sent. If it emits an error, an internal error
+// closure message is sent and the error is returned. A write error stops the observation and is returned, as well
+// as the error of ctx once cancelled, no closure message being sent then.
+func ToWebSocket(ctx context.Context, conn Conn, observable rxgo.Observable, opts ...rxgo.Option) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for item := range observable.Observe(append([]rxgo.Option{rxgo.WithContext(ctx)}, opts...)...) {
+		if item.Error() {
+			_ = conn.WriteControl(CloseMessage, closeMessage(CloseInternalError, item.E.Error()), time.Now().Add(closeTimeout))
+			return item.E
+		}
+		var err error
+		switch v := item.V.(type) {
+		case Message:
+			err = conn.WriteMessage(v.Type, v.Data)
+		case []byte:
+			err = conn.WriteMessage(BinaryMessage, v)
+		case string:
+			err = conn.WriteMessage(TextMessage, []byte(v))
+		default:
+			err = fmt.Errorf("rxwebsocket: unsupported item type %T", v)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return conn.WriteControl(CloseMessage, closeMessage(CloseNormalClosure, ""), time.Now().Add(closeTimeout))
+}
+
+// closeMessage formats the payload of a close message.
+func closeMessage(code int, reason string) []byte {
+	// The payload of a control message is limited to 125 bytes
+	if len(reason) > 123 {
+		reason = reason[:123]
+	}
+	b := make([]byte, 2+len(reason))
+	binary.BigEndian.PutUint16(b, uint16(code))
+	copy(b[2:], reason)
+	return b
+}
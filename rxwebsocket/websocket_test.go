@@ -0,0 +1,175 @@
+package rxwebsocket
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errFoo   = errors.New("foo")
+	errClose = errors.New("close 1000")
+)
+
+type written struct {
+	messageType int
+	data        []byte
+}
+
+// fakeConn reads the messages sent to inbound and records the messages written.
+type fakeConn struct {
+	mutex       sync.Mutex
+	inbound     chan Message
+	written     []written
+	deadline    chan struct{}
+	pongHandler func(string) error
+}
+
+func newFakeConn() *fakeConn {
+	return &fakeConn{
+		inbound:  make(chan Message, 10),
+		deadline: make(chan struct{}),
+	}
+}
+
+func (c *fakeConn) ReadMessage() (int, []byte, error) {
+	select {
+	case m, ok := <-c.inbound:
+		if !ok {
+			return 0, nil, errClose
+		}
+		if m.Type == 0 {
+			return 0, nil, errFoo
+		}
+		return m.Type, m.Data, nil
+	case <-c.deadline:
+		return 0, nil, errors.New("i/o timeout")
+	}
+}
+
+func (c *fakeConn) WriteMessage(messageType int, data []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.written = append(c.written, written{messageType: messageType, data: data})
+	return nil
+}
+
+func (c *fakeConn) WriteControl(messageType int, data []byte, _ time.Time) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.written = append(c.written, written{messageType: messageType, data: data})
+	if messageType == PingMessage && c.pongHandler != nil {
+		go c.pongHandler("")
+	}
+	return nil
+}
+
+func (c *fakeConn) SetReadDeadline(t time.Time) error {
+	if !t.IsZero() && !t.After(time.Now()) {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		select {
+		case <-c.deadline:
+		default:
+			close(c.deadline)
+		}
+	}
+	return nil
+}
+
+func (c *fakeConn) SetPongHandler(h func(string) error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.pongHandler = h
+}
+
+func (c *fakeConn) writtenMessages() []written {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]written(nil), c.written...)
+}
+
+func isNormalClose(err error) bool {
+	return err == errClose
+}
+
+func Test_FromWebSocket(t *testing.T) {
+	conn := newFakeConn()
+	conn.inbound <- Message{Type: TextMessage, Data: []byte("foo")}
+	conn.inbound <- Message{Type: BinaryMessage, Data: []byte{1}}
+	close(conn.inbound)
+
+	obs := FromWebSocket(conn, Config{IsNormalClose: isNormalClose})
+	rxgo.Assert(context.Background(), t, obs, rxgo.HasItems(
+		Message{Type: TextMessage, Data: []byte("foo")},
+		Message{Type: BinaryMessage, Data: []byte{1}},
+	), rxgo.HasNoError())
+}
+
+func Test_FromWebSocket_Error(t *testing.T) {
+	conn := newFakeConn()
+	conn.inbound <- Message{Type: TextMessage, Data: []byte("foo")}
+	conn.inbound <- Message{}
+
+	obs := FromWebSocket(conn, Config{IsNormalClose: isNormalClose})
+	rxgo.Assert(context.Background(), t, obs, rxgo.HasItems(Message{Type: TextMessage, Data: []byte("foo")}), rxgo.HasError(errFoo))
+}
+
+func Test_FromWebSocket_ContextCanceled(t *testing.T) {
+	conn := newFakeConn()
+	ctx, cancel := context.WithCancel(context.Background())
+	observe := FromWebSocket(conn, Config{}).Observe(rxgo.WithContext(ctx))
+	cancel()
+	for item := range observe {
+		assert.Fail(t, "unexpected item", item)
+	}
+}
+
+func Test_FromWebSocket_Ping(t *testing.T) {
+	conn := newFakeConn()
+	ctx, cancel := context.WithCancel(context.Background())
+	observe := FromWebSocket(conn, Config{PingInterval: time.Millisecond}).Observe(rxgo.WithContext(ctx))
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	for range observe {
+	}
+	messages := conn.writtenMessages()
+	assert.NotEmpty(t, messages)
+	for _, m := range messages {
+		assert.Equal(t, PingMessage, m.messageType)
+	}
+}
+
+func Test_ToWebSocket(t *testing.T) {
+	conn := newFakeConn()
+	err := ToWebSocket(context.Background(), conn, rxgo.FromSlice([]interface{}{"foo", []byte{1}, Message{Type: TextMessage, Data: []byte("bar")}}))
+	assert.NoError(t, err)
+	assert.Equal(t, []written{
+		{messageType: TextMessage, data: []byte("foo")},
+		{messageType: BinaryMessage, data: []byte{1}},
+		{messageType: TextMessage, data: []byte("bar")},
+		{messageType: CloseMessage, data: []byte{0x03, 0xe8}},
+	}, conn.writtenMessages())
+}
+
+func Test_ToWebSocket_Error(t *testing.T) {
+	conn := newFakeConn()
+	err := ToWebSocket(context.Background(), conn, rxgo.Just("foo", errFoo, "bar")())
+	assert.Equal(t, errFoo, err)
+	assert.Equal(t, []written{
+		{messageType: TextMessage, data: []byte("foo")},
+		{messageType: CloseMessage, data: []byte{0x03, 0xf3, 'f', 'o', 'o'}},
+	}, conn.writtenMessages())
+}
+
+func Test_ToWebSocket_UnsupportedItem(t *testing.T) {
+	conn := newFakeConn()
+	err := ToWebSocket(context.Background(), conn, rxgo.Just(1)())
+	assert.EqualError(t, err, "rxwebsocket: unsupported item type int")
+	assert.Empty(t, conn.writtenMessages())
+}
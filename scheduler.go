@@ -0,0 +1,444 @@
+package rxgo
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Scheduler runs tasks. It determines on which goroutine the work of the SubscribeOn and ObserveOn operators happens.
+type Scheduler interface {
+	// Schedule submits a task, run eventually by the Scheduler. It returns false if the task is rejected, e.g. once
+	// the Scheduler is stopped: the task is then never run.
+	Schedule(task func()) bool
+}
+
+type immediateScheduler struct{}
+
+// NewImmediateScheduler creates a Scheduler running each task synchronously, on the goroutine scheduling it.
+func NewImmediateScheduler() Scheduler {
+	return immediateScheduler{}
+}
+
+func (immediateScheduler) Schedule(task func()) bool {
+	task()
+	return true
+}
+
+type goroutineScheduler struct{}
+
+// NewGoroutineScheduler creates a Scheduler running each task on a new goroutine.
+func NewGoroutineScheduler() Scheduler {
+	return goroutineScheduler{}
+}
+
+func (goroutineScheduler) Schedule(task func()) bool {
+	go task()
+	return true
+}
+
+// TrampolineScheduler is a Scheduler running the tasks on the goroutine scheduling them. A task scheduled while
+// another one is running, e.g. recursively from it, is queued and run once the running task returns, so that a
+// recursion does not grow the stack nor spawn goroutines. The tasks scheduled from another goroutine while the
+// queue is drained are run by the draining goroutine.
+type TrampolineScheduler struct {
+	mutex   sync.Mutex
+	tasks   []func()
+	running bool
+}
+
+// NewTrampolineScheduler creates a TrampolineScheduler.
+func NewTrampolineScheduler() *TrampolineScheduler {
+	return &TrampolineScheduler{}
+}
+
+// Schedule runs the task and then the tasks queued meanwhile, or queues it if a task is already running.
+func (s *TrampolineScheduler) Schedule(task func()) bool {
+	s.mutex.Lock()
+	if s.running {
+		s.tasks = append(s.tasks, task)
+		s.mutex.Unlock()
+		return true
+	}
+	s.running = true
+	s.mutex.Unlock()
+
+	// The queue is released if a task panics, the remaining tasks being run by the next call
+	defer func() {
+		if r := recover(); r != nil {
+			s.mutex.Lock()
+			s.running = false
+			s.mutex.Unlock()
+			panic(r)
+		}
+	}()
+	for {
+		task()
+		s.mutex.Lock()
+		if len(s.tasks) == 0 {
+			s.running = false
+			s.mutex.Unlock()
+			return true
+		}
+		task = s.tasks[0]
+		s.tasks[0] = nil
+		s.tasks = s.tasks[1:]
+		s.mutex.Unlock()
+	}
+}
+
+// taskQueue is an unbounded FIFO queue of tasks, so that a task can schedule another one without blocking.
+type taskQueue struct {
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	tasks   []func()
+	stopped bool
+}
+
+func newTaskQueue() *taskQueue {
+	q := &taskQueue{}
+	q.cond = sync.NewCond(&q.mutex)
+	return q
+}
+
+// push queues a task. It returns false once the queue is stopped, the task being rejected.
+func (q *taskQueue) push(task func()) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.stopped {
+		return false
+	}
+	q.tasks = append(q.tasks, task)
+	q.cond.Signal()
+	return true
+}
+
+// pop waits for a task. It returns false once the queue is stopped and empty.
+func (q *taskQueue) pop() (func(), bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	for len(q.tasks) == 0 && !q.stopped {
+		q.cond.Wait()
+	}
+	if len(q.tasks) == 0 {
+		return nil, false
+	}
+	task := q.tasks[0]
+	q.tasks[0] = nil
+	q.tasks = q.tasks[1:]
+	return task, true
+}
+
+func (q *taskQueue) stop() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.stopped = true
+	q.cond.Broadcast()
+}
+
+// run runs the tasks until the queue is stopped and empty.
+func (q *taskQueue) run() {
+	for {
+		task, ok := q.pop()
+		if !ok {
+			return
+		}
+		task()
+	}
+}
+
+// PoolScheduler is a Scheduler running the tasks on a pool of goroutines, growing from a minimum to a maximum number
+// of workers with the pending tasks. The workers above the minimum exit once idle for the idle timeout.
+// The tasks are started in the order they were scheduled, the queue of pending tasks being unbounded, so that a task
+// can schedule another one without blocking.
+type PoolScheduler struct {
+	mutex         sync.Mutex
+	tasks         []func()
+	min           int
+	max           int
+	idleTimeout   time.Duration
+	workers       int
+	idle          int
+	maxQueueDepth int
+	stopped       bool
+	wake          chan struct{}
+	done          chan struct{}
+	stopOnce      sync.Once
+	wg            sync.WaitGroup
+}
+
+// PoolStats is a snapshot of the activity of a PoolScheduler.
+type PoolStats struct {
+	// Workers is the number of running workers, idle or not.
+	Workers int
+	// IdleWorkers is the number of workers waiting for a task.
+	IdleWorkers int
+	// QueueDepth is the number of tasks waiting for a worker.
+	QueueDepth int
+	// MaxQueueDepth is the highest queue depth since the creation.
+	MaxQueueDepth int
+}
+
+// NewPoolScheduler creates a PoolScheduler running the tasks on a fixed number of workers.
+func NewPoolScheduler(workers int) *PoolScheduler {
+	if workers <= 0 {
+		workers = 1
+	}
+	return NewWorkerPoolScheduler(workers, workers, 0)
+}
+
+// NewWorkerPoolScheduler creates a PoolScheduler running at least min and at most max workers. With a zero idle
+// timeout, the workers above min exit as soon as there is no pending task.
+func NewWorkerPoolScheduler(min, max int, idleTimeout time.Duration) *PoolScheduler {
+	if min < 0 {
+		min = 0
+	}
+	if max < min {
+		max = min
+	}
+	if max < 1 {
+		max = 1
+	}
+	s := &PoolScheduler{
+		min:         min,
+		max:         max,
+		idleTimeout: idleTimeout,
+		wake:        make(chan struct{}, max),
+		done:        make(chan struct{}),
+	}
+	s.mutex.Lock()
+	for i := 0; i < min; i++ {
+		s.startWorker()
+	}
+	s.mutex.Unlock()
+	return s
+}
+
+// Schedule queues a task, starting a new worker if none is idle and the maximum is not reached. It is rejected once
+// the PoolScheduler is stopped.
+func (s *PoolScheduler) Schedule(task func()) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.stopped {
+		return false
+	}
+	s.tasks = append(s.tasks, task)
+	if len(s.tasks) > s.maxQueueDepth {
+		s.maxQueueDepth = len(s.tasks)
+	}
+	if s.idle > 0 {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+	if len(s.tasks) > s.idle && s.workers < s.max {
+		s.startWorker()
+	}
+	return true
+}
+
+// Stats returns a snapshot of the workers and of the queue.
+func (s *PoolScheduler) Stats() PoolStats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return PoolStats{
+		Workers:       s.workers,
+		IdleWorkers:   s.idle,
+		QueueDepth:    len(s.tasks),
+		MaxQueueDepth: s.maxQueueDepth,
+	}
+}
+
+// Stop stops the PoolScheduler once the queued tasks are run, and waits for its workers to exit.
+func (s *PoolScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		s.mutex.Lock()
+		s.stopped = true
+		s.mutex.Unlock()
+		close(s.done)
+	})
+	s.wg.Wait()
+}
+
+// startWorker starts a worker, the mutex being locked.
+func (s *PoolScheduler) startWorker() {
+	s.workers++
+	s.wg.Add(1)
+	go s.work()
+}
+
+func (s *PoolScheduler) work() {
+	defer s.wg.Done()
+	for {
+		s.mutex.Lock()
+		if len(s.tasks) > 0 {
+			task := s.tasks[0]
+			s.tasks[0] = nil
+			s.tasks = s.tasks[1:]
+			s.mutex.Unlock()
+			task()
+			continue
+		}
+		if s.stopped || (s.workers > s.min && s.idleTimeout <= 0) {
+			s.workers--
+			s.mutex.Unlock()
+			return
+		}
+		s.idle++
+		s.mutex.Unlock()
+
+		if !s.wait() {
+			return
+		}
+	}
+}
+
+// wait waits for a task while idle. It returns false if the worker has exited, being above the minimum.
+func (s *PoolScheduler) wait() bool {
+	var timeout <-chan time.Time
+	if s.idleTimeout > 0 {
+		timer := time.NewTimer(s.idleTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	for {
+		select {
+		case <-s.wake:
+		case <-s.done:
+		case <-timeout:
+			s.mutex.Lock()
+			if len(s.tasks) == 0 {
+				if s.workers > s.min {
+					s.idle--
+					s.workers--
+					s.mutex.Unlock()
+					return false
+				}
+				// The worker is part of the minimum, it keeps waiting
+				s.mutex.Unlock()
+				timeout = nil
+				continue
+			}
+			s.mutex.Unlock()
+		}
+		s.mutex.Lock()
+		s.idle--
+		s.mutex.Unlock()
+		return true
+	}
+}
+
+// EventLoopScheduler is a Scheduler running all the tasks sequentially, in the order they were scheduled, on a
+// single goroutine locked to its OS thread. It suits the resources bound to a thread, e.g. a CGo handle or a UI
+// loop. The tasks are queued until the loop is started with Start or Run.
+type EventLoopScheduler struct {
+	queue   *taskQueue
+	mutex   sync.Mutex
+	started bool
+	done    chan struct{}
+}
+
+// NewEventLoopScheduler creates an EventLoopScheduler, not started yet.
+func NewEventLoopScheduler() *EventLoopScheduler {
+	return &EventLoopScheduler{
+		queue: newTaskQueue(),
+		done:  make(chan struct{}),
+	}
+}
+
+// NewSingleScheduler creates an EventLoopScheduler already started, running all the tasks sequentially on its own
+// goroutine.
+func NewSingleScheduler() *EventLoopScheduler {
+	s := NewEventLoopScheduler()
+	s.Start()
+	return s
+}
+
+// Start runs the loop on a new goroutine.
+func (s *EventLoopScheduler) Start() {
+	if s.start() {
+		go s.loop()
+	}
+}
+
+// Run runs the loop on the calling goroutine, e.g. the main one, until the EventLoopScheduler is stopped.
+// It returns immediately if the loop is already running.
+func (s *EventLoopScheduler) Run() {
+	if s.start() {
+		s.loop()
+	}
+}
+
+func (s *EventLoopScheduler) start() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.started {
+		return false
+	}
+	s.started = true
+	return true
+}
+
+func (s *EventLoopScheduler) loop() {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	defer close(s.done)
+	s.queue.run()
+}
+
+// Schedule queues a task. It is rejected once the EventLoopScheduler is stopped.
+func (s *EventLoopScheduler) Schedule(task func()) bool {
+	return s.queue.push(task)
+}
+
+// Stop stops the EventLoopScheduler once the queued tasks are run and, if the loop is running, waits for it to
+// return. It must not be called from a task.
+func (s *EventLoopScheduler) Stop() {
+	s.queue.stop()
+	s.mutex.Lock()
+	started := s.started
+	s.mutex.Unlock()
+	if started {
+		<-s.done
+	}
+}
+
+// scheduledObserver calls the methods of an Observer from tasks run by a Scheduler, see WithObserverScheduler.
+// Each call is awaited, so that the Observer is called sequentially and a panic is propagated to the caller.
+type scheduledObserver struct {
+	observer  Observer
+	scheduler Scheduler
+}
+
+// call runs f from a task and waits for it. It returns false if the task is rejected.
+func (o scheduledObserver) call(f func()) bool {
+	done := make(chan interface{}, 1)
+	if !o.scheduler.Schedule(func() {
+		defer func() {
+			done <- recover()
+		}()
+		f()
+	}) {
+		return false
+	}
+	if r := <-done; r != nil {
+		panic(r)
+	}
+	return true
+}
+
+// OnNext panics with a RejectedTaskError if the call is rejected, failing the subscription.
+func (o scheduledObserver) OnNext(i interface{}) {
+	if !o.call(func() { o.observer.OnNext(i) }) {
+		panic(errRejectedTask)
+	}
+}
+
+func (o scheduledObserver) OnError(err error) {
+	o.call(func() { o.observer.OnError(err) })
+}
+
+func (o scheduledObserver) OnCompleted() {
+	o.call(o.observer.OnCompleted)
+}
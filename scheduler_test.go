@@ -0,0 +1,350 @@
+package rxgo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ImmediateScheduler(t *testing.T) {
+	called := false
+	NewImmediateScheduler().Schedule(func() {
+		called = true
+	})
+	assert.True(t, called)
+}
+
+func Test_GoroutineScheduler(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	NewGoroutineScheduler().Schedule(wg.Done)
+	wg.Wait()
+}
+
+func Test_TrampolineScheduler(t *testing.T) {
+	s := NewTrampolineScheduler()
+	id := goroutineID()
+	got := make([]int, 0)
+	depth, maxDepth := 0, 0
+	var schedule func(i int)
+	schedule = func(i int) {
+		s.Schedule(func() {
+			assert.Equal(t, id, goroutineID())
+			depth++
+			if depth > maxDepth {
+				maxDepth = depth
+			}
+			got = append(got, i)
+			if i < 100000 {
+				schedule(i + 1)
+			}
+			depth--
+		})
+	}
+	schedule(1)
+	assert.Len(t, got, 100000)
+	assert.Equal(t, 100000, got[len(got)-1])
+	// The recursive tasks are queued rather than nested
+	assert.Equal(t, 1, maxDepth)
+}
+
+func Test_TrampolineScheduler_Order(t *testing.T) {
+	s := NewTrampolineScheduler()
+	got := make([]string, 0)
+	s.Schedule(func() {
+		s.Schedule(func() {
+			got = append(got, "b")
+			s.Schedule(func() {
+				got = append(got, "d")
+			})
+		})
+		s.Schedule(func() {
+			got = append(got, "c")
+		})
+		got = append(got, "a")
+	})
+	assert.Equal(t, []string{"a", "b", "c", "d"}, got)
+}
+
+func Test_TrampolineScheduler_Panic(t *testing.T) {
+	s := NewTrampolineScheduler()
+	called := false
+	assert.Panics(t, func() {
+		s.Schedule(func() {
+			s.Schedule(func() {
+				called = true
+			})
+			panic("foo")
+		})
+	})
+	assert.False(t, called)
+	// The queued task is run by the next call
+	s.Schedule(func() {})
+	assert.True(t, called)
+}
+
+func Test_SingleScheduler_Order(t *testing.T) {
+	s := NewSingleScheduler()
+	got := make([]int, 0)
+	for i := 0; i < 100; i++ {
+		i := i
+		s.Schedule(func() {
+			got = append(got, i)
+		})
+	}
+	s.Stop()
+	assert.Len(t, got, 100)
+	for i, v := range got {
+		assert.Equal(t, i, v)
+	}
+}
+
+func Test_PoolScheduler_NestedSchedule(t *testing.T) {
+	s := NewPoolScheduler(2)
+	var count int32
+	var wg sync.WaitGroup
+	wg.Add(10)
+	for i := 0; i < 10; i++ {
+		s.Schedule(func() {
+			atomic.AddInt32(&count, 1)
+			s.Schedule(func() {
+				atomic.AddInt32(&count, 1)
+				wg.Done()
+			})
+		})
+	}
+	wg.Wait()
+	s.Stop()
+	assert.Equal(t, int32(20), atomic.LoadInt32(&count))
+}
+
+func Test_PoolScheduler_Stopped(t *testing.T) {
+	s := NewPoolScheduler(2)
+	s.Stop()
+	called := false
+	assert.False(t, s.Schedule(func() {
+		called = true
+	}))
+	assert.False(t, called)
+}
+
+// goroutineID returns the ID of the calling goroutine, read from its stack trace.
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	id, _ := strconv.ParseUint(string(buf[:bytes.IndexByte(buf, ' ')]), 10, 64)
+	return id
+}
+
+func Test_EventLoopScheduler(t *testing.T) {
+	s := NewEventLoopScheduler()
+	got := make([]int, 0)
+	goroutines := make(map[uint64]bool)
+	for i := 0; i < 100; i++ {
+		i := i
+		// The tasks are queued until the loop is started
+		s.Schedule(func() {
+			got = append(got, i)
+			goroutines[goroutineID()] = true
+		})
+	}
+	s.Start()
+	s.Stop()
+	assert.Len(t, got, 100)
+	for i, v := range got {
+		assert.Equal(t, i, v)
+	}
+	assert.Len(t, goroutines, 1)
+}
+
+func Test_EventLoopScheduler_Run(t *testing.T) {
+	s := NewEventLoopScheduler()
+	caller := goroutineID()
+	var loop uint64
+	s.Schedule(func() {
+		loop = goroutineID()
+		go s.Stop()
+	})
+	// Run returns once stopped
+	s.Run()
+	assert.Equal(t, caller, loop)
+}
+
+func Test_EventLoopScheduler_NotStarted(t *testing.T) {
+	s := NewEventLoopScheduler()
+	s.Stop()
+	called := false
+	assert.False(t, s.Schedule(func() {
+		called = true
+	}))
+	assert.False(t, called)
+}
+
+func Test_WithObserverScheduler(t *testing.T) {
+	loop := NewEventLoopScheduler()
+	loop.Start()
+	defer loop.Stop()
+	var loopID uint64
+	loop.Schedule(func() {
+		loopID = goroutineID()
+	})
+
+	// The callbacks of both subscriptions run on the loop, hence sequentially
+	var goroutines sync.Map
+	var sum int
+	observer := funcObserver{
+		next: func(i interface{}) {
+			goroutines.Store(goroutineID(), true)
+			sum += i.(int)
+		},
+		completed: func() {},
+	}
+	first := Range(1, 99).SubscribeAwait(observer, WithObserverScheduler(loop))
+	second := Range(1, 99).SubscribeAwait(observer, WithObserverScheduler(loop))
+	assert.NoError(t, first.Await(context.Background()))
+	assert.NoError(t, second.Await(context.Background()))
+	assert.Equal(t, 2*5050, sum)
+	goroutines.Range(func(id, _ interface{}) bool {
+		assert.Equal(t, loopID, id)
+		return true
+	})
+}
+
+func Test_WithObserverScheduler_Panic(t *testing.T) {
+	loop := NewEventLoopScheduler()
+	loop.Start()
+	defer loop.Stop()
+	sub := testObservable(1, 2).SubscribeAwait(&panickingObserver{}, WithObserverScheduler(loop))
+	// The panic is recovered on the subscription goroutine, the loop keeps running
+	err := sub.Await(context.Background())
+	assert.Equal(t, "foo", err.(PanicError).Value)
+}
+
+func Test_WithObserverScheduler_Rejected(t *testing.T) {
+	loop := NewEventLoopScheduler()
+	loop.Stop()
+	sub := testObservable(1, 2).SubscribeAwait(&recordingObserver{}, WithObserverScheduler(loop))
+	err := sub.Await(context.Background())
+	assert.True(t, errors.As(err, &RejectedTaskError{}))
+}
+
+func Test_PoolScheduler_Grow(t *testing.T) {
+	s := NewWorkerPoolScheduler(1, 4, time.Hour)
+	defer s.Stop()
+	assert.Equal(t, 1, s.Stats().Workers)
+
+	release := make(chan struct{})
+	var started sync.WaitGroup
+	started.Add(4)
+	for i := 0; i < 6; i++ {
+		s.Schedule(func() {
+			started.Done()
+			<-release
+		})
+	}
+	// The pool grows up to the maximum, the other tasks being queued
+	started.Wait()
+	stats := s.Stats()
+	assert.Equal(t, 4, stats.Workers)
+	assert.Equal(t, 2, stats.QueueDepth)
+	assert.GreaterOrEqual(t, stats.MaxQueueDepth, 2)
+	started.Add(2)
+	close(release)
+	started.Wait()
+}
+
+func Test_PoolScheduler_IdleTimeout(t *testing.T) {
+	s := NewWorkerPoolScheduler(1, 4, time.Millisecond)
+	defer s.Stop()
+	var wg sync.WaitGroup
+	wg.Add(4)
+	release := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		s.Schedule(func() {
+			wg.Done()
+			<-release
+		})
+	}
+	wg.Wait()
+	close(release)
+	// The workers above the minimum exit once idle
+	assert.Eventually(t, func() bool {
+		return s.Stats().Workers == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 1, s.Stats().IdleWorkers)
+
+	wg.Add(1)
+	s.Schedule(wg.Done)
+	wg.Wait()
+}
+
+func Test_PoolScheduler_Stop(t *testing.T) {
+	s := NewWorkerPoolScheduler(0, 2, 0)
+	var count int32
+	for i := 0; i < 10; i++ {
+		s.Schedule(func() {
+			atomic.AddInt32(&count, 1)
+		})
+	}
+	// The queued tasks are run before stopping
+	s.Stop()
+	assert.Equal(t, int32(10), atomic.LoadInt32(&count))
+	assert.Equal(t, 0, s.Stats().Workers)
+	assert.False(t, s.Schedule(func() {
+		atomic.AddInt32(&count, 1)
+	}))
+	assert.Equal(t, int32(10), atomic.LoadInt32(&count))
+}
+
+func Test_WithScheduler(t *testing.T) {
+	s := NewWorkerPoolScheduler(0, 8, time.Second)
+	defer s.Stop()
+	var running, maxRunning int32
+	obs := Range(1, 99).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return i.(int) * 10, nil
+	}, WithPool(4), WithScheduler(s))
+	items, err := obs.ToSlice(0)
+	assert.NoError(t, err)
+	assert.Len(t, items, 100)
+	// The pool bounds the items processed concurrently, whatever the scheduler
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(4))
+}
+
+func Test_WithScheduler_Gathered(t *testing.T) {
+	s := NewWorkerPoolScheduler(0, 2, time.Second)
+	defer s.Stop()
+	obs := Range(1, 99).Reduce(func(_ context.Context, acc, i interface{}) (interface{}, error) {
+		if acc == nil {
+			return i, nil
+		}
+		return acc.(int) + i.(int), nil
+	}, WithPool(4), WithScheduler(s))
+	Assert(context.Background(), t, obs, HasItem(5050), HasNoError())
+}
+
+func Test_WithScheduler_Error(t *testing.T) {
+	s := NewWorkerPoolScheduler(0, 2, time.Second)
+	defer s.Stop()
+	obs := testObservable(1, 2, errFoo, 4).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	}, WithPool(2), WithScheduler(s))
+	Assert(context.Background(), t, obs, HasError(errFoo))
+}
@@ -0,0 +1,73 @@
+//go:build go1.23
+// +build go1.23
+
+package rxgo
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FromSeq(t *testing.T) {
+	obs := FromSeq(slices.Values([]interface{}{1, 2, 3}))
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
+	// The sequence is iterated for each observer
+	Assert(context.Background(), t, obs, HasItems(1, 2, 3), HasNoError())
+}
+
+func Test_FromSeq_Error(t *testing.T) {
+	obs := FromSeq(slices.Values([]interface{}{1, errFoo, 3}))
+	Assert(context.Background(), t, obs, HasItems(1, 3), HasError(errFoo))
+}
+
+func Test_FromSeq_ObserverContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	var seq iter.Seq[any] = func(yield func(any) bool) {
+		defer close(stopped)
+		for i := 0; yield(i); i++ {
+		}
+	}
+	observe := FromSeq(seq).Observe(WithContext(ctx))
+	assert.Equal(t, 0, (<-observe).V)
+	cancel()
+	<-stopped
+}
+
+func Test_Observable_ToSeq(t *testing.T) {
+	var seq iter.Seq[any] = testObservable(1, 2, 3).ToSeq(context.Background())
+	assert.Equal(t, []interface{}{1, 2, 3}, slices.Collect(seq))
+}
+
+func Test_Observable_ToSeq_Break(t *testing.T) {
+	obs := Just(0, 1, 2, 3, 4)()
+	var got []interface{}
+	for v := range obs.ToSeq(context.Background()) {
+		if v == 3 {
+			break
+		}
+		got = append(got, v)
+	}
+	assert.Equal(t, []interface{}{0, 1, 2}, got)
+	// The Observable is observed again on each iteration
+	assert.Equal(t, 5, len(slices.Collect(iter.Seq[any](obs.ToSeq(context.Background())))))
+}
+
+func Test_Observable_ToSeq_Error(t *testing.T) {
+	obs := Just(1, errFoo, 3)()
+	assert.Equal(t, []interface{}{1}, slices.Collect(iter.Seq[any](obs.ToSeq(context.Background()))))
+	assert.Equal(t, []interface{}{1, errFoo},
+		slices.Collect(iter.Seq[any](obs.ToSeq(context.Background(), WithErrorValues()))))
+	assert.Equal(t, []interface{}{1, errFoo, 3},
+		slices.Collect(iter.Seq[any](obs.ToSeq(context.Background(), WithErrorValues(), WithErrorStrategy(ContinueOnError)))))
+}
+
+func Test_Observable_ToSeq_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Empty(t, slices.Collect(iter.Seq[any](Never().ToSeq(ctx))))
+}
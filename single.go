@@ -9,6 +9,7 @@ type Single interface {
 	Get(opts ...Option) (Item, error)
 	Map(apply Func, opts ...Option) Single
 	Run(opts ...Option) Disposed
+	ToObservable() Observable
 }
 
 // SingleImpl implements Single.
@@ -124,3 +125,8 @@ func (s *SingleImpl) Run(opts ...Option) Disposed {
 
 	return dispose
 }
+
+// ToObservable converts a Single into an Observable emitting its item.
+func (s *SingleImpl) ToObservable() Observable {
+	return &ObservableImpl{iterable: s.iterable}
+}
@@ -51,3 +51,10 @@ func Test_Single_Map(t *testing.T) {
 	})
 	Assert(context.Background(), t, single, HasItem(2), HasNoError())
 }
+
+func Test_Single_ToObservable(t *testing.T) {
+	obs := JustItem(1).ToObservable().Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i.(int) + 1, nil
+	})
+	Assert(context.Background(), t, obs, HasItems(2), HasNoError())
+}
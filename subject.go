@@ -0,0 +1,349 @@
+package rxgo
+
+import (
+	"sync"
+	"time"
+)
+
+// Subject is both an Observable and an Observer.
+// Each item received as an Observer is multicasted to all the current observers of the Observable.
+type Subject interface {
+	Observable
+	Observer
+}
+
+// subjectRecorder decides which items are replayed to a new observer.
+type subjectRecorder interface {
+	record(item Item)
+	replay(terminated bool) []Item
+}
+
+type subjectObserver struct {
+	buffer *observerBuffer
+}
+
+type subject struct {
+	Observable
+	// emitting serializes the emissions, sent to the observers without holding mutex so that a slow observer
+	// does not block the registration of the others. It is acquired before mutex.
+	emitting sync.Mutex
+	// mutex guards the fields below
+	mutex    sync.Mutex
+	opts     []Option
+	metrics  Metrics
+	recorder subjectRecorder
+	// lastOnCompletion defers the emission of the recorded items to the completion
+	lastOnCompletion bool
+	// unicast allows a single observer
+	unicast    bool
+	observed   bool
+	observers  []*subjectObserver
+	terminated bool
+	err        error
+	done       chan struct{}
+}
+
+func newSubject(recorder subjectRecorder, opts ...Option) *subject {
+	option := parseOptions(opts...)
+	s := &subject{
+		opts:      opts,
+		metrics:   option.getMetrics(),
+		recorder:  recorder,
+		observers: make([]*subjectObserver, 0),
+		done:      make(chan struct{}),
+	}
+	s.Observable = &ObservableImpl{iterable: s}
+	return s
+}
+
+// Observe registers a new observer. It is unregistered once its context is cancelled.
+func (s *subject) Observe(opts ...Option) <-chan Item {
+	option := parseOptions(mergeOptions(s.opts, opts)...)
+	ctx := option.buildContext()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.unicast && s.observed {
+		ch := observerChannel(option, 1)
+		ch <- Error(IllegalInputError{error: "UnicastSubject allows a single observer"})
+		close(ch)
+		return ch
+	}
+	s.observed = true
+
+	var replay []Item
+	if s.recorder != nil {
+		replay = s.recorder.replay(s.terminated)
+	}
+	buffer := newObserverBuffer(ctx, option, len(replay)+1, s.metrics)
+	buffer.fill(replay...)
+	if s.terminated {
+		if s.err != nil {
+			buffer.fill(Error(s.err))
+		}
+		buffer.close()
+		return buffer.ch
+	}
+
+	observer := &subjectObserver{buffer: buffer}
+	s.observers = append(s.observers, observer)
+	if ctx.Done() != nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+				s.unsubscribe(observer)
+			case <-s.done:
+			}
+		}()
+	}
+	return buffer.ch
+}
+
+func (s *subject) unsubscribe(observer *subjectObserver) {
+	s.emitting.Lock()
+	defer s.emitting.Unlock()
+	if s.remove(observer) {
+		observer.buffer.close()
+	}
+}
+
+// remove unregisters an observer. It returns false if it was not registered anymore.
+func (s *subject) remove(observer *subjectObserver) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for i, o := range s.observers {
+		if o == observer {
+			s.observers = append(s.observers[:i], s.observers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// snapshot returns a copy of the current observers, the mutex being locked.
+func (s *subject) snapshot() []*subjectObserver {
+	return append([]*subjectObserver(nil), s.observers...)
+}
+
+// send sends the items to the observers, emitting being locked, and unregisters the observers which have to be
+// terminated.
+func (s *subject) send(observers []*subjectObserver, items ...Item) {
+	for _, observer := range observers {
+		for _, item := range items {
+			if !observer.buffer.send(item) {
+				if s.remove(observer) {
+					observer.buffer.close()
+				}
+				break
+			}
+		}
+	}
+}
+
+// OnNext emits an item to all the current observers.
+func (s *subject) OnNext(i interface{}) {
+	s.emitting.Lock()
+	defer s.emitting.Unlock()
+	s.mutex.Lock()
+	if s.terminated {
+		s.mutex.Unlock()
+		return
+	}
+	item := Of(i)
+	if s.recorder != nil {
+		s.recorder.record(item)
+	}
+	if s.lastOnCompletion {
+		s.mutex.Unlock()
+		return
+	}
+	observers := s.snapshot()
+	s.mutex.Unlock()
+	s.send(observers, item)
+}
+
+// OnError emits an error to all the current observers and terminates the Subject.
+func (s *subject) OnError(err error) {
+	s.emitting.Lock()
+	defer s.emitting.Unlock()
+	s.mutex.Lock()
+	if s.terminated {
+		s.mutex.Unlock()
+		return
+	}
+	s.err = err
+	if s.lastOnCompletion {
+		// The recorded items are never emitted after an error
+		s.recorder = nil
+	}
+	observers := s.terminate()
+	s.mutex.Unlock()
+	s.send(observers, Error(err))
+	closeObservers(observers)
+}
+
+// OnCompleted terminates the Subject.
+func (s *subject) OnCompleted() {
+	s.emitting.Lock()
+	defer s.emitting.Unlock()
+	s.mutex.Lock()
+	if s.terminated {
+		s.mutex.Unlock()
+		return
+	}
+	var items []Item
+	if s.lastOnCompletion {
+		items = s.recorder.replay(true)
+	}
+	observers := s.terminate()
+	s.mutex.Unlock()
+	s.send(observers, items...)
+	closeObservers(observers)
+}
+
+func (s *subject) isTerminated() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.terminated
+}
+
+// terminate marks the Subject as terminated, the mutex being locked, and returns the observers to close once the
+// last items are sent.
+func (s *subject) terminate() []*subjectObserver {
+	s.terminated = true
+	observers := s.observers
+	s.observers = nil
+	close(s.done)
+	return observers
+}
+
+// closeObservers closes the observers of a terminated Subject, emitting being locked.
+func closeObservers(observers []*subjectObserver) {
+	for _, observer := range observers {
+		observer.buffer.close()
+	}
+}
+
+// PublishSubject creates a Subject that emits to each observer the items received after its registration.
+// Once terminated, a new observer only receives the error, if any.
+// It is safe to call its methods and to register or unregister observers concurrently.
+func PublishSubject(opts ...Option) Subject {
+	return newSubject(nil, opts...)
+}
+
+type behaviorRecorder struct {
+	latest Item
+}
+
+func (r *behaviorRecorder) record(item Item) {
+	r.latest = item
+}
+
+func (r *behaviorRecorder) replay(terminated bool) []Item {
+	if terminated {
+		return nil
+	}
+	return []Item{r.latest}
+}
+
+// BehaviorSubject creates a Subject that emits the most recent item (or the initial value if none has been
+// received yet) to each new observer, then the subsequent items.
+func BehaviorSubject(initialValue interface{}, opts ...Option) Subject {
+	return newSubject(&behaviorRecorder{latest: Of(initialValue)}, opts...)
+}
+
+type replayRecorder struct {
+	bufferSize int
+	window     time.Duration
+	clock      Clock
+	items      []Item
+	timestamps []time.Time
+}
+
+func (r *replayRecorder) record(item Item) {
+	r.items = append(r.items, item)
+	r.timestamps = append(r.timestamps, r.clock.Now())
+	if r.bufferSize > 0 && len(r.items) > r.bufferSize {
+		r.items = r.items[1:]
+		r.timestamps = r.timestamps[1:]
+	}
+}
+
+func (r *replayRecorder) replay(_ bool) []Item {
+	if r.window > 0 {
+		limit := r.clock.Now().Add(-r.window)
+		i := 0
+		for i < len(r.timestamps) && !r.timestamps[i].After(limit) {
+			i++
+		}
+		r.items = r.items[i:]
+		r.timestamps = r.timestamps[i:]
+	}
+	replay := make([]Item, len(r.items))
+	copy(replay, r.items)
+	return replay
+}
+
+// ReplaySubject creates a Subject that replays the recorded items to each new observer, even once
+// terminated, then the subsequent items.
+// At most bufferSize items are replayed (0 means no limit) and, if window is not 0, only the items
+// received within this time window.
+func ReplaySubject(bufferSize int, window time.Duration, opts ...Option) Subject {
+	return newSubject(&replayRecorder{
+		bufferSize: bufferSize,
+		window:     window,
+		clock:      parseOptions(opts...).getClock(),
+	}, opts...)
+}
+
+type asyncRecorder struct {
+	latest *Item
+}
+
+func (r *asyncRecorder) record(item Item) {
+	r.latest = &item
+}
+
+func (r *asyncRecorder) replay(terminated bool) []Item {
+	if !terminated || r.latest == nil {
+		return nil
+	}
+	return []Item{*r.latest}
+}
+
+// AsyncSubject creates a Subject that only emits the last item received (if any), once completed, to
+// each observer, including the ones registered after the completion.
+// If the Subject terminates with an error, only the error is emitted.
+func AsyncSubject(opts ...Option) Subject {
+	s := newSubject(&asyncRecorder{}, opts...)
+	s.lastOnCompletion = true
+	return s
+}
+
+type unicastRecorder struct {
+	items    []Item
+	observed bool
+}
+
+func (r *unicastRecorder) record(item Item) {
+	if !r.observed {
+		r.items = append(r.items, item)
+	}
+}
+
+func (r *unicastRecorder) replay(_ bool) []Item {
+	r.observed = true
+	replay := r.items
+	r.items = nil
+	return replay
+}
+
+// UnicastSubject creates a Subject allowing a single observer. The items received before its registration
+// are buffered and emitted to it first, followed by the terminal notification if the Subject has already
+// terminated. Any other observer receives an IllegalInputError.
+func UnicastSubject(opts ...Option) Subject {
+	s := newSubject(&unicastRecorder{}, opts...)
+	s.unicast = true
+	return s
+}
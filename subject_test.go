@@ -0,0 +1,261 @@
+package rxgo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PublishSubject(t *testing.T) {
+	s := PublishSubject(WithBufferedChannel(3))
+	s.OnNext(0)
+	first := s.Observe()
+	s.OnNext(1)
+	second := s.Observe()
+	s.OnNext(2)
+	s.OnCompleted()
+	s.OnNext(3)
+	Assert(context.Background(), t, FromChannel(first), HasItems(1, 2), HasNoError())
+	Assert(context.Background(), t, FromChannel(second), HasItems(2), HasNoError())
+	Assert(context.Background(), t, s, IsEmpty(), HasNoError())
+}
+
+func Test_PublishSubject_Error(t *testing.T) {
+	s := PublishSubject(WithBufferedChannel(1))
+	observe := s.Observe()
+	s.OnNext(1)
+	s.OnError(errFoo)
+	Assert(context.Background(), t, FromChannel(observe), HasItems(1), HasError(errFoo))
+	Assert(context.Background(), t, s, IsEmpty(), HasError(errFoo))
+}
+
+func Test_PublishSubject_Concurrent(t *testing.T) {
+	s := PublishSubject()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		observe := s.Observe(WithContext(ctx))
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer cancel()
+			received := 0
+			for range observe {
+				received++
+				if received == i {
+					// Unsubscribes while items are being emitted
+					return
+				}
+			}
+		}(i)
+	}
+	for i := 0; i < 100; i++ {
+		s.OnNext(i)
+		if i%10 == 0 {
+			// Subscribes while items are being emitted
+			ctx, cancel := context.WithCancel(context.Background())
+			s.Observe(WithContext(ctx))
+			cancel()
+		}
+	}
+	s.OnCompleted()
+	wg.Wait()
+}
+
+func Test_PublishSubject_SlowObserver(t *testing.T) {
+	s := PublishSubject()
+	slow := s.Observe()
+	// Fills the buffer of the slow observer
+	s.OnNext(0)
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		// Blocks until the slow observer reads
+		s.OnNext(1)
+	}()
+	// Lets the emission block on the slow observer
+	time.Sleep(50 * time.Millisecond)
+
+	// Registering another observer is not blocked by the pending emission
+	observed := make(chan (<-chan Item))
+	go func() {
+		observed <- s.Observe(WithBufferedChannel(1))
+	}()
+	var fast <-chan Item
+	select {
+	case fast = <-observed:
+	case <-time.After(time.Second):
+		assert.FailNow(t, "Observe blocked by a slow observer")
+	}
+
+	assert.Equal(t, 0, (<-slow).V)
+	assert.Equal(t, 1, (<-slow).V)
+	<-sent
+	s.OnNext(2)
+	assert.Equal(t, 2, (<-fast).V)
+	s.OnCompleted()
+}
+
+func Test_BehaviorSubject_InitialValue(t *testing.T) {
+	s := BehaviorSubject(0, WithBufferedChannel(2))
+	observe := s.Observe()
+	s.OnNext(1)
+	s.OnNext(2)
+	s.OnCompleted()
+	Assert(context.Background(), t, FromChannel(observe), HasItems(0, 1, 2), HasNoError())
+}
+
+func Test_BehaviorSubject_LateObserver(t *testing.T) {
+	s := BehaviorSubject(0, WithBufferedChannel(3))
+	first := s.Observe()
+	s.OnNext(1)
+	s.OnNext(2)
+	second := s.Observe()
+	s.OnNext(3)
+	s.OnCompleted()
+	Assert(context.Background(), t, FromChannel(first), HasItems(0, 1, 2, 3), HasNoError())
+	Assert(context.Background(), t, FromChannel(second), HasItems(2, 3), HasNoError())
+}
+
+func Test_BehaviorSubject_Error(t *testing.T) {
+	s := BehaviorSubject(0, WithBufferedChannel(1))
+	observe := s.Observe()
+	s.OnNext(1)
+	s.OnError(errFoo)
+	s.OnNext(2)
+	Assert(context.Background(), t, FromChannel(observe), HasItems(0, 1), HasError(errFoo))
+	Assert(context.Background(), t, s, IsEmpty(), HasError(errFoo))
+}
+
+func Test_BehaviorSubject_Completed(t *testing.T) {
+	s := BehaviorSubject(0)
+	s.OnNext(1)
+	s.OnCompleted()
+	Assert(context.Background(), t, s, IsEmpty(), HasNoError())
+}
+
+func Test_BehaviorSubject_Operator(t *testing.T) {
+	s := BehaviorSubject(1, WithBufferedChannel(3))
+	obs := s.Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		return i.(int) * 10, nil
+	})
+	observe := obs.Observe()
+	s.OnNext(2)
+	s.OnNext(3)
+	s.OnCompleted()
+	Assert(context.Background(), t, FromChannel(observe), HasItems(10, 20, 30))
+}
+
+func Test_BehaviorSubject_Unsubscribe(t *testing.T) {
+	s := BehaviorSubject(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	observe := s.Observe(WithContext(ctx))
+	assert.Equal(t, 0, (<-observe).V)
+	cancel()
+	for range observe {
+	}
+	s.OnNext(1)
+	s.OnCompleted()
+}
+
+func Test_BehaviorSubject_Latest(t *testing.T) {
+	s := BehaviorSubject(0, WithBackPressureStrategy(Latest))
+	observe := s.Observe()
+	for i := 1; i <= 5; i++ {
+		s.OnNext(i)
+	}
+	s.OnCompleted()
+	Assert(context.Background(), t, FromChannel(observe), CustomPredicate(func(items []interface{}) error {
+		if len(items) == 0 || len(items) >= 5 || items[len(items)-1] != 5 {
+			return fmt.Errorf("items should end with the latest one: %v", items)
+		}
+		return nil
+	}), HasNoError())
+}
+
+func Test_ReplaySubject_BufferSize(t *testing.T) {
+	s := ReplaySubject(2, 0, WithBufferedChannel(1))
+	s.OnNext(1)
+	s.OnNext(2)
+	s.OnNext(3)
+	observe := s.Observe()
+	s.OnNext(4)
+	s.OnCompleted()
+	Assert(context.Background(), t, FromChannel(observe), HasItems(2, 3, 4), HasNoError())
+}
+
+func Test_ReplaySubject_Unbounded(t *testing.T) {
+	s := ReplaySubject(0, 0)
+	s.OnNext(1)
+	s.OnNext(2)
+	s.OnNext(3)
+	s.OnCompleted()
+	Assert(context.Background(), t, s, HasItems(1, 2, 3), HasNoError())
+	Assert(context.Background(), t, s, HasItems(1, 2, 3), HasNoError())
+}
+
+func Test_ReplaySubject_Error(t *testing.T) {
+	s := ReplaySubject(0, 0)
+	s.OnNext(1)
+	s.OnError(errFoo)
+	Assert(context.Background(), t, s, HasItems(1), HasError(errFoo))
+}
+
+func Test_ReplaySubject_Window(t *testing.T) {
+	scheduler := NewTestScheduler(time.Time{})
+	s := ReplaySubject(0, time.Minute, WithClock(scheduler))
+	s.OnNext(1)
+	scheduler.AdvanceTimeBy(30 * time.Second)
+	s.OnNext(2)
+	scheduler.AdvanceTimeBy(45 * time.Second)
+	s.OnNext(3)
+	s.OnCompleted()
+	Assert(context.Background(), t, s, HasItems(2, 3), HasNoError())
+}
+
+func Test_AsyncSubject(t *testing.T) {
+	s := AsyncSubject(WithBufferedChannel(1))
+	first := s.Observe()
+	s.OnNext(1)
+	s.OnNext(2)
+	s.OnCompleted()
+	Assert(context.Background(), t, FromChannel(first), HasItems(2), HasNoError())
+	Assert(context.Background(), t, s, HasItems(2), HasNoError())
+}
+
+func Test_AsyncSubject_Empty(t *testing.T) {
+	s := AsyncSubject()
+	s.OnCompleted()
+	Assert(context.Background(), t, s, IsEmpty(), HasNoError())
+}
+
+func Test_AsyncSubject_Error(t *testing.T) {
+	s := AsyncSubject(WithBufferedChannel(1))
+	observe := s.Observe()
+	s.OnNext(1)
+	s.OnError(errFoo)
+	Assert(context.Background(), t, FromChannel(observe), IsEmpty(), HasError(errFoo))
+	Assert(context.Background(), t, s, IsEmpty(), HasError(errFoo))
+}
+
+func Test_UnicastSubject(t *testing.T) {
+	s := UnicastSubject(WithBufferedChannel(1))
+	s.OnNext(1)
+	s.OnNext(2)
+	observe := s.Observe()
+	s.OnNext(3)
+	s.OnCompleted()
+	Assert(context.Background(), t, FromChannel(observe), HasItems(1, 2, 3), HasNoError())
+	Assert(context.Background(), t, s, IsEmpty(), HasError(IllegalInputError{error: "UnicastSubject allows a single observer"}))
+}
+
+func Test_UnicastSubject_Terminated(t *testing.T) {
+	s := UnicastSubject()
+	s.OnNext(1)
+	s.OnError(errFoo)
+	Assert(context.Background(), t, s, HasItems(1), HasError(errFoo))
+}
@@ -0,0 +1,120 @@
+package rxgo
+
+import (
+	"context"
+	"sync"
+)
+
+// Subscription is a handle on the subscription of an Observer, see Observable.SubscribeAwait.
+type Subscription struct {
+	done      Disposed
+	cancel    context.CancelFunc
+	drain     chan struct{}
+	drainOnce sync.Once
+	// err is set before done is closed
+	err error
+}
+
+// Done returns a channel closed once the subscription terminates.
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns the error terminating the subscription: the error passed to the Observer, or the context error if
+// the subscription is cancelled. It returns nil if the Observer is completed or the subscription is not terminated.
+func (s *Subscription) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// Await waits for the subscription to terminate and returns Err. If ctx is done before, the context error is
+// returned, the subscription being left running.
+func (s *Subscription) Await(ctx context.Context) error {
+	select {
+	case <-s.done:
+		return s.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Cancel disposes the subscription, the items in flight being lost. The Observer is not called anymore.
+func (s *Subscription) Cancel() {
+	s.cancel()
+}
+
+// Drain stops the sources from emitting new items, lets the items in flight be processed by the downstream
+// operators, then completes the Observer. It returns once the subscription terminates.
+// If ctx is done before, the subscription is cancelled and the context error is returned.
+func (s *Subscription) Drain(ctx context.Context) error {
+	s.drainOnce.Do(func() {
+		close(s.drain)
+	})
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		<-s.done
+		return ctx.Err()
+	}
+}
+
+// drainSource returns the drain signal an operator observing iterable listens to, to stop observing it.
+// It is nil if iterable is an Observable, which either is an operator handling the drain or stops being observed
+// once drained: only the first operator stops, so that the items in flight are processed by the next ones.
+func drainSource(iterable Iterable, option Option) <-chan struct{} {
+	if _, ok := iterable.(*ObservableImpl); ok {
+		return nil
+	}
+	return option.getDrain()
+}
+
+// observeUntilDrained observes a source which does not handle the drain of a Subscription: it stops being
+// observed once drained, the returned channel being closed so that the downstream operators, including the
+// custom ones such as FlatMap or Merge, complete after processing the items in flight.
+func observeUntilDrained(iterable Iterable, drain <-chan struct{}, opts ...Option) <-chan Item {
+	option := parseOptions(opts...)
+	ctx, cancel := context.WithCancel(option.buildContext())
+	observe := iterable.Observe(append(opts[:len(opts):len(opts)], WithContext(ctx))...)
+	next := option.buildChannel()
+	go func() {
+		defer close(next)
+		defer cancel()
+		for !isDrained(drain) {
+			select {
+			case <-ctx.Done():
+				return
+			case <-drain:
+				return
+			case item, ok := <-observe:
+				// An item not taken by the downstream operator once drained is not in flight yet
+				if !ok || isDrained(drain) {
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-drain:
+					return
+				case next <- item:
+				}
+			}
+		}
+	}()
+	return next
+}
+
+// isDrained returns whether a drain signal is closed.
+func isDrained(drain <-chan struct{}) bool {
+	select {
+	case <-drain:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,175 @@
+package rxgo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Subscription_Drain(t *testing.T) {
+	ch := make(chan Item, 3)
+	var sub *Subscription
+	drained := make(chan error, 1)
+	obs := FromChannel(ch).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			if i == 1 {
+				// The item is in flight while the subscription is drained
+				go func() {
+					drained <- sub.Drain(context.Background())
+				}()
+				<-sub.drain
+			}
+			return i, nil
+		}).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i.(int) * 10, nil
+		})
+	observer := &recordingObserver{}
+	sub = obs.SubscribeAwait(observer)
+	ch <- Of(1)
+	ch <- Of(2)
+	ch <- Of(3)
+
+	assert.NoError(t, <-drained)
+	<-sub.Done()
+	events, _ := observer.recorded()
+	assert.Equal(t, []string{"next: 10", "completed"}, events)
+}
+
+func Test_Subscription_Drain_FlushesOperators(t *testing.T) {
+	ch := make(chan Item, 2)
+	var sub *Subscription
+	drained := make(chan error, 1)
+	obs := FromChannel(ch).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			if i == 2 {
+				go func() {
+					drained <- sub.Drain(context.Background())
+				}()
+				<-sub.drain
+			}
+			return i, nil
+		}).
+		BufferWithCount(10)
+	observer := &recordingObserver{}
+	sub = obs.SubscribeAwait(observer)
+	ch <- Of(1)
+	ch <- Of(2)
+
+	assert.NoError(t, <-drained)
+	events, _ := observer.recorded()
+	assert.Equal(t, []string{"next: [1 2]", "completed"}, events)
+}
+
+func Test_Subscription_Drain_CustomOperator(t *testing.T) {
+	ch := make(chan Item, 3)
+	var sub *Subscription
+	drained := make(chan error, 1)
+	obs := FromChannel(ch).
+		FlatMap(func(item Item) Observable {
+			if item.V == 1 {
+				go func() {
+					drained <- sub.Drain(context.Background())
+				}()
+				<-sub.drain
+			}
+			return Just(item.V, item.V.(int)*10)()
+		}).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i.(int) + 1, nil
+		})
+	observer := &recordingObserver{}
+	sub = obs.SubscribeAwait(observer)
+	ch <- Of(1)
+	ch <- Of(2)
+	ch <- Of(3)
+
+	assert.NoError(t, <-drained)
+	events, _ := observer.recorded()
+	assert.Equal(t, []string{"next: 2", "next: 11", "completed"}, events)
+}
+
+func Test_Subscription_Drain_Merge(t *testing.T) {
+	ch1 := make(chan Item)
+	ch2 := make(chan Item)
+	observer := &recordingObserver{}
+	sub := Merge([]Observable{FromChannel(ch1), FromChannel(ch2)}).SubscribeAwait(observer)
+	ch1 <- Of(1)
+	ch2 <- Of(2)
+
+	assert.NoError(t, sub.Drain(context.Background()))
+	events, _ := observer.recorded()
+	assert.Equal(t, "completed", events[len(events)-1])
+}
+
+func Test_Subscription_Drain_Source(t *testing.T) {
+	ch := make(chan Item)
+	observer := &recordingObserver{}
+	sub := FromChannel(ch).SubscribeAwait(observer)
+	ch <- Of(1)
+
+	assert.NoError(t, sub.Drain(context.Background()))
+	events, _ := observer.recorded()
+	assert.Equal(t, "completed", events[len(events)-1])
+}
+
+func Test_Subscription_Drain_Timeout(t *testing.T) {
+	ch := make(chan Item, 1)
+	ch <- Of(1)
+	processing := make(chan struct{})
+	obs := FromChannel(ch).Map(func(ctx context.Context, i interface{}) (interface{}, error) {
+		// The item is processed until the subscription is cancelled
+		close(processing)
+		<-ctx.Done()
+		return i, nil
+	})
+	observer := &recordingObserver{}
+	sub := obs.SubscribeAwait(observer)
+	<-processing
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, sub.Drain(ctx))
+	events, _ := observer.recorded()
+	assert.Empty(t, events)
+}
+
+func Test_Subscription_Cancel(t *testing.T) {
+	observer := &recordingObserver{}
+	sub := FromChannel(make(chan Item)).SubscribeAwait(observer)
+	sub.Cancel()
+	<-sub.Done()
+	events, _ := observer.recorded()
+	assert.Empty(t, events)
+	assert.Equal(t, context.Canceled, sub.Err())
+}
+
+func Test_Subscription_Err(t *testing.T) {
+	sub := testObservable(1, errFoo).SubscribeAwait(&recordingObserver{})
+	assert.Equal(t, errFoo, sub.Await(context.Background()))
+	assert.Equal(t, errFoo, sub.Err())
+}
+
+func Test_Subscription_Err_Completed(t *testing.T) {
+	sub := testObservable(1, 2).SubscribeAwait(&recordingObserver{})
+	assert.NoError(t, sub.Await(context.Background()))
+	assert.NoError(t, sub.Err())
+}
+
+func Test_Subscription_Err_Panic(t *testing.T) {
+	sub := testObservable(1, 2).SubscribeAwait(&panickingObserver{})
+	err := sub.Await(context.Background())
+	assert.Equal(t, "foo", err.(PanicError).Value)
+}
+
+func Test_Subscription_Await_Timeout(t *testing.T) {
+	sub := Never().SubscribeAwait(&recordingObserver{})
+	defer sub.Cancel()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, sub.Await(ctx))
+	// The subscription is still running
+	assert.NoError(t, sub.Err())
+}
@@ -0,0 +1,116 @@
+package rxgo
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestObserver records the events of an Iterable so that they can be asserted.
+// Unlike Assert, it does not block: the Iterable is observed as soon as the TestObserver is created.
+type TestObserver struct {
+	mutex     sync.Mutex
+	values    []interface{}
+	errs      []error
+	completed bool
+	done      chan struct{}
+}
+
+// NewTestObserver creates a TestObserver observing the Iterable with the given options.
+func NewTestObserver(iterable Iterable, opts ...Option) *TestObserver {
+	o := &TestObserver{
+		values: make([]interface{}, 0),
+		errs:   make([]error, 0),
+		done:   make(chan struct{}),
+	}
+	observe := iterable.Observe(opts...)
+
+	go func() {
+		defer close(o.done)
+		failed := false
+		for item := range observe {
+			o.mutex.Lock()
+			if item.Error() {
+				o.errs = append(o.errs, item.E)
+			} else {
+				o.values = append(o.values, item.V)
+			}
+			o.mutex.Unlock()
+			failed = item.Error()
+		}
+		o.mutex.Lock()
+		o.completed = !failed
+		o.mutex.Unlock()
+	}()
+	return o
+}
+
+// AwaitDone waits for the Iterable to terminate and returns false if it did not within the timeout.
+func (o *TestObserver) AwaitDone(timeout time.Duration) bool {
+	select {
+	case <-o.done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// Values returns the values received so far.
+func (o *TestObserver) Values() []interface{} {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	values := make([]interface{}, len(o.values))
+	copy(values, o.values)
+	return values
+}
+
+// Errors returns the errors received so far.
+func (o *TestObserver) Errors() []error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	errs := make([]error, len(o.errs))
+	copy(errs, o.errs)
+	return errs
+}
+
+// IsCompleted returns whether the Iterable terminated, with a last item that is not an error.
+func (o *TestObserver) IsCompleted() bool {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return o.completed
+}
+
+// AssertValues asserts the values received so far, in order.
+func (o *TestObserver) AssertValues(t *testing.T, values ...interface{}) bool {
+	if values == nil {
+		values = make([]interface{}, 0)
+	}
+	return assert.Equal(t, values, o.Values())
+}
+
+// AssertValueCount asserts the number of values received so far.
+func (o *TestObserver) AssertValueCount(t *testing.T, count int) bool {
+	return assert.Len(t, o.Values(), count)
+}
+
+// AssertError asserts that the only error received is err.
+func (o *TestObserver) AssertError(t *testing.T, err error) bool {
+	return assert.Equal(t, []error{err}, o.Errors())
+}
+
+// AssertNoError asserts that no error was received.
+func (o *TestObserver) AssertNoError(t *testing.T) bool {
+	return assert.Empty(t, o.Errors())
+}
+
+// AssertCompleted asserts that the Iterable completed.
+func (o *TestObserver) AssertCompleted(t *testing.T) bool {
+	return assert.True(t, o.IsCompleted(), "not completed")
+}
+
+// AssertNotCompleted asserts that the Iterable did not complete (yet).
+func (o *TestObserver) AssertNotCompleted(t *testing.T) bool {
+	return assert.False(t, o.IsCompleted(), "completed")
+}
@@ -0,0 +1,41 @@
+package rxgo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TestObserver(t *testing.T) {
+	o := NewTestObserver(Just(1, 2, 3)())
+	assert.True(t, o.AwaitDone(time.Second))
+	o.AssertValues(t, 1, 2, 3)
+	o.AssertValueCount(t, 3)
+	o.AssertNoError(t)
+	o.AssertCompleted(t)
+}
+
+func Test_TestObserver_Error(t *testing.T) {
+	o := NewTestObserver(testObservable(1, errFoo))
+	assert.True(t, o.AwaitDone(time.Second))
+	o.AssertValues(t, 1)
+	o.AssertError(t, errFoo)
+	o.AssertNotCompleted(t)
+}
+
+func Test_TestObserver_Empty(t *testing.T) {
+	o := NewTestObserver(Empty())
+	assert.True(t, o.AwaitDone(time.Second))
+	o.AssertValues(t)
+	o.AssertCompleted(t)
+}
+
+func Test_TestObserver_AwaitDone_Timeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	o := NewTestObserver(Never(), WithContext(ctx))
+	assert.False(t, o.AwaitDone(time.Millisecond))
+	o.AssertNotCompleted(t)
+}
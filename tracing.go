@@ -0,0 +1,142 @@
+package rxgo
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tracer starts the spans of the operators created with WithTracer.
+type Tracer interface {
+	// Start starts the span of an operator processing an item, as a child of the span held by ctx, if any.
+	// It returns a context holding the new span.
+	Start(ctx context.Context, operator string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	// RecordError records an error emitted by the operator and sets the span status.
+	RecordError(err error)
+	// End ends the span once the item is processed.
+	End()
+}
+
+// contextValue is the value of an item carrying a context, see Item.WithContext.
+type contextValue struct {
+	ctx context.Context
+	v   interface{}
+}
+
+// itemContext is the context passed to an operator processing an item carrying a context: the functions of the
+// operator look its values up first, and the items sent to dst with it carry them in turn.
+type itemContext struct {
+	context.Context
+	values context.Context
+	dst    chan<- Item
+	// span is the span started by a traced operator, recording the errors sent to dst
+	span    Span
+	forward bool
+}
+
+func (c *itemContext) Value(key interface{}) interface{} {
+	if v := c.values.Value(key); v != nil {
+		return v
+	}
+	return c.Context.Value(key)
+}
+
+// carry makes an item sent to dst carry the context.
+func (c *itemContext) carry(item Item) Item {
+	if item.E != nil {
+		if c.span != nil {
+			c.span.RecordError(item.E)
+		}
+		return item
+	}
+	if c.forward {
+		if _, ok := item.V.(contextValue); !ok {
+			item.V = contextValue{ctx: c.values, v: item.V}
+		}
+	}
+	return item
+}
+
+// withItemContext unwraps the value of an item carrying a context, returning the context to process it with.
+func withItemContext(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) (context.Context, Item) {
+	v, ok := item.V.(contextValue)
+	if !ok {
+		return ctx, item
+	}
+	item.V = v.v
+	return &itemContext{Context: ctx, values: v.ctx, dst: dst, forward: operatorOptions.forwardContexts}, item
+}
+
+// tracedOperator starts a span for each item processed by an operator. The span is started from the context
+// carried by the item, and carried by the items emitted while processing it.
+type tracedOperator struct {
+	operator
+	tracer Tracer
+	name   string
+}
+
+func traceOperator(operatorFactory func() operator, option Option) func() operator {
+	tracer := option.getTracer()
+	if tracer == nil {
+		return operatorFactory
+	}
+	return func() operator {
+		op := operatorFactory()
+		return &tracedOperator{
+			operator: op,
+			tracer:   tracer,
+			name:     operatorName(op),
+		}
+	}
+}
+
+func (op *tracedOperator) next(ctx context.Context, item Item, dst chan<- Item, operatorOptions operatorOptions) {
+	parent := context.Background()
+	if c, ok := ctx.(*itemContext); ok && c.dst == dst {
+		parent = c.values
+	}
+	spanCtx, span := op.tracer.Start(parent, op.name)
+	defer span.End()
+	// The functions passed to the operator receive the span along with the cancellation of the observer
+	op.operator.next(&itemContext{
+		Context: ctx,
+		values:  spanCtx,
+		dst:     dst,
+		span:    span,
+		forward: operatorOptions.forwardContexts,
+	}, item, dst, operatorOptions)
+}
+
+// traceCustomOperator makes a custom operator start a span for each subscription if it is created with
+// WithTracer, as it is not run item by item. The items sent to next carry the span, recording the errors.
+func traceCustomOperator(f func(ctx context.Context, next chan Item, option Option, opts ...Option), option Option) func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+	tracer := option.getTracer()
+	if tracer == nil {
+		return f
+	}
+	// The span is named after the operator calling customObservableOperator, e.g. "flatMap" for FlatMap
+	name := ""
+	if pc, _, _, ok := runtime.Caller(2); ok {
+		name = runtime.FuncForPC(pc).Name()
+		name = name[strings.LastIndexByte(name, '.')+1:]
+		r, size := utf8.DecodeRuneInString(name)
+		name = string(unicode.ToLower(r)) + name[size:]
+	}
+	return func(ctx context.Context, next chan Item, option Option, opts ...Option) {
+		spanCtx, span := tracer.Start(context.Background(), name)
+		defer span.End()
+		f(&itemContext{
+			Context: ctx,
+			values:  spanCtx,
+			dst:     next,
+			span:    span,
+			forward: option.isItemContextRequested(),
+		}, next, option, opts...)
+	}
+}
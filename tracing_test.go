@@ -0,0 +1,137 @@
+package rxgo
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type spanKey struct{}
+
+type testSpan struct {
+	name   string
+	parent *testSpan
+	errs   []error
+	ended  bool
+}
+
+// testTracer records the spans started.
+type testTracer struct {
+	mutex sync.Mutex
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, operator string) (context.Context, Span) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	parent, _ := ctx.Value(spanKey{}).(*testSpan)
+	span := &testSpan{name: operator, parent: parent}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func (s *testSpan) RecordError(err error) {
+	s.errs = append(s.errs, err)
+}
+
+func (s *testSpan) End() {
+	s.ended = true
+}
+
+func Test_WithTracer(t *testing.T) {
+	tracer := &testTracer{}
+	root := &testSpan{name: "root"}
+	ch := make(chan Item, 2)
+	ch <- Of(1).WithContext(context.WithValue(context.Background(), spanKey{}, root))
+	ch <- Of(2).WithContext(context.WithValue(context.Background(), spanKey{}, root))
+	close(ch)
+
+	var received []*testSpan
+	obs := FromChannel(ch).
+		Map(func(ctx context.Context, i interface{}) (interface{}, error) {
+			received = append(received, ctx.Value(spanKey{}).(*testSpan))
+			return i.(int) * 10, nil
+		}, WithTracer(tracer)).
+		Filter(func(interface{}) bool {
+			return true
+		}).
+		Map(func(_ context.Context, i interface{}) (interface{}, error) {
+			return i, nil
+		}, WithTracer(tracer))
+	Assert(context.Background(), t, obs, HasItems(10, 20), HasNoError())
+
+	assert.Len(t, tracer.spans, 4)
+	var first, second []*testSpan
+	for _, span := range tracer.spans {
+		assert.Equal(t, "map", span.name)
+		assert.True(t, span.ended)
+		// The spans of the first Map are children of the root span, carried by the source items
+		if span.parent == root {
+			first = append(first, span)
+		} else {
+			second = append(second, span)
+		}
+	}
+	assert.Equal(t, first, received)
+	// The spans of the second Map are children of the spans of the first one, carried through Filter
+	assert.Len(t, second, 2)
+	assert.Equal(t, first[0], second[0].parent)
+	assert.Equal(t, first[1], second[1].parent)
+}
+
+func Test_WithTracer_Error(t *testing.T) {
+	tracer := &testTracer{}
+	obs := testObservable(1, 2).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		if i == 2 {
+			return nil, errFoo
+		}
+		return i, nil
+	}, WithTracer(tracer))
+	Assert(context.Background(), t, obs, HasItems(1), HasError(errFoo))
+
+	assert.Len(t, tracer.spans, 2)
+	assert.Nil(t, tracer.spans[0].parent)
+	assert.Empty(t, tracer.spans[0].errs)
+	assert.Equal(t, []error{errFoo}, tracer.spans[1].errs)
+}
+
+func Test_WithTracer_StreamErrors(t *testing.T) {
+	obs := testObservable(1).Filter(func(interface{}) bool {
+		panic(errFoo)
+	}, WithTracer(&testTracer{}), WithStreamErrors(), WithPanicStrategy(RecoverPanic))
+	_, err := obs.BlockingFirst(context.Background())
+	assert.Equal(t, "filter", err.(StreamError).Operator)
+}
+
+func Test_WithTracer_CustomOperator(t *testing.T) {
+	tracer := &testTracer{}
+	obs := testObservable(1, 2).FlatMap(func(item Item) Observable {
+		return Just(item.V)()
+	}, WithTracer(tracer)).Map(func(ctx context.Context, i interface{}) (interface{}, error) {
+		return i, nil
+	}, WithTracer(tracer))
+	Assert(context.Background(), t, obs, HasItems(1, 2), HasNoError())
+
+	// A single span is started for the subscription to FlatMap, parent of the spans of Map
+	assert.Len(t, tracer.spans, 3)
+	flatMap := tracer.spans[0]
+	assert.Equal(t, "flatMap", flatMap.name)
+	assert.True(t, flatMap.ended)
+	for _, span := range tracer.spans[1:] {
+		assert.Equal(t, "map", span.name)
+		assert.Equal(t, flatMap, span.parent)
+	}
+}
+
+func Test_WithTracer_CustomOperator_Error(t *testing.T) {
+	tracer := &testTracer{}
+	obs := testObservable(1).FlatMap(func(item Item) Observable {
+		return Thrown(errFoo)
+	}, WithTracer(tracer))
+	Assert(context.Background(), t, obs, HasError(errFoo))
+
+	assert.Len(t, tracer.spans, 1)
+	assert.Equal(t, []error{errFoo}, tracer.spans[0].errs)
+}
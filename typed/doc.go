@@ -0,0 +1,5 @@
+// Package typed provides a type-safe API on top of RxGo Observables using Go generics.
+//
+// An Observable[T] wraps an ordinary rxgo.Observable. The conversion from one world to the other is done
+// using From and Untyped. The package requires Go 1.18 or later.
+package typed
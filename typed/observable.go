@@ -0,0 +1,214 @@
+//go:build go1.18
+// +build go1.18
+
+package typed
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reactivex/rxgo/v2"
+)
+
+// TypeMismatchError is triggered when an item cannot be converted to the expected type.
+type TypeMismatchError struct {
+	error string
+}
+
+func (e TypeMismatchError) Error() string {
+	return "type mismatch: " + e.error
+}
+
+// Item is a wrapper having either a value of type T or an error.
+type Item[T any] struct {
+	V T
+	E error
+}
+
+// Error checks if an item is an error.
+func (i Item[T]) Error() bool {
+	return i.E != nil
+}
+
+// Observer groups the callbacks notified by a typed Observable.
+// Each callback is optional.
+type Observer[T any] struct {
+	Next      func(T)
+	Err       func(error)
+	Completed func()
+}
+
+// Observable is a type-safe Observable emitting items of type T.
+type Observable[T any] struct {
+	obs rxgo.Observable
+}
+
+// From converts an untyped Observable into a typed one.
+// An item that is not of type T is converted into a TypeMismatchError.
+func From[T any](obs rxgo.Observable) Observable[T] {
+	return Observable[T]{obs: obs}
+}
+
+// Just creates a typed Observable with the provided items.
+func Just[T any](items ...T) func(opts ...rxgo.Option) Observable[T] {
+	return func(opts ...rxgo.Option) Observable[T] {
+		return From[T](rxgo.Defer([]rxgo.Producer{func(ctx context.Context, next chan<- rxgo.Item) {
+			for _, item := range items {
+				if !rxgo.Of(item).SendContext(ctx, next) {
+					return
+				}
+			}
+		}}, opts...))
+	}
+}
+
+// Untyped returns the underlying untyped Observable.
+func (o Observable[T]) Untyped() rxgo.Observable {
+	return o.obs
+}
+
+// Observe observes a typed Observable by returning its channel.
+// The channel is closed once the Observable completes or once ctx is cancelled; ctx is also propagated to the
+// parent Observable(s).
+func (o Observable[T]) Observe(ctx context.Context, opts ...rxgo.Option) <-chan Item[T] {
+	observe := o.obs.Observe(append(opts, rxgo.WithContext(ctx))...)
+	next := make(chan Item[T])
+
+	go func() {
+		defer close(next)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+				typedItem := Item[T]{E: item.E}
+				if !item.Error() {
+					typedItem.V, typedItem.E = cast[T](item.V)
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case next <- typedItem:
+				}
+			}
+		}
+	}()
+
+	return next
+}
+
+// Filter emits only those items from an Observable that pass a predicate test.
+// An item that is not of type T is emitted as a TypeMismatchError, handled according to the error strategy.
+func (o Observable[T]) Filter(apply func(T) bool, opts ...rxgo.Option) Observable[T] {
+	return From[T](o.obs.Filter(func(i interface{}) bool {
+		v, err := cast[T](i)
+		if err != nil {
+			// Forwarded to be converted into an error
+			return true
+		}
+		return apply(v)
+	}, opts...).Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		if _, err := cast[T](i); err != nil {
+			return nil, err
+		}
+		return i, nil
+	}, opts...))
+}
+
+// Skip suppresses the first n items emitted by an Observable.
+func (o Observable[T]) Skip(nth uint, opts ...rxgo.Option) Observable[T] {
+	return From[T](o.obs.Skip(nth, opts...))
+}
+
+// Take emits only the first n items emitted by an Observable.
+func (o Observable[T]) Take(nth uint, opts ...rxgo.Option) Observable[T] {
+	return From[T](o.obs.Take(nth, opts...))
+}
+
+// ForEach subscribes to the Observable and notifies the observer for each element.
+// An item that is not of type T is notified to Err as a TypeMismatchError, which stops the subscription: neither
+// Next nor Completed is called afterwards.
+func (o Observable[T]) ForEach(observer Observer[T], opts ...rxgo.Option) rxgo.Disposed {
+	// The callbacks are called sequentially by a single goroutine
+	mismatch := false
+	return o.obs.Map(func(_ context.Context, i interface{}) (interface{}, error) {
+		v, err := cast[T](i)
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	}, opts...).ForEach(func(i interface{}) {
+		if mismatch {
+			return
+		}
+		v, _ := cast[T](i)
+		if observer.Next != nil {
+			observer.Next(v)
+		}
+	}, func(err error) {
+		if mismatch {
+			return
+		}
+		if _, ok := err.(TypeMismatchError); ok {
+			mismatch = true
+		}
+		if observer.Err != nil {
+			observer.Err(err)
+		}
+	}, func() {
+		if !mismatch && observer.Completed != nil {
+			observer.Completed()
+		}
+	}, opts...)
+}
+
+// ToSlice collects all items from an Observable and emit them in a slice and an optional error.
+func (o Observable[T]) ToSlice(initialCapacity int, opts ...rxgo.Option) ([]T, error) {
+	items, err := o.obs.ToSlice(initialCapacity, opts...)
+	s := make([]T, 0, len(items))
+	for _, item := range items {
+		v, castErr := cast[T](item)
+		if castErr != nil {
+			return s, castErr
+		}
+		s = append(s, v)
+	}
+	return s, err
+}
+
+// Map transforms the items emitted by an Observable by applying a function to each item.
+func Map[T, U any](o Observable[T], apply func(context.Context, T) (U, error), opts ...rxgo.Option) Observable[U] {
+	return From[U](o.obs.Map(func(ctx context.Context, i interface{}) (interface{}, error) {
+		v, err := cast[T](i)
+		if err != nil {
+			return nil, err
+		}
+		return apply(ctx, v)
+	}, opts...))
+}
+
+// FlatMap transforms the items emitted by an Observable into Observables, then flatten the emissions from those
+// into a single Observable.
+func FlatMap[T, U any](o Observable[T], apply func(T) Observable[U], opts ...rxgo.Option) Observable[U] {
+	return From[U](o.obs.FlatMap(func(item rxgo.Item) rxgo.Observable {
+		if item.Error() {
+			return rxgo.Thrown(item.E)
+		}
+		v, err := cast[T](item.V)
+		if err != nil {
+			return rxgo.Thrown(err)
+		}
+		return apply(v).obs
+	}, opts...))
+}
+
+func cast[T any](i interface{}) (T, error) {
+	v, ok := i.(T)
+	if !ok && i != nil {
+		return v, TypeMismatchError{error: fmt.Sprintf("expected %T, got %T", v, i)}
+	}
+	return v, nil
+}
@@ -0,0 +1,141 @@
+//go:build go1.18
+// +build go1.18
+
+package typed
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/reactivex/rxgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var errFoo = errors.New("foo")
+
+func Test_Observable_Observe(t *testing.T) {
+	values := make([]int, 0)
+	for item := range Just(1, 2, 3)().Observe(context.Background()) {
+		assert.False(t, item.Error())
+		values = append(values, item.V)
+	}
+	assert.Equal(t, []int{1, 2, 3}, values)
+}
+
+func Test_Observable_Observe_TypeMismatch(t *testing.T) {
+	var errs []error
+	for item := range From[int](rxgo.Just(1, "x")()).Observe(context.Background()) {
+		if item.Error() {
+			errs = append(errs, item.E)
+		}
+	}
+	assert.Len(t, errs, 1)
+	assert.IsType(t, TypeMismatchError{}, errs[0])
+}
+
+func Test_Observable_Filter_TypeMismatch(t *testing.T) {
+	obs := From[int](rxgo.Just(1, "x", 2)()).Filter(func(i int) bool {
+		return true
+	})
+	_, err := obs.ToSlice(0)
+	assert.IsType(t, TypeMismatchError{}, err)
+
+	obs = From[int](rxgo.Just(1, "x", 2)()).Filter(func(i int) bool {
+		return true
+	}, rxgo.WithErrorStrategy(rxgo.ContinueOnError))
+	var values []int
+	var errs []error
+	for item := range obs.Observe(context.Background()) {
+		if item.Error() {
+			errs = append(errs, item.E)
+		} else {
+			values = append(values, item.V)
+		}
+	}
+	assert.Equal(t, []int{1, 2}, values)
+	assert.Len(t, errs, 1)
+	assert.IsType(t, TypeMismatchError{}, errs[0])
+}
+
+func Test_Map(t *testing.T) {
+	obs := Map(Just(1, 2, 3)(), func(_ context.Context, i int) (string, error) {
+		return strconv.Itoa(i * 10), nil
+	})
+	s, err := obs.ToSlice(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10", "20", "30"}, s)
+}
+
+func Test_Map_Error(t *testing.T) {
+	obs := Map(Just(1, 2, 3)(), func(_ context.Context, i int) (int, error) {
+		if i == 2 {
+			return 0, errFoo
+		}
+		return i, nil
+	})
+	rxgo.Assert(context.Background(), t, obs.Untyped(), rxgo.HasItems(1), rxgo.HasError(errFoo))
+}
+
+func Test_FlatMap(t *testing.T) {
+	obs := FlatMap(Just(1, 2)(), func(i int) Observable[int] {
+		return Just(i, i*10)()
+	})
+	s, err := obs.ToSlice(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 10, 2, 20}, s)
+}
+
+func Test_Observable_Filter_Take_Skip(t *testing.T) {
+	obs := Just(1, 2, 3, 4, 5, 6)().
+		Filter(func(i int) bool {
+			return i%2 == 0
+		}).
+		Skip(1).
+		Take(1)
+	s, err := obs.ToSlice(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4}, s)
+}
+
+func Test_Observable_ForEach(t *testing.T) {
+	var values []int
+	var gotErr error
+	completed := false
+	<-Just(1, 2)().ForEach(Observer[int]{
+		Next: func(i int) {
+			values = append(values, i)
+		},
+		Err: func(err error) {
+			gotErr = err
+		},
+		Completed: func() {
+			completed = true
+		},
+	})
+	assert.Equal(t, []int{1, 2}, values)
+	assert.NoError(t, gotErr)
+	assert.True(t, completed)
+}
+
+func Test_Observable_ForEach_TypeMismatch(t *testing.T) {
+	var values []int
+	var errs []error
+	completed := false
+	<-From[int](rxgo.Just(1, "a", 2)()).ForEach(Observer[int]{
+		Next: func(i int) {
+			values = append(values, i)
+		},
+		Err: func(err error) {
+			errs = append(errs, err)
+		},
+		Completed: func() {
+			completed = true
+		},
+	})
+	assert.Equal(t, []int{1}, values)
+	assert.Len(t, errs, 1)
+	assert.IsType(t, TypeMismatchError{}, errs[0])
+	assert.False(t, completed)
+}
@@ -4,8 +4,16 @@ import "context"
 
 type (
 	operatorOptions struct {
-		stop          func()
+		// stop stops the operator if the error strategy is StopOnError.
+		stop func()
+		// complete stops the operator regardless of the error strategy.
+		complete      func()
 		resetIterable func(Iterable)
+		// stopOnError is set if the error strategy is StopOnError. The runner then skips the end of an operator
+		// stopped by an error, an operator flushing what is pending in end doing it in err instead.
+		stopOnError bool
+		// forwardContexts is set if the items emitted carry the context of the item processed, see Item.WithContext.
+		forwardContexts bool
 	}
 
 	// Comparator defines a func that returns an int:
@@ -25,6 +33,14 @@ type (
 	FuncN func(...interface{}) interface{}
 	// ErrorFunc defines a function that computes a value from an error.
 	ErrorFunc func(error) interface{}
+	// ObserverInterceptor defines a function wrapping the Observer of a subscription, see
+	// RegisterObserverInterceptor and WithObserverInterceptors.
+	ObserverInterceptor func(next Observer) Observer
+	// ObserverOperator defines a custom operator, see Observable.Lift. It returns the Observer fed by the source
+	// Observable, from the downstream Observer. The context is cancelled once the subscription is terminated.
+	ObserverOperator func(ctx context.Context, downstream Observer) Observer
+	// OperatorFunc defines a function that transforms an Observable into another Observable, see Observable.Pipe.
+	OperatorFunc func(Observable) Observable
 	// Predicate defines a func that returns a bool from an input value.
 	Predicate func(interface{}) bool
 	// Marshaller defines a marshaller type (interface{} to []byte).
@@ -56,6 +72,10 @@ const (
 	Block BackpressureStrategy = iota
 	// Drop drops the message.
 	Drop
+	// Latest keeps only the latest message if the observer is not ready, the oldest pending one being dropped.
+	Latest
+	// Fail terminates the observer with a BackpressureError if it is not ready.
+	Fail
 )
 
 // OnErrorStrategy is the Observable error strategy.
@@ -69,6 +89,28 @@ const (
 	ContinueOnError
 )
 
+// PanicStrategy is the strategy applied when a function passed to an operator, or an Observer, panics.
+type PanicStrategy uint32
+
+const (
+	// RecoverPanic is the default panic strategy.
+	// The panic is recovered and converted into a PanicError, handled like any other error.
+	RecoverPanic PanicStrategy = iota
+	// PropagatePanic means the panic is not recovered.
+	PropagatePanic
+)
+
+// TimeoutStrategy defines when the timespan of Timeout and TimeoutWith is measured.
+type TimeoutStrategy uint32
+
+const (
+	// TimeoutEachItem is the default timeout strategy.
+	// The timespan is measured from the subscription, then from each item.
+	TimeoutEachItem TimeoutStrategy = iota
+	// TimeoutFirstItem means only the first item has to be emitted within the timespan.
+	TimeoutFirstItem
+)
+
 // ObservationStrategy defines the strategy to consume from an Observable.
 type ObservationStrategy uint32
 
@@ -1,7 +1,12 @@
 package rxgo
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"errors"
+	"io"
+	"strconv"
+	"sync/atomic"
 )
 
 type testStruct struct {
@@ -32,3 +37,90 @@ func channelValue(items ...interface{}) chan Item {
 func testObservable(items ...interface{}) Observable {
 	return FromChannel(channelValue(items...))
 }
+
+// testDriver is a database/sql driver whose query "n" returns the rows 1 to n, then fails with errFoo if n is
+// negative.
+type testDriver struct {
+	closedRows int32
+}
+
+var testSQLDriver = &testDriver{}
+
+func init() {
+	sql.Register("rxgo", testSQLDriver)
+}
+
+func (d *testDriver) Open(string) (driver.Conn, error) {
+	return testConn{driver: d}, nil
+}
+
+type testConn struct {
+	driver *testDriver
+}
+
+func (c testConn) Prepare(query string) (driver.Stmt, error) {
+	n, err := strconv.Atoi(query)
+	if err != nil {
+		return nil, err
+	}
+	return testStmt{driver: c.driver, n: n}, nil
+}
+
+func (testConn) Close() error {
+	return nil
+}
+
+func (testConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+type testStmt struct {
+	driver *testDriver
+	n      int
+}
+
+func (testStmt) Close() error {
+	return nil
+}
+
+func (testStmt) NumInput() int {
+	return 0
+}
+
+func (testStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s testStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &testRows{stmt: s}, nil
+}
+
+type testRows struct {
+	stmt    testStmt
+	current int
+}
+
+func (*testRows) Columns() []string {
+	return []string{"n"}
+}
+
+func (r *testRows) Close() error {
+	atomic.AddInt32(&r.stmt.driver.closedRows, 1)
+	return nil
+}
+
+func (r *testRows) Next(dest []driver.Value) error {
+	n := r.stmt.n
+	if n < 0 {
+		n = -n
+	}
+	if r.current == n {
+		if r.stmt.n < 0 {
+			return errFoo
+		}
+		return io.EOF
+	}
+	r.current++
+	dest[0] = int64(r.current)
+	return nil
+}